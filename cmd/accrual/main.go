@@ -9,6 +9,9 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Rule schedules name IANA time zones, which must resolve even where the
+	// system has no zoneinfo.
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -16,6 +19,7 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/config"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/handlers"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/middlewares"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
@@ -49,12 +53,19 @@ func main() {
 
 	goodRewardRepository := repositories.NewGoodRewardRepository(dbPool)
 	registeredOrdersRepository := repositories.NewRegisteredOrdersRepository(dbPool)
+	apiKeyRepository := repositories.NewAPIKeyRepository(dbPool)
+	merchantRepository := repositories.NewMerchantRepository(dbPool)
+	campaignRepository := repositories.NewCampaignRepository(dbPool)
 
 	goodRewardsService := services.NewGoodRewardsService(goodRewardRepository)
-	accrualOrdersService := services.NewAccrualOrdersService(registeredOrdersRepository)
+	accrualOrdersService := services.NewAccrualOrdersService(registeredOrdersRepository, goodRewardRepository)
+	apiKeysService := services.NewAPIKeysService(apiKeyRepository)
+	merchantsService := services.NewMerchantsService(merchantRepository)
+	campaignsService := services.NewCampaignsService(campaignRepository)
 
 	goodsHandler := handlers.NewGoodsHandler(goodRewardsService)
 	accrualOrdersHandler := handlers.NewAccrualOrdersHandler(accrualOrdersService)
+	campaignsHandler := handlers.NewCampaignsHandler(campaignsService)
 
 	workersCtx, workersStopCtx := context.WithCancel(context.Background())
 
@@ -64,9 +75,13 @@ func main() {
 	)
 	go calculateOrderAccrualWorker.Start(workersCtx)
 
+	router := makeRouter(
+		appConfig, apiKeysService, merchantsService, goodsHandler, campaignsHandler, accrualOrdersHandler,
+	)
+
 	server := &http.Server{
 		Addr:    appConfig.RunAddress,
-		Handler: makeRouter(appConfig, goodsHandler, accrualOrdersHandler),
+		Handler: router,
 	}
 
 	log.Println("Accrual server is running on", appConfig.RunAddress)
@@ -110,9 +125,15 @@ func main() {
 // @version 1.0
 // @description Accrual service responsible for calculating accrual for registered orders
 // @BasePath /api
+// @securityDefinitions.apikey APIKey
+// @in header
+// @name X-API-Key
 func makeRouter(
 	appConfig *config.AccrualConfig,
+	apiKeysService *services.APIKeysService,
+	merchantsService *services.MerchantsService,
 	goodsHandler *handlers.GoodsHandler,
+	campaignsHandler *handlers.CampaignsHandler,
 	accrualOrdersHandler *handlers.AccrualOrdersHandler,
 ) http.Handler {
 	router := chi.NewRouter()
@@ -120,16 +141,71 @@ func makeRouter(
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.Logger)
 
+	authenticate := middlewares.NewAPIKeyMiddleware(apiKeysService)
+	requireScope := middlewares.RequireScope
+
+	if appConfig.APIKeysDisabled {
+		log.Println("WARNING: API keys are disabled, every route is open to anyone")
+
+		authenticate = func(next http.Handler) http.Handler { return next }
+		requireScope = func(string) func(next http.Handler) http.Handler { return authenticate }
+	}
+
+	merchant := middlewares.NewMerchantMiddleware(merchantsService)
+
 	router.Route("/api/goods", func(r chi.Router) {
-		r.Post("/", goodsHandler.SaveNewGoodReward)
+		r.Use(authenticate)
+		r.Use(merchant)
+
+		r.With(requireScope(domain.RulesReadScope)).Get("/", goodsHandler.GetGoodRewards)
+		r.With(requireScope(domain.RulesWriteScope)).Post("/", goodsHandler.SaveNewGoodReward)
+		r.With(requireScope(domain.RulesWriteScope)).Post("/import", goodsHandler.ImportGoodRewards)
+		r.With(requireScope(domain.RulesReadScope)).Get("/export", goodsHandler.ExportGoodRewards)
+		r.With(requireScope(domain.RulesReadScope)).Get("/{id}", goodsHandler.GetGoodReward)
+		r.With(requireScope(domain.RulesWriteScope)).Put("/{id}", goodsHandler.ReplaceGoodReward)
+		r.With(requireScope(domain.RulesWriteScope)).Patch("/{id}", goodsHandler.UpdateGoodReward)
+		r.With(requireScope(domain.RulesWriteScope)).Delete("/{id}", goodsHandler.DeleteGoodReward)
+		r.With(requireScope(domain.RulesReadScope)).Get("/{id}/versions", goodsHandler.GetGoodRewardVersions)
+		r.With(requireScope(domain.RulesReadScope)).Get("/{id}/versions/diff", goodsHandler.DiffGoodRewardVersions)
+	})
+
+	router.Route("/api/campaigns", func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(merchant)
+
+		r.With(requireScope(domain.RulesReadScope)).Get("/", campaignsHandler.GetCampaigns)
+		r.With(requireScope(domain.RulesWriteScope)).Post("/", campaignsHandler.CreateCampaign)
+		r.With(requireScope(domain.RulesReadScope)).Get("/{id}", campaignsHandler.GetCampaign)
+		r.With(requireScope(domain.RulesWriteScope)).Patch("/{id}", campaignsHandler.UpdateCampaign)
 	})
 
 	router.Route("/api/orders", func(r chi.Router) {
-		r.Get("/{orderID}", middlewares.NewRateLimit(
+		r.Use(authenticate)
+		r.Use(merchant)
+
+		r.With(requireScope(domain.OrdersReadScope)).Get("/{orderID}", middlewares.NewRateLimit(
 			2000,
 			time.Minute*1,
 		)(http.HandlerFunc(accrualOrdersHandler.GetRegisteredOrderInfo)))
-		r.Post("/", accrualOrdersHandler.RegisterOrderForAccrual)
+		r.With(requireScope(domain.OrdersReadScope)).Get("/{orderID}/breakdown", accrualOrdersHandler.GetOrderBreakdown)
+		r.With(requireScope(domain.OrdersWriteScope)).Post("/", accrualOrdersHandler.RegisterOrderForAccrual)
+		r.With(requireScope(domain.OrdersAdminScope)).Post("/recalculate", accrualOrdersHandler.RecalculateOrders)
+	})
+
+	// Adjustments of every merchant are read in one feed, so there is no
+	// merchant middleware here.
+	router.Route("/api/adjustments", func(r chi.Router) {
+		r.Use(authenticate)
+
+		r.With(requireScope(domain.OrdersReadScope)).Get("/", accrualOrdersHandler.GetAdjustments)
+	})
+
+	router.Route("/api/accrual", func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(merchant)
+
+		// Quotes come from the checkout, which registers the order afterwards.
+		r.With(requireScope(domain.OrdersWriteScope)).Post("/quote", accrualOrdersHandler.QuoteOrder)
 	})
 
 	router.Get("/swagger/*", httpSwagger.Handler(
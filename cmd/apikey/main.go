@@ -0,0 +1,161 @@
+// Command apikey manages the API keys of the accrual service.
+//
+//	go run ./cmd/apikey create -name shop -scopes rules:read,rules:write,orders:write -merchant shop
+//	go run ./cmd/apikey list
+//	go run ./cmd/apikey revoke -id <key id>
+//
+// The key printed by create is shown only once; the database keeps a hash.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/services"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
+)
+
+const usage = `usage: apikey <command> [flags]
+
+commands:
+  create -name <name> -scopes <scope,...> [-merchant <code>]   issue a new key
+  list                                                         list every key
+  revoke -id <key id>                                          revoke a key
+
+scopes: rules:read, rules:write, orders:write, orders:read, orders:admin
+
+A key created without -merchant is a platform key: it may act for any merchant
+named in the X-Merchant header.
+`
+
+func main() {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env provided")
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	databaseURI := command.String("d", os.Getenv("DATABASE_URI"), "Database uri")
+	name := command.String("name", "", "Who the key is for")
+	scopes := command.String("scopes", "", "Comma separated scopes")
+	id := command.String("id", "", "ID of the key")
+	merchantCode := command.String("merchant", "", "Code of the merchant the key is bound to")
+
+	if err := command.Parse(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+
+	dbPool, err := postgresql.InitPool(*databaseURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dbPool.Close()
+
+	ctx := context.Background()
+	service := services.NewAPIKeysService(repositories.NewAPIKeyRepository(dbPool))
+	merchantsService := services.NewMerchantsService(repositories.NewMerchantRepository(dbPool))
+
+	switch command.Name() {
+	case "create":
+		var merchantID *int
+
+		if *merchantCode != "" {
+			merchant, err := merchantsService.GetByCode(ctx, *merchantCode)
+			if err != nil {
+				log.Fatalf("can not find merchant %q: %v", *merchantCode, err)
+			}
+
+			merchantID = &merchant.ID
+		}
+
+		issued, err := service.Create(ctx, *name, merchantID, strings.Split(*scopes, ","))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("id:     %s\nscopes: %s\nkey:    %s\n", issued.ID, strings.Join(issued.Scopes, ","), issued.Key)
+	case "list":
+		keys, err := service.List(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		merchants, err := merchantsService.List(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printKeys(keys, merchants)
+	case "revoke":
+		if err := service.Revoke(ctx, *id); err != nil {
+			log.Fatalf("can not revoke key %q: %v", *id, err)
+		}
+
+		fmt.Printf("key %s is revoked\n", *id)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printKeys(keys []domain.APIKey, merchants []domain.Merchant) {
+	codes := make(map[int]string, len(merchants))
+
+	for _, merchant := range merchants {
+		codes[merchant.ID] = merchant.Code
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tNAME\tMERCHANT\tSCOPES\tCREATED\tLAST USED\tSTATUS")
+
+	for _, key := range keys {
+		status := "active"
+		if !key.IsActive() {
+			status = "revoked"
+		}
+
+		merchant := "*"
+		if key.MerchantID != nil {
+			merchant = codes[*key.MerchantID]
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID,
+			key.Name,
+			merchant,
+			strings.Join(key.Scopes, ","),
+			key.CreatedAt.UTC().Format(time.DateTime),
+			formatOptionalTime(key.LastUsedAt),
+			status,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.UTC().Format(time.DateTime)
+}
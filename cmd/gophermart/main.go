@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,7 +17,9 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/config"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/handlers"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/middlewares"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/services"
@@ -36,6 +39,21 @@ func main() {
 	appConfig := &config.GophermartConfig{}
 	appConfig.Parse()
 
+	tokenManager, err := makeTokenManager(appConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	passwordPolicy := domain.PasswordPolicy{
+		MinLength:     appConfig.PasswordMinLength,
+		RequireLetter: appConfig.PasswordNeedLetter,
+		RequireDigit:  appConfig.PasswordNeedDigit,
+		RejectLogin:   appConfig.PasswordRejectLogin,
+	}
+	if err := passwordPolicy.Check(); err != nil {
+		log.Fatal(err)
+	}
+
 	dbPool, err := postgresql.InitPool(appConfig.DatabaseURI)
 	if err != nil {
 		log.Panic(err)
@@ -50,26 +68,75 @@ func main() {
 	userRepository := repositories.NewUserRepository(dbPool)
 	balanceActionsRepository := repositories.NewBalanceActionsRepository(dbPool)
 	userOrderRepository := repositories.NewUserOrderRepository(dbPool)
+	sessionRepository := repositories.NewSessionRepository(dbPool)
+	loginThrottleRepository := repositories.NewLoginThrottleRepository(dbPool)
+	passwordResetRepository := repositories.NewPasswordResetRepository(dbPool)
 
-	userService := services.NewUserService(userRepository, balanceActionsRepository)
+	userService := services.NewUserService(userRepository, balanceActionsRepository, appConfig.PointsExpiringSoon, passwordPolicy)
 	ordersService := services.NewOrdersService(userOrderRepository)
 	withdrawalService := services.NewWithdrawalsService(balanceActionsRepository)
+	holdsService := services.NewHoldsService(balanceActionsRepository, appConfig.HoldTTL)
+	reversalsService := services.NewReversalsService(balanceActionsRepository, userOrderRepository)
+	statementService := services.NewStatementService(balanceActionsRepository)
+	sessionsService := services.NewSessionsService(sessionRepository, userRepository, tokenManager, appConfig.AccessTokenTTL, appConfig.RefreshTokenTTL)
+	loginThrottlePolicy := domain.DefaultLoginThrottlePolicy()
+	loginThrottlePolicy.LockoutThreshold = appConfig.LoginMaxFailures
+	loginThrottlePolicy.LockoutDuration = appConfig.LoginLockout
+	loginThrottleService := services.NewLoginThrottleService(loginThrottleRepository, loginThrottlePolicy)
+	passwordService := services.NewPasswordService(
+		userRepository,
+		passwordResetRepository,
+		sessionsService,
+		passwordPolicy,
+		appConfig.PasswordResetTTL,
+	)
+	adminService := services.NewAdminService(
+		userRepository,
+		balanceActionsRepository,
+		userOrderRepository,
+		userService,
+		sessionsService,
+		loginThrottleRepository,
+		appConfig.PointsTTL,
+	)
 
-	authHandler := handlers.NewAuthHandler(userService)
+	authHandler := handlers.NewAuthHandler(userService, sessionsService, loginThrottleService)
 	balanceHandler := handlers.NewBalanceHandler(userService, withdrawalService)
 	ordersHandler := handlers.NewOrdersHandler(ordersService)
+	holdsHandler := handlers.NewHoldsHandler(holdsService)
+	reversalsHandler := handlers.NewReversalsHandler(reversalsService)
+	statementHandler := handlers.NewStatementHandler(statementService)
+	jwksHandler := handlers.NewJWKSHandler(tokenManager)
+	adminHandler := handlers.NewAdminHandler(adminService)
+	passwordHandler := handlers.NewPasswordHandler(passwordService, sessionsService)
 
 	workersCtx, workersStopCtx := context.WithCancel(context.Background())
 
 	orderAccrualCheckingWorker := workers.NewOrderAccrualCheckingWorker(
 		userOrderRepository,
 		appConfig.AccrualSystemAddress,
+		appConfig.AccrualAPIKey,
+		appConfig.PointsTTL,
 	)
 	go orderAccrualCheckingWorker.Start(workersCtx)
 
+	accrualAdjustmentsWorker := workers.NewAccrualAdjustmentsWorker(
+		userOrderRepository,
+		appConfig.AccrualSystemAddress,
+		appConfig.AccrualAPIKey,
+		appConfig.PointsTTL,
+	)
+	go accrualAdjustmentsWorker.Start(workersCtx)
+
+	holdExpirationWorker := workers.NewHoldExpirationWorker(balanceActionsRepository)
+	go holdExpirationWorker.Start(workersCtx)
+
+	pointsExpirationWorker := workers.NewPointsExpirationWorker(balanceActionsRepository)
+	go pointsExpirationWorker.Start(workersCtx)
+
 	server := &http.Server{
 		Addr:    appConfig.RunAddress,
-		Handler: makeRouter(appConfig, authHandler, balanceHandler, ordersHandler),
+		Handler: makeRouter(appConfig, tokenManager, sessionsService, authHandler, balanceHandler, ordersHandler, holdsHandler, reversalsHandler, statementHandler, jwksHandler, adminHandler, passwordHandler),
 	}
 
 	log.Println("Gophermart server is running on", appConfig.RunAddress)
@@ -109,18 +176,52 @@ func main() {
 	log.Println("graceful shutdown server successfully")
 }
 
+// makeTokenManager signs access tokens with the key file when one is given.
+// Otherwise it falls back to the shared HS256 secret, which must not be the
+// well known default outside of dev mode.
+func makeTokenManager(appConfig *config.GophermartConfig) (*jwt.Manager, error) {
+	if appConfig.JWTSigningKeyFile != "" {
+		return jwt.LoadManager(appConfig.JWTSigningKeyFile, appConfig.JWTVerificationKeys)
+	}
+
+	if len(appConfig.JWTVerificationKeys) > 0 {
+		return nil, errors.New("JWT verification keys require a JWT signing key")
+	}
+
+	secret := appConfig.JWTSecret
+
+	if secret == "" || secret == jwt.InsecureDefaultSecret {
+		if !appConfig.Dev {
+			return nil, errors.New("refusing to start with the insecure default JWT secret, set JWT_SIGNING_KEY_FILE or JWT_SECRET, or enable dev mode")
+		}
+
+		log.Println("WARNING: signing access tokens with the insecure default JWT secret")
+		secret = jwt.InsecureDefaultSecret
+	}
+
+	return jwt.NewHMACManager(secret), nil
+}
+
 // @title Gophermart Loyalty Service
 // @version 1.0
 // @description Gophermart Loyalty Service responsible for saving user orders, saving user balance and withdraw balance
-// @BasePath /api/user
+// @BasePath /api
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
 func makeRouter(
 	appConfig *config.GophermartConfig,
+	tokenManager *jwt.Manager,
+	sessionsService *services.SessionsService,
 	authHandler *handlers.AuthHandler,
 	balanceHandler *handlers.BalanceHandler,
 	ordersHandler *handlers.OrdersHandler,
+	holdsHandler *handlers.HoldsHandler,
+	reversalsHandler *handlers.ReversalsHandler,
+	statementHandler *handlers.StatementHandler,
+	jwksHandler *handlers.JWKSHandler,
+	adminHandler *handlers.AdminHandler,
+	passwordHandler *handlers.PasswordHandler,
 ) http.Handler {
 	router := chi.NewRouter()
 
@@ -132,19 +233,55 @@ func makeRouter(
 
 		publicRouter.Post("/register", authHandler.Register)
 		publicRouter.Post("/login", authHandler.Login)
+		publicRouter.Post("/token/refresh", authHandler.RefreshToken)
+		publicRouter.Post("/password/reset", passwordHandler.ResetPassword)
 	})
 
 	router.Group(func(authRouter chi.Router) {
-		authRouter.Use(middlewares.AuthMiddleware)
+		authRouter.Use(middlewares.NewAuthMiddleware(tokenManager, sessionsService))
 		authRouter.Use(middleware.Logger)
 		authRouter.Use(middleware.Compress(5, "gzip"))
 
+		authRouter.Post("/logout", authHandler.Logout)
+		authRouter.Post("/password", passwordHandler.ChangePassword)
+
 		authRouter.Get("/orders", ordersHandler.GetOrders)
 		authRouter.Post("/orders", ordersHandler.RegisterOrder)
+		authRouter.Get("/orders/{orderID}", ordersHandler.GetOrder)
 
 		authRouter.Get("/balance", balanceHandler.GetUserBalance)
 		authRouter.Post("/balance/withdraw", balanceHandler.WithdrawBalance)
+		authRouter.Post("/balance/holds", holdsHandler.HoldBalance)
+		authRouter.Post("/balance/holds/{holdID}/capture", holdsHandler.CaptureHold)
+		authRouter.Post("/balance/holds/{holdID}/release", holdsHandler.ReleaseHold)
 		authRouter.Get("/withdrawals", balanceHandler.GetWithdrawalHistory)
+		authRouter.Get("/statement", statementHandler.GetStatement)
+	})
+
+	router.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
+	router.Route("/api/admin", func(adminRouter chi.Router) {
+		adminRouter.Use(middlewares.NewAuthMiddleware(tokenManager, sessionsService))
+		adminRouter.Use(middlewares.RequireRoles(domain.SupportRole, domain.AdminRole))
+		adminRouter.Use(middleware.Logger)
+
+		adminRouter.Get("/users", adminHandler.FindUser)
+		adminRouter.Get("/users/{userID}", adminHandler.GetUser)
+		adminRouter.Get("/users/{userID}/balance", adminHandler.GetUserBalance)
+		adminRouter.Post("/users/{userID}/lock", adminHandler.LockUser)
+		adminRouter.Post("/users/{userID}/unlock", adminHandler.UnlockUser)
+		adminRouter.Delete("/users/{userID}/login-lockout", adminHandler.ClearLoginLockout)
+		adminRouter.Post("/orders/{orderID}/requeue", adminHandler.RequeueOrder)
+		adminRouter.Post("/orders/{orderID}/clawback", reversalsHandler.ClawbackOrderAccrual)
+		adminRouter.Post("/withdrawals/{withdrawalID}/refund", reversalsHandler.RefundWithdrawal)
+
+		adminRouter.Group(func(adminOnlyRouter chi.Router) {
+			adminOnlyRouter.Use(middlewares.RequireRoles(domain.AdminRole))
+
+			adminOnlyRouter.Post("/users/{userID}/balance/adjustments", adminHandler.AdjustBalance)
+			adminOnlyRouter.Put("/users/{userID}/role", adminHandler.SetUserRole)
+			adminOnlyRouter.Post("/users/{userID}/password-reset", passwordHandler.IssuePasswordReset)
+		})
 	})
 
 	router.Mount("/api/user", router)
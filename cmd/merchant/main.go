@@ -0,0 +1,97 @@
+// Command merchant manages the merchants of the accrual service. Every
+// merchant has its own reward rules and order numbers.
+//
+//	go run ./cmd/merchant create -code shop -name "Corner Shop"
+//	go run ./cmd/merchant list
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/services"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
+)
+
+const usage = `usage: merchant <command> [flags]
+
+commands:
+  create -code <code> [-name <name>]   add a merchant
+  list                                 list every merchant
+
+codes are lower case letters, digits, "-" and "_", at most 64 characters
+`
+
+func main() {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env provided")
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	databaseURI := command.String("d", os.Getenv("DATABASE_URI"), "Database uri")
+	code := command.String("code", "", "Code of the merchant, sent in the X-Merchant header")
+	name := command.String("name", "", "Display name, the code by default")
+
+	if err := command.Parse(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+
+	dbPool, err := postgresql.InitPool(*databaseURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dbPool.Close()
+
+	ctx := context.Background()
+	service := services.NewMerchantsService(repositories.NewMerchantRepository(dbPool))
+
+	switch command.Name() {
+	case "create":
+		merchant, err := service.Create(ctx, *code, *name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("id:   %d\ncode: %s\nname: %s\n", merchant.ID, merchant.Code, merchant.Name)
+	case "list":
+		merchants, err := service.List(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(w, "ID\tCODE\tNAME\tCREATED")
+
+		for _, merchant := range merchants {
+			fmt.Fprintf(
+				w,
+				"%d\t%s\t%s\t%s\n",
+				merchant.ID,
+				merchant.Code,
+				merchant.Name,
+				merchant.CreatedAt.UTC().Format(time.DateTime),
+			)
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
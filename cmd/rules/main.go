@@ -0,0 +1,169 @@
+// Command rules imports and exports the reward rules of a merchant in bulk,
+// the same way as the import and export endpoints of the accrual service.
+//
+//	go run ./cmd/rules export -merchant shop -file rules.csv
+//	go run ./cmd/rules import -merchant shop -file rules.csv -dry-run
+//	go run ./cmd/rules import -merchant shop -file rules.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/joho/godotenv"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/rulefile"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/services"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
+)
+
+const usage = `usage: rules <command> [flags]
+
+commands:
+  import -merchant <code> [-file <path>] [-format csv|json] [-dry-run]   create or replace rules
+  export -merchant <code> [-file <path>] [-format csv|json]              write every rule
+
+The file is read from stdin or written to stdout when -file is not given. The
+format is taken from the file extension, csv by default. An import saves every
+rule or none: when a row fails, the report lists what is wrong and nothing is
+saved.
+`
+
+func main() {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env provided")
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	databaseURI := command.String("d", os.Getenv("DATABASE_URI"), "Database uri")
+	merchantCode := command.String("merchant", "", "Code of the merchant the rules belong to")
+	path := command.String("file", "", "Rule file, stdin or stdout by default")
+	format := command.String("format", "", "csv or json, from the file extension by default")
+	dryRun := command.Bool("dry-run", false, "Check the file without saving anything")
+
+	if err := command.Parse(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*path), ".")
+	}
+
+	if *format == "" {
+		*format = rulefile.CSVFormat
+	}
+
+	if !rulefile.IsValidFormat(*format) {
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	dbPool, err := postgresql.InitPool(*databaseURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dbPool.Close()
+
+	ctx := context.Background()
+	service := services.NewGoodRewardsService(repositories.NewGoodRewardRepository(dbPool))
+	merchantsService := services.NewMerchantsService(repositories.NewMerchantRepository(dbPool))
+
+	merchant, err := merchantsService.GetByCode(ctx, *merchantCode)
+	if err != nil {
+		log.Fatalf("can not find merchant %q: %v", *merchantCode, err)
+	}
+
+	switch command.Name() {
+	case "import":
+		var reader io.Reader = os.Stdin
+
+		if *path != "" {
+			file, err := os.Open(*path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer file.Close()
+
+			reader = file
+		}
+
+		rows, err := rulefile.Read(*format, reader)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		report, err := service.ImportGoodRewards(ctx, merchant.ID, rows, *dryRun)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := printReport(report); err != nil {
+			log.Fatal(err)
+		}
+
+		if report.Failed > 0 {
+			os.Exit(1)
+		}
+	case "export":
+		rewards, err := service.ExportGoodRewards(ctx, merchant.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var writer io.Writer = os.Stdout
+
+		if *path != "" {
+			file, err := os.Create(*path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer file.Close()
+
+			writer = file
+		}
+
+		if err := rulefile.Write(*format, writer, rewards); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// printReport prints the totals of an import and the rows that failed.
+func printReport(report *domain.GoodRewardImportReport) error {
+	fmt.Printf(
+		"imported: %t\ncreated: %d\nupdated: %d\nunchanged: %d\nfailed: %d\n",
+		report.Imported, report.Created, report.Updated, report.Unchanged, report.Failed,
+	)
+
+	if report.Failed == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "\nROW\tERROR")
+
+	for _, row := range report.Rows {
+		if row.Action == domain.FailedImportAction {
+			fmt.Fprintf(w, "%d\t%s\n", row.Row, row.Error)
+		}
+	}
+
+	return w.Flush()
+}
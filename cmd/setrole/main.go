@@ -0,0 +1,63 @@
+// Command setrole changes the role of a gophermart user. It is how the first
+// admin gets appointed; after that roles are managed through the admin API.
+//
+//	go run ./cmd/setrole -d "$DATABASE_URI" -login alice -role admin
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/repositories"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
+)
+
+func main() {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env provided")
+	}
+
+	databaseURI := flag.String("d", os.Getenv("DATABASE_URI"), "Database uri")
+	login := flag.String("login", "", "Login of the user")
+	role := flag.String("role", "", "New role: customer, support or admin")
+	flag.Parse()
+
+	if *login == "" || !domain.IsValidRole(*role) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dbPool, err := postgresql.InitPool(*databaseURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dbPool.Close()
+
+	ctx := context.Background()
+
+	userRepository := repositories.NewUserRepository(dbPool)
+	sessionRepository := repositories.NewSessionRepository(dbPool)
+
+	user, err := userRepository.GetByLogin(ctx, *login)
+	if err != nil {
+		log.Fatalf("can not find user %q: %v", *login, err)
+	}
+
+	user, err = userRepository.SetRole(ctx, user.ID, *role)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Ending the sessions makes the new role apply on the next login.
+	if err := sessionRepository.RevokeAllByUserID(ctx, user.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("user %s (id %d) is now %s\n", user.Login, user.ID, user.Role)
+}
@@ -15,8 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/goods": {
+        "/accrual/quote": {
             "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Calculates what an order of the goods would earn without registering it, the same way\nregistered orders are calculated. goods lists every good with the rules it matched and what\neach gave, order what order-level rules gave. The result holds as long as the rules do.",
                 "consumes": [
                     "application/json"
                 ],
@@ -24,25 +30,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "order"
                 ],
-                "summary": "Save new good reward",
+                "summary": "Quote order accrual",
                 "parameters": [
                     {
-                        "description": "Add new Good Reward",
+                        "description": "Goods to quote",
                         "name": "dto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.saveNewGoodRewardBody"
+                            "$ref": "#/definitions/handlers.quoteOrderBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.GoodReward"
+                            "$ref": "#/definitions/domain.AccrualBreakdown"
                         }
                     },
                     "400": {
@@ -51,12 +63,72 @@ const docTemplate = `{
                             "$ref": "#/definitions/httputils.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Lists what recalculations changed in the accrual of processed orders, oldest first.\nPass the id of the last adjustment seen as after to get the next ones; the list is empty\nwhen there are none. Keys bound to a merchant only see the adjustments of that merchant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get accrual adjustments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of the last adjustment seen, 0 by default",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default, 500 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AccrualAdjustment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/httputils.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -66,8 +138,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Get campaigns",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Campaign"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
             "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "A campaign groups reward rules: they only apply while it is enabled and within [valid_from, valid_to).",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,25 +207,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "campaigns"
                 ],
-                "summary": "Register order for accrual",
+                "summary": "Create campaign",
                 "parameters": [
                     {
-                        "description": "Register Order for accrual",
+                        "description": "New campaign",
                         "name": "dto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.registerOrderForAccrualBody"
+                            "$ref": "#/definitions/handlers.createCampaignBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.RegisteredOrder"
+                            "$ref": "#/definitions/domain.Campaign"
                         }
                     },
                     "400": {
@@ -102,6 +240,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/httputils.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -117,33 +261,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{orderID}": {
+        "/campaigns/{id}": {
             "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "campaigns"
                 ],
-                "summary": "Get registered order info",
+                "summary": "Get campaign",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "orderID",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.getRegisteredOrderInfoResponse"
+                            "$ref": "#/definitions/domain.Campaign"
                         }
                     },
-                    "204": {
-                        "description": "No Content"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -152,75 +322,1658 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "domain.GoodReward": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "match": {
-                    "type": "string"
-                },
-                "reward": {
-                    "type": "number"
-                },
-                "reward_type": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.OrderGood": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                }
-            }
-        },
-        "domain.RegisteredOrder": {
-            "type": "object",
-            "properties": {
-                "accrual": {
-                    "type": "number"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "goods": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.OrderGood"
+            },
+            "patch": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Changes only the fields present in the body, {\"disabled\": true} stops every rule of the campaign.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Update campaign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateCampaignBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
                     }
-                },
-                "order_id": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "handlers.getRegisteredOrderInfoResponse": {
-            "type": "object",
-            "properties": {
-                "accrual": {
-                    "type": "number"
-                },
-                "order": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+                }
+            }
+        },
+        "/goods": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "One page of the merchant rules as {\"items\": [...], \"next_cursor\": \"...\"},\nnext_cursor is null on the last page. Deleted rules are not listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Get good rewards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the match text, case insensitive",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only rules of this campaign",
+                        "name": "campaign",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default, 500 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ACTIVE",
+                            "DISABLED"
+                        ],
+                        "type": "string",
+                        "description": "Rule status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before, RFC 3339 or YYYY-MM-DD (the whole day)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction by creation time, asc by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.goodRewardsPageForResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "match_type decides how match is compared with good descriptions: substring (default),\nexact, prefix, word (not part of a longer word) or regex (RE2 syntax, unanchored).\nignore_case compares both in lower case. match_field picks what match is compared with:\nthe description (default), or the sku or category of goods, which are only matched exactly.\nThe rule applies to purchases made in [valid_from, valid_to) and within schedule, whose days\nare 0 (Sunday) to 6 and hours [from_hour, to_hour) in timezone (UTC by default); hours past\nmidnight wrap. A rule of a campaign also needs the campaign to be enabled and valid.\nreward_type % and pt reward each matching good. order_pt and order_% reward the basket of\nmatching goods once per order (an empty match takes every good) when it reaches min_basket;\ntiered_pt gives the points of the highest tier reached and tiered_% each tier's percent of\nthe basket part between its threshold and the next one. Tiered rules have no reward.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Save new good reward",
+                "parameters": [
+                    {
+                        "description": "Add new Good Reward",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.saveNewGoodRewardBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodReward"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/export": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Every rule that is not deleted, in the format the import reads, so the file can be edited\nand imported again.",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Export good rewards",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "File format, csv by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rule file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/import": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Creates or replaces many rules at once from a CSV file with a header row or a JSON array,\nwith the fields of the rule API; in CSV, tiers and schedule are JSON cells. A rule with an id\nreplaces that rule, one without replaces the rule with the same match_field, match, match_type\nand ignore_case (per-good and order rules apart), or is created. Every row is checked, and\neither every rule is imported or none is: when a row fails the response is 422 and the\nother rows tell what would have been done. dry_run=true only checks the file.\nThe format is taken from the Content-Type when not given.",
+                "consumes": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Import good rewards",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the file without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Rule file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodRewardImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodRewardImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Get good reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodReward"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Replace good reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New state of the Good Reward",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.replaceGoodRewardBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodReward"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "The rule stops applying and is no longer listed, but is kept for past calculations.\nThe deletion is recorded as the last version of the rule.",
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Delete good reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Changes only the fields present in the body, {\"disabled\": true} disables the rule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Update good reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.updateGoodRewardBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodReward"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Every change of a rule creates a new version, effective from the time of the change.\nVersions are never changed, breakdowns name the version every reward came from.\nDeleted rules keep their history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Get good reward version history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.GoodRewardVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "changes lists the fields, by their JSON names, that differ between the versions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Diff two good reward versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Good reward ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GoodRewardDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "price is what a line of goods costs in total; it can be left out when unit_price is given, and\nthen is unit_price times quantity, 1 by default and 1000000 at most. Rules can match the sku\nor category of goods, and point rewards are given per unit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Register order for accrual",
+                "parameters": [
+                    {
+                        "description": "Register Order for accrual",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.registerOrderForAccrualBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisteredOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/recalculate": {
+            "post": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Calculates processed orders again with the rules as they are now, as of the moments they were\nevaluated for, and replaces their accrual. The breakdown of every calculation is kept, the\nbreakdown endpoint shows the latest one. Give either orders or from and to,\nat most 1000 orders are recalculated at once. Every changed accrual is kept as an adjustment,\nwhich gophermart picks up from /adjustments and posts to the balance of the user.\nOrders are saved one by one: when one fails, the 500 response still lists the orders\nand adjustments saved before it, and names the order that failed in failed_order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Recalculate processed orders",
+                "parameters": [
+                    {
+                        "description": "Orders to recalculate",
+                        "name": "dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.recalculateOrdersBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RecalculationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RecalculationResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderID}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get registered order info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.getRegisteredOrderInfoResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{orderID}/breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "APIKey": []
+                    }
+                ],
+                "description": "Explains the accrual of a processed order: goods lists every good with the rules it matched,\nthe rule versions used and what each gave, order what order-level rules gave. After a\nrecalculation it explains the recalculated accrual.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get registered order accrual breakdown",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant code, ignored for keys bound to a merchant",
+                        "name": "X-Merchant",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AccrualBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AccrualAdjustment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant": {
+                    "type": "string"
+                },
+                "new_accrual": {
+                    "type": "number"
+                },
+                "old_accrual": {
+                    "type": "number"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AccrualBreakdown": {
+            "type": "object",
+            "properties": {
+                "accrual": {
+                    "type": "number"
+                },
+                "goods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GoodBreakdown"
+                    }
+                },
+                "order": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RuleContribution"
+                    }
+                }
+            }
+        },
+        "domain.Campaign": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GoodBreakdown": {
+            "type": "object",
+            "properties": {
+                "accrual": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RuleContribution"
+                    }
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.GoodReward": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "description": "The rule applies to purchases made in [ValidFrom, ValidTo) and within\nSchedule, and only while its campaign, if any, is active as well.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ignore_case": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "type": "string"
+                },
+                "match_field": {
+                    "type": "string"
+                },
+                "match_type": {
+                    "type": "string"
+                },
+                "max_per_good": {
+                    "description": "MaxPerGood and MaxPerOrder cap what the rule gives for a single good\nand for the whole order.",
+                    "type": "number"
+                },
+                "max_per_order": {
+                    "type": "number"
+                },
+                "merchant_id": {
+                    "type": "integer"
+                },
+                "min_basket": {
+                    "description": "MinBasket and Tiers are only used by order rules, see\nIsOrderRewardType.",
+                    "type": "number"
+                },
+                "priority": {
+                    "description": "Rules with a higher Priority are evaluated first.",
+                    "type": "integer"
+                },
+                "reward": {
+                    "type": "number"
+                },
+                "reward_type": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "stacking": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RewardTier"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version goes up with every change of the rule, including its\ndeletion. Every version is kept, see GoodRewardVersion.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.GoodRewardChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "domain.GoodRewardDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GoodRewardChange"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/domain.GoodRewardVersion"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "$ref": "#/definitions/domain.GoodRewardVersion"
+                }
+            }
+        },
+        "domain.GoodRewardImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "boolean"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GoodRewardImportResult"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.GoodRewardImportResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "unchanged",
+                        "failed"
+                    ]
+                },
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "rule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.GoodRewardVersion": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "description": "The rule applies to purchases made in [ValidFrom, ValidTo) and within\nSchedule, and only while its campaign, if any, is active as well.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "effective_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ignore_case": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "type": "string"
+                },
+                "match_field": {
+                    "type": "string"
+                },
+                "match_type": {
+                    "type": "string"
+                },
+                "max_per_good": {
+                    "description": "MaxPerGood and MaxPerOrder cap what the rule gives for a single good\nand for the whole order.",
+                    "type": "number"
+                },
+                "max_per_order": {
+                    "type": "number"
+                },
+                "merchant_id": {
+                    "type": "integer"
+                },
+                "min_basket": {
+                    "description": "MinBasket and Tiers are only used by order rules, see\nIsOrderRewardType.",
+                    "type": "number"
+                },
+                "priority": {
+                    "description": "Rules with a higher Priority are evaluated first.",
+                    "type": "integer"
+                },
+                "reward": {
+                    "type": "number"
+                },
+                "reward_type": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "stacking": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RewardTier"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version goes up with every change of the rule, including its\ndeletion. Every version is kept, see GoodRewardVersion.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OrderGood": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.RecalculationResult": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccrualAdjustment"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_order": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RegisteredOrder": {
+            "type": "object",
+            "properties": {
+                "accrual": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "goods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderGood"
+                    }
+                },
+                "merchant_id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "purchased_at": {
+                    "description": "PurchasedAt is when the customer bought the goods, if the merchant\ntold us.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RewardTier": {
+            "type": "object",
+            "properties": {
+                "reward": {
+                    "type": "number"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.RuleContribution": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "reward_type": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "rule_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Schedule": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "from_hour": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to_hour": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.createCampaignBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.getRegisteredOrderInfoResponse": {
+            "type": "object",
+            "properties": {
+                "accrual": {
+                    "type": "number"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.goodRewardsPageForResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GoodReward"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.quoteOrderBody": {
+            "type": "object",
+            "properties": {
+                "goods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderGood"
+                    }
+                },
+                "purchased_at": {
+                    "description": "PurchasedAt is the moment reward rules are evaluated for, now when left\nout.",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.recalculateOrdersBody": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "orders": {
+                    "description": "Orders lists the orders to recalculate. Without it, the orders\npurchased in [From, To) are, or registered then when the purchase time\nis unknown.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
@@ -235,20 +1988,264 @@ const docTemplate = `{
                 },
                 "order": {
                     "type": "string"
+                },
+                "purchased_at": {
+                    "description": "PurchasedAt is when the goods were bought, the registration time when\nleft out. Reward rules are evaluated as of this moment.",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.replaceGoodRewardBody": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "ignore_case": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "type": "string"
+                },
+                "match_field": {
+                    "description": "MatchField is description when empty.",
+                    "type": "string",
+                    "enum": [
+                        "description",
+                        "sku",
+                        "category"
+                    ]
+                },
+                "match_type": {
+                    "description": "MatchType is substring when empty, exact for sku and category.",
+                    "type": "string",
+                    "enum": [
+                        "substring",
+                        "exact",
+                        "prefix",
+                        "word",
+                        "regex"
+                    ]
+                },
+                "max_per_good": {
+                    "type": "number"
+                },
+                "max_per_order": {
+                    "type": "number"
+                },
+                "min_basket": {
+                    "description": "MinBasket and Tiers are only for order rules.",
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "reward": {
+                    "type": "number"
+                },
+                "reward_type": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "stacking": {
+                    "description": "Stacking is stackable when empty.",
+                    "type": "string",
+                    "enum": [
+                        "stackable",
+                        "exclusive",
+                        "best_of"
+                    ]
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RewardTier"
+                    }
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
                 }
             }
         },
         "handlers.saveNewGoodRewardBody": {
             "type": "object",
             "properties": {
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "ignore_case": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "type": "string"
+                },
+                "match_field": {
+                    "description": "MatchField is description when empty.",
+                    "type": "string",
+                    "enum": [
+                        "description",
+                        "sku",
+                        "category"
+                    ]
+                },
+                "match_type": {
+                    "description": "MatchType is substring when empty, exact for sku and category.",
+                    "type": "string",
+                    "enum": [
+                        "substring",
+                        "exact",
+                        "prefix",
+                        "word",
+                        "regex"
+                    ]
+                },
+                "max_per_good": {
+                    "type": "number"
+                },
+                "max_per_order": {
+                    "type": "number"
+                },
+                "min_basket": {
+                    "description": "MinBasket and Tiers are only for order rules.",
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "reward": {
+                    "type": "number"
+                },
+                "reward_type": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "stacking": {
+                    "description": "Stacking is stackable when empty.",
+                    "type": "string",
+                    "enum": [
+                        "stackable",
+                        "exclusive",
+                        "best_of"
+                    ]
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RewardTier"
+                    }
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateCampaignBody": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "A zero time (\"0001-01-01T00:00:00Z\") removes the bound.",
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateGoodRewardBody": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "description": "A zero campaign_id, a zero time (\"0001-01-01T00:00:00Z\") or an empty\nschedule removes it from the rule.",
+                    "type": "integer"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "ignore_case": {
+                    "type": "boolean"
+                },
                 "match": {
                     "type": "string"
                 },
+                "match_field": {
+                    "type": "string",
+                    "enum": [
+                        "description",
+                        "sku",
+                        "category"
+                    ]
+                },
+                "match_type": {
+                    "type": "string",
+                    "enum": [
+                        "substring",
+                        "exact",
+                        "prefix",
+                        "word",
+                        "regex"
+                    ]
+                },
+                "max_per_good": {
+                    "description": "A zero cap removes the cap.",
+                    "type": "number"
+                },
+                "max_per_order": {
+                    "type": "number"
+                },
+                "min_basket": {
+                    "description": "A zero min_basket or empty tiers removes them.",
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
                 "reward": {
                     "type": "number"
                 },
                 "reward_type": {
                     "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/domain.Schedule"
+                },
+                "stacking": {
+                    "type": "string",
+                    "enum": [
+                        "stackable",
+                        "exclusive",
+                        "best_of"
+                    ]
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RewardTier"
+                    }
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
                 }
             }
         },
@@ -260,6 +2257,13 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "APIKey": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,282 @@
+// Package calculator computes the accrual of an order from its goods and the
+// reward rules of its merchant.
+//
+// The evaluation is deterministic:
+//
+//  1. Rules that are not active at the evaluation time, see
+//     domain.GoodReward.ActiveAt, are dropped. The rest are ordered by
+//     priority, highest first, then by id.
+//  2. Goods are evaluated one by one in the order they were registered, with
+//     the per-good rules only.
+//  3. For a good, every rule whose matcher accepts the description, SKU or
+//     category, depending on the rule match field, is a candidate. A rule
+//     gives a percent of the good price or a fixed number of points per unit,
+//     rounded to domain.MoneyScale digits, then cut to its per-good cap, also
+//     per unit, and to what is left of its per-order cap.
+//  4. If an exclusive rule is among the candidates, the first one in rule
+//     order is the only rule applied to the good.
+//  5. Otherwise every stackable candidate is applied, plus the best_of
+//     candidate that gives the most (the first one in rule order on a tie).
+//  6. Then the order rules (see domain.IsOrderRewardType) are evaluated once
+//     for the whole order. An order rule is a candidate when the goods it
+//     matches add up to a basket that is positive and reaches its minimum;
+//     its reward is computed over that basket and cut to its per-order cap.
+//     Steps 4 and 5 pick among the order rules the same way.
+//
+// The accrual is the exact sum of the applied contributions.
+package calculator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// WholeOrder is the Good of the contributions of order rules.
+const WholeOrder = -1
+
+// Contribution is what one rule gave for one good, or for the whole order.
+type Contribution struct {
+	// Good is the index of the good in the order, or WholeOrder.
+	Good   int
+	RuleID int
+	Amount domain.Money
+}
+
+type Result struct {
+	Accrual       domain.Money
+	Contributions []Contribution
+}
+
+type rule struct {
+	domain.GoodReward
+	matches domain.Matcher
+	// position is the place of the rule in evaluation order.
+	position int
+	// given is what the rule has given over the order so far.
+	given domain.Money
+}
+
+// Calculate evaluates rules against goods bought at, see the package
+// documentation.
+func Calculate(goods []domain.OrderGood, rewards []domain.GoodReward, at time.Time) (*Result, error) {
+	rules := make([]*rule, 0, len(rewards))
+
+	for _, reward := range rewards {
+		if !reward.ActiveAt(at) {
+			continue
+		}
+
+		matcher, err := reward.Matcher()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", reward.ID, err)
+		}
+
+		rules = append(rules, &rule{GoodReward: reward, matches: matcher})
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+
+		return rules[i].ID < rules[j].ID
+	})
+
+	goodRules := make([]*rule, 0, len(rules))
+	orderRules := make([]*rule, 0)
+
+	for i, r := range rules {
+		r.position = i
+
+		if domain.IsOrderRewardType(r.RewardType) {
+			orderRules = append(orderRules, r)
+		} else {
+			goodRules = append(goodRules, r)
+		}
+	}
+
+	result := &Result{Contributions: make([]Contribution, 0)}
+
+	apply := func(good int, applied *rule, amount domain.Money) {
+		if amount.IsZero() {
+			return
+		}
+
+		applied.given = applied.given.Add(amount)
+		result.Accrual = result.Accrual.Add(amount)
+		result.Contributions = append(result.Contributions, Contribution{
+			Good:   good,
+			RuleID: applied.ID,
+			Amount: amount,
+		})
+	}
+
+	for i, good := range goods {
+		candidates := make([]*rule, 0)
+
+		for _, r := range goodRules {
+			if r.accepts(&good) {
+				candidates = append(candidates, r)
+			}
+		}
+
+		amountFor := func(r *rule) domain.Money {
+			return r.amountForGood(good)
+		}
+
+		for _, applied := range selectRules(candidates, amountFor) {
+			apply(i, applied, amountFor(applied))
+		}
+	}
+
+	baskets := make(map[*rule]domain.Money, len(orderRules))
+	candidates := make([]*rule, 0)
+
+	for _, r := range orderRules {
+		basket := r.basket(goods)
+
+		if !basket.IsPositive() || (r.MinBasket != nil && basket.Cmp(*r.MinBasket) < 0) {
+			continue
+		}
+
+		baskets[r] = basket
+		candidates = append(candidates, r)
+	}
+
+	amountFor := func(r *rule) domain.Money {
+		return r.amountForBasket(baskets[r])
+	}
+
+	for _, applied := range selectRules(candidates, amountFor) {
+		apply(WholeOrder, applied, amountFor(applied))
+	}
+
+	return result, nil
+}
+
+// selectRules returns the candidates to apply, in rule order.
+func selectRules(candidates []*rule, amountFor func(r *rule) domain.Money) []*rule {
+	selected := make([]*rule, 0)
+
+	var best *rule
+	var bestAmount domain.Money
+
+	for _, r := range candidates {
+		switch r.Stacking {
+		case domain.ExclusiveStackingMode:
+			return []*rule{r}
+		case domain.BestOfStackingMode:
+			if amount := amountFor(r); best == nil || amount.Cmp(bestAmount) > 0 {
+				best, bestAmount = r, amount
+			}
+		default:
+			selected = append(selected, r)
+		}
+	}
+
+	if best == nil {
+		return selected
+	}
+
+	// Keep the rule order, so contributions are listed the same way every time.
+	selected = append(selected, best)
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].position < selected[j].position
+	})
+
+	return selected
+}
+
+// amountForGood is what a per-good rule would give for good, caps included.
+func (r *rule) amountForGood(good domain.OrderGood) domain.Money {
+	var amount domain.Money
+
+	switch r.RewardType {
+	case domain.PercentRewardType:
+		amount = good.Price.Percent(r.Reward)
+	case domain.PointRewardType:
+		amount = r.Reward.Mul(good.Units())
+	}
+
+	if r.MaxPerGood != nil {
+		if maxPerGood := r.MaxPerGood.Mul(good.Units()); amount.Cmp(maxPerGood) > 0 {
+			amount = maxPerGood
+		}
+	}
+
+	return r.capToOrder(amount)
+}
+
+// accepts tells whether the rule applies to good.
+func (r *rule) accepts(good *domain.OrderGood) bool {
+	return r.matches(good.Field(r.MatchField))
+}
+
+// basket is the total price of the goods the rule matches.
+func (r *rule) basket(goods []domain.OrderGood) domain.Money {
+	var basket domain.Money
+
+	for i := range goods {
+		if r.accepts(&goods[i]) {
+			basket = basket.Add(goods[i].Price)
+		}
+	}
+
+	return basket
+}
+
+// amountForBasket is what an order rule would give for basket, cap included.
+func (r *rule) amountForBasket(basket domain.Money) domain.Money {
+	var amount domain.Money
+
+	switch r.RewardType {
+	case domain.OrderPointRewardType:
+		amount = r.Reward
+	case domain.OrderPercentRewardType:
+		amount = basket.Percent(r.Reward)
+	case domain.TieredPointRewardType:
+		for _, tier := range r.Tiers {
+			if basket.Cmp(tier.Threshold) >= 0 {
+				amount = tier.Reward
+			}
+		}
+	case domain.TieredPercentRewardType:
+		// Every tier is rounded on its own, like every contribution.
+		for i, tier := range r.Tiers {
+			upper := basket
+
+			if i+1 < len(r.Tiers) && r.Tiers[i+1].Threshold.Cmp(upper) < 0 {
+				upper = r.Tiers[i+1].Threshold
+			}
+
+			if part := upper.Sub(tier.Threshold); part.IsPositive() {
+				amount = amount.Add(part.Percent(tier.Reward))
+			}
+		}
+	}
+
+	return r.capToOrder(amount)
+}
+
+// capToOrder cuts amount to what is left of the per-order cap.
+func (r *rule) capToOrder(amount domain.Money) domain.Money {
+	if r.MaxPerOrder == nil {
+		return amount
+	}
+
+	left := r.MaxPerOrder.Sub(r.given)
+
+	if left.IsNegative() {
+		left = domain.Money{}
+	}
+
+	if amount.Cmp(left) > 0 {
+		return left
+	}
+
+	return amount
+}
@@ -0,0 +1,261 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// now is a Friday afternoon.
+var now = time.Date(2026, time.October, 16, 15, 0, 0, 0, time.UTC)
+
+func money(s string) *domain.Money {
+	m := domain.MustParseMoney(s)
+	return &m
+}
+
+func TestCalculate(t *testing.T) {
+	testCases := []struct {
+		Name                  string
+		Goods                 []domain.OrderGood
+		Rules                 []domain.GoodReward
+		ExpectedAccrual       string
+		ExpectedContributions []Contribution
+	}{
+		{
+			Name:  "stackable rules add up",
+			Goods: []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)}},
+			Rules: []domain.GoodReward{
+				{ID: 1, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10)},
+				{ID: 2, Match: "kettle", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(5)},
+			},
+			ExpectedAccrual: "105",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 1, Amount: domain.MoneyFromInt(100)},
+				{Good: 0, RuleID: 2, Amount: domain.MoneyFromInt(5)},
+			},
+		},
+		{
+			Name:  "first exclusive rule wins",
+			Goods: []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)}},
+			Rules: []domain.GoodReward{
+				{ID: 1, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10)},
+				{ID: 2, Match: "kettle", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(5), Stacking: domain.ExclusiveStackingMode, Priority: 1},
+				{ID: 3, Match: "kettle", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(50), Stacking: domain.ExclusiveStackingMode},
+			},
+			ExpectedAccrual: "5",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 2, Amount: domain.MoneyFromInt(5)},
+			},
+		},
+		{
+			Name:  "best of rules give the most, stackable ones still apply",
+			Goods: []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)}},
+			Rules: []domain.GoodReward{
+				{ID: 1, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(3), Stacking: domain.BestOfStackingMode, Priority: 2},
+				{ID: 2, Match: "kettle", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(40), Stacking: domain.BestOfStackingMode},
+				{ID: 3, Match: "kettle", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(1), Priority: 1},
+			},
+			ExpectedAccrual: "41",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 3, Amount: domain.MoneyFromInt(1)},
+				{Good: 0, RuleID: 2, Amount: domain.MoneyFromInt(40)},
+			},
+		},
+		{
+			Name:  "best of tie goes to the first rule",
+			Goods: []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(100)}},
+			Rules: []domain.GoodReward{
+				{ID: 2, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(10), Stacking: domain.BestOfStackingMode},
+				{ID: 1, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10), Stacking: domain.BestOfStackingMode},
+			},
+			ExpectedAccrual: "10",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 1, Amount: domain.MoneyFromInt(10)},
+			},
+		},
+		{
+			Name: "per good and per order caps",
+			Goods: []domain.OrderGood{
+				{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)},
+				{Description: "Bork iron", Price: domain.MoneyFromInt(500)},
+				{Description: "Bork toaster", Price: domain.MoneyFromInt(500)},
+			},
+			Rules: []domain.GoodReward{
+				{ID: 1, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10), MaxPerGood: money("60"), MaxPerOrder: money("100.5")},
+			},
+			ExpectedAccrual: "100.5",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 1, Amount: domain.MoneyFromInt(60)},
+				{Good: 1, RuleID: 1, Amount: domain.MustParseMoney("40.5")},
+			},
+		},
+		{
+			Name: "sku and category rules, points and caps per unit",
+			Goods: []domain.OrderGood{
+				{Description: "Bork kettle", SKU: "BK-810", Category: "Kettles", Quantity: 3, Price: domain.MoneyFromInt(3000)},
+				{Description: "Kettle descaler", SKU: "DS-1", Category: "kettles", Price: domain.MoneyFromInt(100)},
+			},
+			Rules: []domain.GoodReward{
+				{ID: 1, Match: "BK-810", MatchType: domain.ExactMatchType, MatchField: domain.SKUMatchField, RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(5)},
+				{ID: 2, Match: "Kettles", MatchType: domain.ExactMatchType, MatchField: domain.CategoryMatchField, RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10), MaxPerGood: money("50")},
+				{ID: 3, Match: "Bork", MatchField: domain.SKUMatchField, MatchType: domain.ExactMatchType, RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(100)},
+			},
+			ExpectedAccrual: "165",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 1, Amount: domain.MoneyFromInt(15)},
+				{Good: 0, RuleID: 2, Amount: domain.MoneyFromInt(150)},
+			},
+		},
+		{
+			Name:                  "nothing matches",
+			Goods:                 []domain.OrderGood{{Description: "Philips", Price: domain.MoneyFromInt(100)}},
+			Rules:                 []domain.GoodReward{{ID: 1, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(10)}},
+			ExpectedAccrual:       "0",
+			ExpectedContributions: []Contribution{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result, err := Calculate(testCase.Goods, testCase.Rules, now)
+			require.NoError(t, err)
+			assert.Equal(t, domain.MustParseMoney(testCase.ExpectedAccrual), result.Accrual)
+			assert.Equal(t, testCase.ExpectedContributions, result.Contributions)
+		})
+	}
+}
+
+func TestCalculate_InvalidRule(t *testing.T) {
+	_, err := Calculate(
+		[]domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(100)}},
+		[]domain.GoodReward{{ID: 1, Match: "Bork(", MatchType: domain.RegexMatchType}},
+		now,
+	)
+	require.ErrorIs(t, err, domain.ErrInvalidMatch)
+}
+
+func TestCalculate_EvaluationTime(t *testing.T) {
+	ended := now.Add(-time.Hour)
+	goods := []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)}}
+	rules := []domain.GoodReward{
+		{ID: 1, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(1)},
+		{ID: 2, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(10), ValidTo: &ended},
+		{
+			ID: 3, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(100),
+			Schedule: &domain.Schedule{Days: []int{int(time.Saturday), int(time.Sunday)}},
+		},
+	}
+
+	result, err := Calculate(goods, rules, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MoneyFromInt(1), result.Accrual)
+
+	result, err = Calculate(goods, rules, ended.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, domain.MoneyFromInt(11), result.Accrual)
+
+	result, err = Calculate(goods, rules, now.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, domain.MoneyFromInt(101), result.Accrual)
+}
+
+func TestCalculate_OrderRules(t *testing.T) {
+	tiers := []domain.RewardTier{
+		{Threshold: domain.MoneyFromInt(0), Reward: domain.MoneyFromInt(3)},
+		{Threshold: domain.MoneyFromInt(5000), Reward: domain.MoneyFromInt(5)},
+	}
+
+	testCases := []struct {
+		Name                  string
+		Goods                 []domain.OrderGood
+		Rules                 []domain.GoodReward
+		ExpectedAccrual       string
+		ExpectedContributions []Contribution
+	}{
+		{
+			Name: "flat bonus over a minimum basket, after per-good rules",
+			Goods: []domain.OrderGood{
+				{Description: "Bork kettle", Price: domain.MoneyFromInt(6000)},
+				{Description: "Philips iron", Price: domain.MoneyFromInt(4500)},
+			},
+			Rules: []domain.GoodReward{
+				{ID: 1, RewardType: domain.OrderPointRewardType, Reward: domain.MoneyFromInt(500), MinBasket: money("10000"), Priority: 9},
+				{ID: 2, Match: "Bork", RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(10)},
+			},
+			ExpectedAccrual: "510",
+			ExpectedContributions: []Contribution{
+				{Good: 0, RuleID: 2, Amount: domain.MoneyFromInt(10)},
+				{Good: WholeOrder, RuleID: 1, Amount: domain.MoneyFromInt(500)},
+			},
+		},
+		{
+			Name:                  "basket below the minimum",
+			Goods:                 []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(9999)}},
+			Rules:                 []domain.GoodReward{{ID: 1, RewardType: domain.OrderPointRewardType, Reward: domain.MoneyFromInt(500), MinBasket: money("10000")}},
+			ExpectedAccrual:       "0",
+			ExpectedContributions: []Contribution{},
+		},
+		{
+			Name: "basket of matching goods only",
+			Goods: []domain.OrderGood{
+				{Description: "Bork kettle", Price: domain.MoneyFromInt(1000)},
+				{Description: "Philips iron", Price: domain.MoneyFromInt(4000)},
+			},
+			Rules:           []domain.GoodReward{{ID: 1, Match: "Bork", RewardType: domain.OrderPercentRewardType, Reward: domain.MoneyFromInt(2)}},
+			ExpectedAccrual: "20",
+			ExpectedContributions: []Contribution{
+				{Good: WholeOrder, RuleID: 1, Amount: domain.MoneyFromInt(20)},
+			},
+		},
+		{
+			Name:            "tiered percent is marginal",
+			Goods:           []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(8000)}},
+			Rules:           []domain.GoodReward{{ID: 1, RewardType: domain.TieredPercentRewardType, Tiers: tiers}},
+			ExpectedAccrual: "300",
+			ExpectedContributions: []Contribution{
+				{Good: WholeOrder, RuleID: 1, Amount: domain.MoneyFromInt(300)},
+			},
+		},
+		{
+			Name:  "tiered points take the highest tier reached",
+			Goods: []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(8000)}},
+			Rules: []domain.GoodReward{{ID: 1, RewardType: domain.TieredPointRewardType, Tiers: []domain.RewardTier{
+				{Threshold: domain.MoneyFromInt(1000), Reward: domain.MoneyFromInt(50)},
+				{Threshold: domain.MoneyFromInt(5000), Reward: domain.MoneyFromInt(300)},
+				{Threshold: domain.MoneyFromInt(10000), Reward: domain.MoneyFromInt(800)},
+			}}},
+			ExpectedAccrual: "300",
+			ExpectedContributions: []Contribution{
+				{Good: WholeOrder, RuleID: 1, Amount: domain.MoneyFromInt(300)},
+			},
+		},
+		{
+			Name:  "order rules stack among themselves and respect caps",
+			Goods: []domain.OrderGood{{Description: "Bork kettle", Price: domain.MoneyFromInt(8000)}},
+			Rules: []domain.GoodReward{
+				{ID: 1, RewardType: domain.TieredPercentRewardType, Tiers: tiers, MaxPerOrder: money("250")},
+				{ID: 2, RewardType: domain.OrderPointRewardType, Reward: domain.MoneyFromInt(100), Stacking: domain.BestOfStackingMode},
+				{ID: 3, RewardType: domain.OrderPointRewardType, Reward: domain.MoneyFromInt(40), Stacking: domain.BestOfStackingMode},
+			},
+			ExpectedAccrual: "350",
+			ExpectedContributions: []Contribution{
+				{Good: WholeOrder, RuleID: 1, Amount: domain.MoneyFromInt(250)},
+				{Good: WholeOrder, RuleID: 2, Amount: domain.MoneyFromInt(100)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result, err := Calculate(testCase.Goods, testCase.Rules, now)
+			require.NoError(t, err)
+			assert.Equal(t, domain.MustParseMoney(testCase.ExpectedAccrual), result.Accrual)
+			assert.Equal(t, testCase.ExpectedContributions, result.Contributions)
+		})
+	}
+}
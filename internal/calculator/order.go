@@ -0,0 +1,64 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// RuleSource loads the rules of a merchant, as they were at asOf, that may
+// apply to goods bought at. It may return rules that do not match, Calculate
+// runs their matchers.
+type RuleSource interface {
+	GetRewardsWithMatches(
+		ctx context.Context, merchantID int, goods []domain.OrderGood, at time.Time, asOf time.Time,
+	) ([]domain.GoodReward, error)
+}
+
+// CalculateOrder evaluates the merchant rules, as they were at asOf, against
+// goods bought at and explains the result. Registered orders and quotes both
+// go through it, so they can not disagree.
+func CalculateOrder(
+	ctx context.Context, source RuleSource, merchantID int, goods []domain.OrderGood, at time.Time, asOf time.Time,
+) (*domain.AccrualBreakdown, error) {
+	rewards, err := source.GetRewardsWithMatches(ctx, merchantID, goods, at, asOf)
+
+	if err != nil {
+		return nil, fmt.Errorf("get rewards with matches %w", err)
+	}
+
+	result, err := Calculate(goods, rewards, at)
+
+	if err != nil {
+		return nil, fmt.Errorf("calculate accrual %w", err)
+	}
+
+	return Explain(goods, rewards, result), nil
+}
+
+// Explain groups the contributions of result by good. rewards are the rules
+// result was calculated with.
+func Explain(goods []domain.OrderGood, rewards []domain.GoodReward, result *Result) *domain.AccrualBreakdown {
+	rules := make(map[int]*domain.GoodReward, len(rewards))
+
+	for i := range rewards {
+		rules[rewards[i].ID] = &rewards[i]
+	}
+
+	breakdown := domain.NewAccrualBreakdown(goods)
+
+	for _, contribution := range result.Contributions {
+		rule := rules[contribution.RuleID]
+
+		breakdown.Add(contribution.Good, domain.RuleContribution{
+			RuleID:      rule.ID,
+			RuleVersion: rule.Version,
+			RewardType:  rule.RewardType,
+			Amount:      contribution.Amount,
+		})
+	}
+
+	return breakdown
+}
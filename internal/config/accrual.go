@@ -7,13 +7,15 @@ import (
 )
 
 type AccrualConfig struct {
-	RunAddress  string `env:"RUN_ADDRESS"`
-	DatabaseURI string `env:"DATABASE_URI"`
+	RunAddress      string `env:"RUN_ADDRESS"`
+	DatabaseURI     string `env:"DATABASE_URI"`
+	APIKeysDisabled bool   `env:"API_KEYS_DISABLED"`
 }
 
 func (appConfig *AccrualConfig) Parse() {
 	flag.StringVar(&appConfig.RunAddress, "a", "localhost:8081", "Base http address that server running on")
 	flag.StringVar(&appConfig.DatabaseURI, "d", "", "Database uri")
+	flag.BoolVar(&appConfig.APIKeysDisabled, "no-api-keys", false, "Serve every route without an API key, for local runs and CI only")
 	flag.Parse()
 
 	if err := env.Parse(appConfig); err != nil {
@@ -3,20 +3,58 @@ package config
 import (
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v9"
 )
 
 type GophermartConfig struct {
-	RunAddress           string `env:"RUN_ADDRESS"`
-	DatabaseURI          string `env:"DATABASE_URI"`
-	AccrualSystemAddress string `env:"ACCRUAL_SYSTEM_ADDRESS"`
+	RunAddress           string        `env:"RUN_ADDRESS"`
+	DatabaseURI          string        `env:"DATABASE_URI"`
+	AccrualSystemAddress string        `env:"ACCRUAL_SYSTEM_ADDRESS"`
+	AccrualAPIKey        string        `env:"ACCRUAL_API_KEY"`
+	HoldTTL              time.Duration `env:"HOLD_TTL"`
+	PointsTTL            time.Duration `env:"POINTS_TTL"`
+	PointsExpiringSoon   time.Duration `env:"POINTS_EXPIRING_SOON"`
+	AccessTokenTTL       time.Duration `env:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL      time.Duration `env:"REFRESH_TOKEN_TTL"`
+	JWTSecret            string        `env:"JWT_SECRET"`
+	JWTSigningKeyFile    string        `env:"JWT_SIGNING_KEY_FILE"`
+	JWTVerificationKeys  []string      `env:"JWT_VERIFICATION_KEY_FILES" envSeparator:","`
+	Dev                  bool          `env:"DEV"`
+	LoginMaxFailures     int           `env:"LOGIN_MAX_FAILURES"`
+	LoginLockout         time.Duration `env:"LOGIN_LOCKOUT"`
+	PasswordMinLength    int           `env:"PASSWORD_MIN_LENGTH"`
+	PasswordNeedLetter   bool          `env:"PASSWORD_REQUIRE_LETTER"`
+	PasswordNeedDigit    bool          `env:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRejectLogin  bool          `env:"PASSWORD_REJECT_LOGIN"`
+	PasswordResetTTL     time.Duration `env:"PASSWORD_RESET_TTL"`
 }
 
 func (appConfig *GophermartConfig) Parse() {
 	flag.StringVar(&appConfig.RunAddress, "a", "localhost:8080", "Base http address that server running on")
 	flag.StringVar(&appConfig.DatabaseURI, "d", "", "Database uri")
 	flag.StringVar(&appConfig.AccrualSystemAddress, "r", "http://localhost:8081", "Address of accrual system")
+	flag.StringVar(&appConfig.AccrualAPIKey, "accrual-api-key", "", "API key with the orders:read scope for the accrual system")
+	flag.DurationVar(&appConfig.HoldTTL, "hold-ttl", time.Minute*15, "How long reserved points stay held before they are released")
+	flag.DurationVar(&appConfig.PointsTTL, "points-ttl", time.Hour*24*365, "How long accrued points stay spendable before they expire")
+	flag.DurationVar(&appConfig.PointsExpiringSoon, "points-expiring-soon", time.Hour*24*30, "Window of the expiring soon breakdown in the balance")
+	flag.DurationVar(&appConfig.AccessTokenTTL, "access-token-ttl", time.Minute*15, "Lifetime of access tokens")
+	flag.DurationVar(&appConfig.RefreshTokenTTL, "refresh-token-ttl", time.Hour*24*30, "Lifetime of refresh tokens, every refresh starts it over")
+	flag.StringVar(&appConfig.JWTSigningKeyFile, "jwt-signing-key", "", "PEM file with the RSA or Ed25519 key access tokens are signed with")
+	flag.Func("jwt-verification-keys", "Comma separated PEM files with retired keys access tokens are still verified with", func(value string) error {
+		appConfig.JWTVerificationKeys = append(appConfig.JWTVerificationKeys, strings.Split(value, ",")...)
+		return nil
+	})
+	flag.BoolVar(&appConfig.Dev, "dev", false, "Development mode, allows the insecure default JWT secret")
+	flag.IntVar(&appConfig.LoginMaxFailures, "login-max-failures", 10, "Failed logins in a row that lock the login for a while")
+	flag.DurationVar(&appConfig.LoginLockout, "login-lockout", time.Minute*30, "How long a login stays locked after too many failures")
+	flag.IntVar(&appConfig.PasswordMinLength, "password-min-length", 6, "Minimum number of characters in a password")
+	flag.BoolVar(&appConfig.PasswordNeedLetter, "password-require-letter", false, "Passwords must contain a letter")
+	flag.BoolVar(&appConfig.PasswordNeedDigit, "password-require-digit", false, "Passwords must contain a digit")
+	flag.BoolVar(&appConfig.PasswordRejectLogin, "password-reject-login", false, "Passwords must not contain the login")
+	flag.DurationVar(&appConfig.PasswordResetTTL, "password-reset-ttl", time.Hour, "How long a password reset token stays usable")
 	flag.Parse()
 
 	if err := env.Parse(appConfig); err != nil {
@@ -0,0 +1,26 @@
+package contextutil
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+const APIKeyKey = contextKey("api_key")
+
+var ErrAPIKeyKeyNotFound = errors.New("api key key not found in context")
+
+func SetAPIKeyToContext(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(ctx, APIKeyKey, key)
+}
+
+func GetAPIKeyFromContext(ctx context.Context) (*domain.APIKey, error) {
+	key, ok := ctx.Value(APIKeyKey).(*domain.APIKey)
+
+	if !ok || key == nil {
+		return nil, ErrAPIKeyKeyNotFound
+	}
+
+	return key, nil
+}
@@ -0,0 +1,27 @@
+package contextutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+func TestGetAPIKeyFromContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := SetAPIKeyToContext(context.Background(), &domain.APIKey{ID: "kid"})
+
+		key, err := GetAPIKeyFromContext(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "kid", key.ID)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		_, err := GetAPIKeyFromContext(context.Background())
+		require.ErrorIs(t, err, ErrAPIKeyKeyNotFound)
+	})
+}
@@ -0,0 +1,24 @@
+package contextutil
+
+import (
+	"context"
+	"errors"
+)
+
+const MerchantIDKey = contextKey("merchant_id")
+
+var ErrMerchantIDKeyNotFound = errors.New("merchant id key not found in context")
+
+func SetMerchantIDToContext(ctx context.Context, merchantID int) context.Context {
+	return context.WithValue(ctx, MerchantIDKey, merchantID)
+}
+
+func GetMerchantIDFromContext(ctx context.Context) (int, error) {
+	id, ok := ctx.Value(MerchantIDKey).(int)
+
+	if !ok {
+		return 0, ErrMerchantIDKeyNotFound
+	}
+
+	return id, nil
+}
@@ -0,0 +1,25 @@
+package contextutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMerchantIDFromContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := SetMerchantIDToContext(context.Background(), 2)
+
+		merchantID, err := GetMerchantIDFromContext(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, merchantID)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		_, err := GetMerchantIDFromContext(context.Background())
+		require.ErrorIs(t, err, ErrMerchantIDKeyNotFound)
+	})
+}
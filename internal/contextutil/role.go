@@ -0,0 +1,24 @@
+package contextutil
+
+import (
+	"context"
+	"errors"
+)
+
+const RoleKey = contextKey("role")
+
+var ErrRoleKeyNotFound = errors.New("role key not found in context")
+
+func SetRoleToContext(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, RoleKey, role)
+}
+
+func GetRoleFromContext(ctx context.Context) (string, error) {
+	role, ok := ctx.Value(RoleKey).(string)
+
+	if !ok || role == "" {
+		return "", ErrRoleKeyNotFound
+	}
+
+	return role, nil
+}
@@ -0,0 +1,25 @@
+package contextutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRoleFromContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := SetRoleToContext(context.Background(), "admin")
+
+		role, err := GetRoleFromContext(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin", role)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		_, err := GetRoleFromContext(context.Background())
+		require.ErrorIs(t, err, ErrRoleKeyNotFound)
+	})
+}
@@ -0,0 +1,24 @@
+package contextutil
+
+import (
+	"context"
+	"errors"
+)
+
+const SessionIDKey = contextKey("session_id")
+
+var ErrSessionIDKeyNotFound = errors.New("session id key not found in context")
+
+func SetSessionIDToContext(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, SessionIDKey, sessionID)
+}
+
+func GetSessionIDFromContext(ctx context.Context) (string, error) {
+	sessionID, ok := ctx.Value(SessionIDKey).(string)
+
+	if !ok || sessionID == "" {
+		return "", ErrSessionIDKeyNotFound
+	}
+
+	return sessionID, nil
+}
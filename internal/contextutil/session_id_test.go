@@ -0,0 +1,25 @@
+package contextutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionIDFromContext(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ctx := SetSessionIDToContext(context.Background(), "sid")
+
+		sessionID, err := GetSessionIDFromContext(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, "sid", sessionID)
+	})
+
+	t.Run("not found key", func(t *testing.T) {
+		_, err := GetSessionIDFromContext(context.Background())
+		require.ErrorIs(t, err, ErrSessionIDKeyNotFound)
+	})
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// AccrualLot is a batch of points issued at once. Spending consumes the lots
+// that expire first, and whatever is left in a lot when it expires is written
+// off. A lot without ExpiresAt never expires.
+type AccrualLot struct {
+	ID              int        `json:"id"`
+	UserID          int        `json:"user_id"`
+	BalanceActionID *int       `json:"balance_action_id,omitempty"`
+	OrderID         *string    `json:"order_id,omitempty"`
+	Amount          Money      `json:"amount" swaggertype:"number"`
+	Remaining       Money      `json:"remaining" swaggertype:"number"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ExpiringPoints is the amount of points that expire on a given day.
+type ExpiringPoints struct {
+	Amount    Money     `json:"amount" swaggertype:"number"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// APIKeyHeader carries the API key on requests to the accrual service.
+const APIKeyHeader = "X-API-Key"
+
+// API key scopes of the accrual service.
+const (
+	RulesReadScope   = "rules:read"
+	RulesWriteScope  = "rules:write"
+	OrdersWriteScope = "orders:write"
+	OrdersReadScope  = "orders:read"
+	// OrdersAdminScope lets operators recalculate processed orders.
+	OrdersAdminScope = "orders:admin"
+)
+
+var validAPIKeyScopes = map[string]struct{}{
+	RulesReadScope:   {},
+	RulesWriteScope:  {},
+	OrdersWriteScope: {},
+	OrdersReadScope:  {},
+	OrdersAdminScope: {},
+}
+
+var (
+	ErrInvalidAPIKey      = errors.New("invalid api key")
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
+	ErrAPIKeyNameRequired = errors.New("api key name is required")
+)
+
+// APIKey lets a merchant or gophermart call the accrual service. The key
+// itself is "<id>.<secret>"; only the hash of the secret is stored. A key with
+// a MerchantID acts for that merchant only, one without it is a platform key.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	MerchantID *int       `json:"merchant_id,omitempty"`
+	SecretHash string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) IsActive() bool {
+	return k.RevokedAt == nil
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IssuedAPIKey is a freshly created key together with its plain value, which
+// is shown only once.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	_, ok := validAPIKeyScopes[scope]
+	return ok
+}
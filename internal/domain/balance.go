@@ -2,11 +2,61 @@ package domain
 
 import "time"
 
+const (
+	ProcessedBalanceActionStatus = "PROCESSED"
+	HeldBalanceActionStatus      = "HELD"
+	ReleasedBalanceActionStatus  = "RELEASED"
+)
+
+// RefundedWithdrawalStatus is not stored, it marks processed withdrawals that
+// have a refund when filtering the history.
+const RefundedWithdrawalStatus = "REFUNDED"
+
+const (
+	AccrualBalanceActionKind    = "ACCRUAL"
+	WithdrawalBalanceActionKind = "WITHDRAWAL"
+	RefundBalanceActionKind     = "REFUND"
+	ClawbackBalanceActionKind   = "CLAWBACK"
+	ExpiryBalanceActionKind     = "EXPIRY"
+	AdjustmentBalanceActionKind = "ADJUSTMENT"
+)
+
+// Reason codes accepted for compensating balance actions.
+const (
+	CustomerRequestReversalReason = "CUSTOMER_REQUEST"
+	OrderReturnedReversalReason   = "ORDER_RETURNED"
+	OrderCancelledReversalReason  = "ORDER_CANCELLED"
+	DuplicateReversalReason       = "DUPLICATE"
+	FraudReversalReason           = "FRAUD"
+	OtherReversalReason           = "OTHER"
+)
+
+var validReversalReasons = map[string]struct{}{
+	CustomerRequestReversalReason: {},
+	OrderReturnedReversalReason:   {},
+	OrderCancelledReversalReason:  {},
+	DuplicateReversalReason:       {},
+	FraudReversalReason:           {},
+	OtherReversalReason:           {},
+}
+
 type BalanceAction struct {
-	ID          int        `json:"id"`
-	UserID      int        `json:"user_id"`
-	Amount      float64    `json:"amount"`
-	OrderID     string     `json:"order_id"`
-	CreatedAt   time.Time  `json:"created_at"`
-	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	ID          int            `json:"id"`
+	UserID      int            `json:"user_id"`
+	Amount      Money          `json:"amount" swaggertype:"number"`
+	OrderID     string         `json:"order_id"`
+	Kind        string         `json:"kind"`
+	Status      string         `json:"status"`
+	ReversesID  *int           `json:"reverses_id,omitempty"`
+	Reason      *string        `json:"reason,omitempty"`
+	ActorID     *int           `json:"actor_id,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ProcessedAt *time.Time     `json:"processed_at,omitempty"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	ReversedBy  *BalanceAction `json:"reversed_by,omitempty"`
+}
+
+func IsValidReversalReason(reason string) bool {
+	_, ok := validReversalReasons[reason]
+	return ok
 }
@@ -0,0 +1,65 @@
+package domain
+
+import "errors"
+
+// ErrNoBreakdown is returned for orders that are not calculated yet, or were
+// calculated before breakdowns were kept.
+var ErrNoBreakdown = errors.New("order has no accrual breakdown")
+
+// RuleContribution is what one version of a reward rule gave.
+type RuleContribution struct {
+	RuleID      int    `json:"rule_id"`
+	RuleVersion int    `json:"rule_version"`
+	RewardType  string `json:"reward_type"`
+	Amount      Money  `json:"amount" swaggertype:"number"`
+}
+
+// GoodBreakdown is a good of an order with what the per-good rules gave for
+// it, in the order they were applied.
+type GoodBreakdown struct {
+	OrderGood
+	Accrual Money              `json:"accrual" swaggertype:"number"`
+	Rewards []RuleContribution `json:"rewards"`
+}
+
+// AccrualBreakdown explains an accrual: Goods lists the goods in the order
+// they were registered, and Order what order rules gave for the whole order.
+// Accrual is the sum of every contribution.
+type AccrualBreakdown struct {
+	Accrual Money              `json:"accrual" swaggertype:"number"`
+	Goods   []GoodBreakdown    `json:"goods"`
+	Order   []RuleContribution `json:"order"`
+}
+
+// NewAccrualBreakdown returns the breakdown of goods nothing was given for
+// yet.
+func NewAccrualBreakdown(goods []OrderGood) *AccrualBreakdown {
+	breakdown := &AccrualBreakdown{
+		Goods: make([]GoodBreakdown, len(goods)),
+		Order: make([]RuleContribution, 0),
+	}
+
+	for i, good := range goods {
+		breakdown.Goods[i] = GoodBreakdown{
+			OrderGood: good,
+			Rewards:   make([]RuleContribution, 0),
+		}
+	}
+
+	return breakdown
+}
+
+// Add records what a rule gave for the good at index line, or for the whole
+// order when line is negative.
+func (b *AccrualBreakdown) Add(line int, contribution RuleContribution) {
+	b.Accrual = b.Accrual.Add(contribution.Amount)
+
+	if line < 0 {
+		b.Order = append(b.Order, contribution)
+		return
+	}
+
+	good := &b.Goods[line]
+	good.Accrual = good.Accrual.Add(contribution.Amount)
+	good.Rewards = append(good.Rewards, contribution)
+}
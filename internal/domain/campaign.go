@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownCampaign   = errors.New("unknown campaign")
+	ErrCampaignNameTaken = errors.New("campaign name already taken")
+)
+
+// Campaign groups the rules of a promotion. Its rules only apply while the
+// campaign is enabled and within its validity window, on top of their own.
+type Campaign struct {
+	ID         int        `json:"id"`
+	MerchantID int        `json:"merchant_id"`
+	Name       string     `json:"name"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidTo    *time.Time `json:"valid_to,omitempty"`
+	Disabled   bool       `json:"disabled"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// CampaignUpdate changes the fields of a campaign that are not nil. A zero
+// time removes the bound.
+type CampaignUpdate struct {
+	Name      *string
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	Disabled  *bool
+}
+
+func (c *Campaign) ActiveAt(t time.Time) bool {
+	return !c.Disabled && withinValidity(t, c.ValidFrom, c.ValidTo)
+}
+
+// Apply sets the fields of update on campaign.
+func (u CampaignUpdate) Apply(campaign *Campaign) {
+	if u.Name != nil {
+		campaign.Name = *u.Name
+	}
+
+	if u.ValidFrom != nil {
+		campaign.ValidFrom = nonZeroTime(*u.ValidFrom)
+	}
+
+	if u.ValidTo != nil {
+		campaign.ValidTo = nonZeroTime(*u.ValidTo)
+	}
+
+	if u.Disabled != nil {
+		campaign.Disabled = *u.Disabled
+	}
+}
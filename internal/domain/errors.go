@@ -12,8 +12,22 @@ var (
 	ErrOrderRegisteredByYou             = errors.New("order already registered by you")
 	ErrOrderRegisteredByOther           = errors.New("order already registered by other user")
 	ErrInsufficientFunds                = errors.New("insufficient funds")
+	ErrHoldNotActive                    = errors.New("hold is not active")
+	ErrHoldExpired                      = errors.New("hold is expired")
+	ErrInvalidReversalReason            = errors.New("invalid reversal reason")
+	ErrNotReversible                    = errors.New("balance action can not be reversed")
+	ErrAlreadyReversed                  = errors.New("balance action already reversed")
 	ErrMatchKeyAlreadyExists            = errors.New("match key already exists")
 	ErrOrderAlreadyRegisteredForAccrual = errors.New("order already registered for accrual")
+	ErrInvalidRefreshToken              = errors.New("invalid refresh token")
+	ErrRefreshTokenReused               = errors.New("refresh token reused, session revoked")
+	ErrSessionNotActive                 = errors.New("session is not active")
+	ErrUserLocked                       = errors.New("user is locked")
+	ErrInvalidRole                      = errors.New("invalid role")
+	ErrReasonRequired                   = errors.New("reason is required")
+	ErrUserOutranksActor                = errors.New("user has a higher role than you")
+	ErrInvalidAdjustment                = errors.New("adjustment amount must not be zero")
+	ErrOrderNotRequeueable              = errors.New("order can not be requeued")
 	ErrInternalServer                   = errors.New("internal server error")
 )
 
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	PercentRewardType = "%"
@@ -8,19 +11,338 @@ const (
 )
 
 var validRewardTypes = map[string]struct{}{
-	PercentRewardType: {},
-	PointRewardType:   {},
+	PercentRewardType:       {},
+	PointRewardType:         {},
+	OrderPointRewardType:    {},
+	OrderPercentRewardType:  {},
+	TieredPointRewardType:   {},
+	TieredPercentRewardType: {},
 }
 
+// Stacking modes decide how a rule combines with the other rules that match
+// the same good, see the calculator package.
+const (
+	StackableStackingMode = "stackable"
+	ExclusiveStackingMode = "exclusive"
+	BestOfStackingMode    = "best_of"
+)
+
+var validStackingModes = map[string]struct{}{
+	StackableStackingMode: {},
+	ExclusiveStackingMode: {},
+	BestOfStackingMode:    {},
+}
+
+// Good reward statuses used to filter the rule list. Deleted rules are never
+// listed.
+const (
+	ActiveGoodRewardStatus   = "ACTIVE"
+	DisabledGoodRewardStatus = "DISABLED"
+)
+
+// GoodReward is a reward rule of a merchant. Disabled rules are kept but not
+// applied to new calculations. Deleted rules are only marked with DeletedAt,
+// so calculations made with them can still be explained.
 type GoodReward struct {
-	ID         int       `json:"id"`
-	Match      string    `json:"match"`
-	Reward     float64   `json:"reward"`
-	RewardType string    `json:"reward_type"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         int    `json:"id"`
+	MerchantID int    `json:"merchant_id"`
+	Match      string `json:"match"`
+	MatchType  string `json:"match_type"`
+	MatchField string `json:"match_field"`
+	IgnoreCase bool   `json:"ignore_case"`
+	Reward     Money  `json:"reward" swaggertype:"number"`
+	RewardType string `json:"reward_type"`
+	// MinBasket and Tiers are only used by order rules, see
+	// IsOrderRewardType.
+	MinBasket *Money       `json:"min_basket,omitempty" swaggertype:"number"`
+	Tiers     []RewardTier `json:"tiers,omitempty"`
+	// Rules with a higher Priority are evaluated first.
+	Priority int    `json:"priority"`
+	Stacking string `json:"stacking"`
+	// MaxPerGood and MaxPerOrder cap what the rule gives for a single good
+	// and for the whole order.
+	MaxPerGood  *Money `json:"max_per_good,omitempty" swaggertype:"number"`
+	MaxPerOrder *Money `json:"max_per_order,omitempty" swaggertype:"number"`
+	// The rule applies to purchases made in [ValidFrom, ValidTo) and within
+	// Schedule, and only while its campaign, if any, is active as well.
+	CampaignID *int       `json:"campaign_id,omitempty"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidTo    *time.Time `json:"valid_to,omitempty"`
+	Schedule   *Schedule  `json:"schedule,omitempty"`
+	Disabled   bool       `json:"disabled"`
+	// Version goes up with every change of the rule, including its
+	// deletion. Every version is kept, see GoodRewardVersion.
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// GoodRewardUpdate changes the fields of a rule that are not nil. A zero
+// cap, minimum basket, campaign id, time or schedule, or empty tiers,
+// remove it from the rule.
+type GoodRewardUpdate struct {
+	Match       *string
+	MatchType   *string
+	MatchField  *string
+	IgnoreCase  *bool
+	Reward      *Money
+	RewardType  *string
+	MinBasket   *Money
+	Tiers       *[]RewardTier
+	Priority    *int
+	Stacking    *string
+	MaxPerGood  *Money
+	MaxPerOrder *Money
+	CampaignID  *int
+	ValidFrom   *time.Time
+	ValidTo     *time.Time
+	Schedule    *Schedule
+	Disabled    *bool
+}
+
+// GoodRewardsFilter describes one page of the rule list. Search matches rules
+// whose match text contains it, ignoring case.
+type GoodRewardsFilter struct {
+	HistoryFilter
+	Search     string
+	CampaignID *int
+}
+
+type GoodRewardsPage struct {
+	Items      []GoodReward
+	NextCursor *string
+}
+
+// SetDefaults fills in the match field, match type and stacking mode rules
+// had before those could be chosen. SKUs and categories are matched exactly.
+func (r *GoodReward) SetDefaults() {
+	if r.MatchField == "" {
+		r.MatchField = DescriptionMatchField
+	}
+
+	if r.MatchType == "" && r.MatchField != DescriptionMatchField {
+		r.MatchType = ExactMatchType
+	}
+
+	if r.MatchType == "" {
+		r.MatchType = SubstringMatchType
+	}
+
+	if r.Stacking == "" {
+		r.Stacking = StackableStackingMode
+	}
+}
+
+// Replacement returns the update that turns any rule into r.
+func (r GoodReward) Replacement() GoodRewardUpdate {
+	var maxPerGood, maxPerOrder, minBasket Money
+	var campaignID int
+	var validFrom, validTo time.Time
+	var schedule Schedule
+
+	if r.MaxPerGood != nil {
+		maxPerGood = *r.MaxPerGood
+	}
+
+	if r.MaxPerOrder != nil {
+		maxPerOrder = *r.MaxPerOrder
+	}
+
+	if r.MinBasket != nil {
+		minBasket = *r.MinBasket
+	}
+
+	tiers := append([]RewardTier{}, r.Tiers...)
+
+	if r.CampaignID != nil {
+		campaignID = *r.CampaignID
+	}
+
+	if r.ValidFrom != nil {
+		validFrom = *r.ValidFrom
+	}
+
+	if r.ValidTo != nil {
+		validTo = *r.ValidTo
+	}
+
+	if r.Schedule != nil {
+		schedule = *r.Schedule
+	}
+
+	return GoodRewardUpdate{
+		Match:       &r.Match,
+		MatchType:   &r.MatchType,
+		MatchField:  &r.MatchField,
+		IgnoreCase:  &r.IgnoreCase,
+		Reward:      &r.Reward,
+		RewardType:  &r.RewardType,
+		MinBasket:   &minBasket,
+		Tiers:       &tiers,
+		Priority:    &r.Priority,
+		Stacking:    &r.Stacking,
+		MaxPerGood:  &maxPerGood,
+		MaxPerOrder: &maxPerOrder,
+		CampaignID:  &campaignID,
+		ValidFrom:   &validFrom,
+		ValidTo:     &validTo,
+		Schedule:    &schedule,
+		Disabled:    &r.Disabled,
+	}
+}
+
+// Matcher returns the function that decides which goods the rule applies to,
+// given the MatchField of each good. An order rule without a match applies
+// to the whole basket.
+func (r *GoodReward) Matcher() (Matcher, error) {
+	if r.Match == "" && IsOrderRewardType(r.RewardType) {
+		return func(string) bool { return true }, nil
+	}
+
+	return NewMatcher(r.MatchType, r.Match, r.IgnoreCase)
+}
+
+// Check makes sure the rule can be evaluated: its reward fits its reward
+// type, its match compiles, its validity window is not empty and its
+// schedule is usable.
+func (r *GoodReward) Check() error {
+	if err := r.checkReward(); err != nil {
+		return err
+	}
+
+	if r.MatchField != "" && !IsValidMatchField(r.MatchField) {
+		return fmt.Errorf("%w: unknown match field %q", ErrInvalidMatch, r.MatchField)
+	}
+
+	if r.MatchField != "" && r.MatchField != DescriptionMatchField && r.MatchType != ExactMatchType {
+		return fmt.Errorf("%w: %s is only matched exactly", ErrInvalidMatch, r.MatchField)
+	}
+
+	if _, err := r.Matcher(); err != nil {
+		return err
+	}
+
+	if err := CheckValidity(r.ValidFrom, r.ValidTo); err != nil {
+		return err
+	}
+
+	if r.Schedule != nil {
+		return r.Schedule.Check()
+	}
+
+	return nil
+}
+
+// ActiveAt tells whether the rule applies to a purchase made at t, leaving
+// its campaign aside.
+func (r *GoodReward) ActiveAt(t time.Time) bool {
+	if r.Disabled || r.DeletedAt != nil || !withinValidity(t, r.ValidFrom, r.ValidTo) {
+		return false
+	}
+
+	return r.Schedule == nil || r.Schedule.Contains(t)
+}
+
+// Apply copies the fields set in update to the rule.
+func (u GoodRewardUpdate) Apply(reward *GoodReward) {
+	if u.Match != nil {
+		reward.Match = *u.Match
+	}
+
+	if u.MatchType != nil {
+		reward.MatchType = *u.MatchType
+	}
+
+	if u.MatchField != nil {
+		reward.MatchField = *u.MatchField
+	}
+
+	if u.IgnoreCase != nil {
+		reward.IgnoreCase = *u.IgnoreCase
+	}
+
+	if u.Reward != nil {
+		reward.Reward = *u.Reward
+	}
+
+	if u.RewardType != nil {
+		reward.RewardType = *u.RewardType
+	}
+
+	if u.MinBasket != nil {
+		reward.MinBasket = nonZeroMoney(*u.MinBasket)
+	}
+
+	if u.Tiers != nil {
+		reward.Tiers = nil
+
+		if len(*u.Tiers) > 0 {
+			reward.Tiers = append([]RewardTier{}, *u.Tiers...)
+		}
+	}
+
+	if u.Priority != nil {
+		reward.Priority = *u.Priority
+	}
+
+	if u.Stacking != nil {
+		reward.Stacking = *u.Stacking
+	}
+
+	if u.MaxPerGood != nil {
+		reward.MaxPerGood = nonZeroMoney(*u.MaxPerGood)
+	}
+
+	if u.MaxPerOrder != nil {
+		reward.MaxPerOrder = nonZeroMoney(*u.MaxPerOrder)
+	}
+
+	if u.CampaignID != nil {
+		reward.CampaignID = nil
+
+		if *u.CampaignID != 0 {
+			campaignID := *u.CampaignID
+			reward.CampaignID = &campaignID
+		}
+	}
+
+	if u.ValidFrom != nil {
+		reward.ValidFrom = nonZeroTime(*u.ValidFrom)
+	}
+
+	if u.ValidTo != nil {
+		reward.ValidTo = nonZeroTime(*u.ValidTo)
+	}
+
+	if u.Schedule != nil {
+		reward.Schedule = nil
+
+		if !u.Schedule.IsZero() {
+			schedule := *u.Schedule
+			reward.Schedule = &schedule
+		}
+	}
+
+	if u.Disabled != nil {
+		reward.Disabled = *u.Disabled
+	}
 }
 
 func IsValidRewardType(rewardType string) bool {
 	_, ok := validRewardTypes[rewardType]
 	return ok
 }
+
+func IsValidStackingMode(stacking string) bool {
+	_, ok := validStackingModes[stacking]
+	return ok
+}
+
+func nonZeroMoney(m Money) *Money {
+	if m.IsZero() {
+		return nil
+	}
+
+	return &m
+}
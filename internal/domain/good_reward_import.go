@@ -0,0 +1,64 @@
+package domain
+
+import "errors"
+
+// MaxImportedGoodRewards is how many rules one import may carry.
+const MaxImportedGoodRewards = 10000
+
+// What an import did with a row. Nothing is saved when a row failed.
+const (
+	CreatedImportAction   = "created"
+	UpdatedImportAction   = "updated"
+	UnchangedImportAction = "unchanged"
+	FailedImportAction    = "failed"
+)
+
+var (
+	ErrInvalidImport  = errors.New("invalid import")
+	ErrAmbiguousMatch = errors.New("several rules have this match, give the id")
+)
+
+// ImportedGoodReward is a rule read from a row of an import file. Row is the
+// line of a CSV file or the position in a JSON array, from 1. Err tells why
+// the row could not be read.
+type ImportedGoodReward struct {
+	Row    int
+	Reward GoodReward
+	Err    error
+}
+
+// GoodRewardImportResult is what an import did with a row.
+type GoodRewardImportResult struct {
+	Row    int    `json:"row"`
+	Action string `json:"action" enums:"created,updated,unchanged,failed"`
+	RuleID int    `json:"rule_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// GoodRewardImportReport sums up an import. Imported is false for dry runs
+// and for imports with failed rows, whose actions are only what would have
+// been done.
+type GoodRewardImportReport struct {
+	Imported  bool                     `json:"imported"`
+	Created   int                      `json:"created"`
+	Updated   int                      `json:"updated"`
+	Unchanged int                      `json:"unchanged"`
+	Failed    int                      `json:"failed"`
+	Rows      []GoodRewardImportResult `json:"rows"`
+}
+
+// Add counts result in the report.
+func (r *GoodRewardImportReport) Add(result GoodRewardImportResult) {
+	switch result.Action {
+	case CreatedImportAction:
+		r.Created++
+	case UpdatedImportAction:
+		r.Updated++
+	case UnchangedImportAction:
+		r.Unchanged++
+	case FailedImportAction:
+		r.Failed++
+	}
+
+	r.Rows = append(r.Rows, result)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// GoodRewardVersion is a rule as it was from EffectiveAt until its next
+// version. Versions are never changed, so every calculation can be
+// reproduced with the versions named in its breakdown.
+type GoodRewardVersion struct {
+	GoodReward
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+// GoodRewardChange is a field, by its JSON name, that differs between two
+// versions of a rule. From or To is null when the field is not set.
+type GoodRewardChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// GoodRewardDiff lists what changed in a rule from one version to another.
+type GoodRewardDiff struct {
+	RuleID  int                `json:"rule_id"`
+	From    GoodRewardVersion  `json:"from"`
+	To      GoodRewardVersion  `json:"to"`
+	Changes []GoodRewardChange `json:"changes"`
+}
+
+// versionBookkeepingFields change with every version, so they are left out
+// of diffs.
+var versionBookkeepingFields = map[string]struct{}{
+	"id":          {},
+	"merchant_id": {},
+	"version":     {},
+	"created_at":  {},
+	"updated_at":  {},
+}
+
+// NewGoodRewardDiff compares two versions of a rule field by field, in the
+// order of the field names.
+func NewGoodRewardDiff(from GoodRewardVersion, to GoodRewardVersion) (*GoodRewardDiff, error) {
+	fromFields, err := ruleFields(from.GoodReward)
+	if err != nil {
+		return nil, err
+	}
+
+	toFields, err := ruleFields(to.GoodReward)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fromFields)+len(toFields))
+
+	for name := range fromFields {
+		names = append(names, name)
+	}
+
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	diff := &GoodRewardDiff{
+		RuleID:  to.ID,
+		From:    from,
+		To:      to,
+		Changes: make([]GoodRewardChange, 0),
+	}
+
+	for _, name := range names {
+		if _, ok := versionBookkeepingFields[name]; ok {
+			continue
+		}
+
+		fromValue, toValue := fromFields[name], toFields[name]
+
+		if bytes.Equal(fromValue, toValue) {
+			continue
+		}
+
+		diff.Changes = append(diff.Changes, GoodRewardChange{
+			Field: name,
+			From:  rawOrNull(fromValue),
+			To:    rawOrNull(toValue),
+		})
+	}
+
+	return diff, nil
+}
+
+func ruleFields(reward GoodReward) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(reward)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// rawOrNull keeps a field that is left out of the JSON of a rule as null.
+func rawOrNull(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+
+	return value
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGoodRewardDiff(t *testing.T) {
+	maxPerGood := MoneyFromInt(10)
+	createdAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	from := GoodRewardVersion{
+		GoodReward: GoodReward{
+			ID: 7, Match: "Bork", MatchType: SubstringMatchType, Reward: MoneyFromInt(5), RewardType: PercentRewardType,
+			Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt,
+		},
+		EffectiveAt: createdAt,
+	}
+	to := GoodRewardVersion{
+		GoodReward: GoodReward{
+			ID: 7, Match: "Bork", MatchType: SubstringMatchType, Reward: MoneyFromInt(7), RewardType: PercentRewardType,
+			MaxPerGood: &maxPerGood, Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour),
+		},
+		EffectiveAt: createdAt.Add(time.Hour),
+	}
+
+	diff, err := NewGoodRewardDiff(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 7, diff.RuleID)
+
+	changes, err := json.Marshal(diff.Changes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"field": "max_per_good", "from": null, "to": 10},
+		{"field": "reward", "from": 5, "to": 7}
+	]`, string(changes))
+
+	diff, err = NewGoodRewardDiff(to, to)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Ledger account types. User accounts are keyed by user id, system accounts
+// use SystemLedgerOwnerID.
+const (
+	UserPointsLedgerAccount     = "USER_POINTS"
+	UserHeldLedgerAccount       = "USER_HELD"
+	IssuanceLedgerAccount       = "SYSTEM_ISSUANCE"
+	RedemptionSinkLedgerAccount = "REDEMPTION_SINK"
+	ExpiredPointsLedgerAccount  = "SYSTEM_EXPIRED"
+)
+
+const SystemLedgerOwnerID = 0
+
+// Ledger entry kinds.
+const (
+	AccrualLedgerEntry    = "ACCRUAL"
+	WithdrawalLedgerEntry = "WITHDRAWAL"
+	HoldLedgerEntry       = "HOLD"
+	CaptureLedgerEntry    = "CAPTURE"
+	ReleaseLedgerEntry    = "RELEASE"
+	RefundLedgerEntry     = "REFUND"
+	ClawbackLedgerEntry   = "CLAWBACK"
+	ExpiryLedgerEntry     = "EXPIRY"
+	AdjustmentLedgerEntry = "ADJUSTMENT"
+)
+
+var ErrUnbalancedLedgerEntry = errors.New("ledger entry postings do not sum to zero")
+
+type LedgerAccount struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	UserID    int       `json:"user_id"`
+	Balance   Money     `json:"balance" swaggertype:"number"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LedgerPosting moves Amount into (positive) or out of (negative) a single
+// account. NoOverdraft makes the whole entry fail with ErrInsufficientFunds
+// if the account would end up below zero.
+type LedgerPosting struct {
+	AccountType string `json:"account_type"`
+	UserID      int    `json:"user_id"`
+	Amount      Money  `json:"amount" swaggertype:"number"`
+	NoOverdraft bool   `json:"-"`
+}
+
+// LedgerEntry is a journal entry. Its postings always sum to zero, so the sum
+// of all account balances stays zero as well.
+type LedgerEntry struct {
+	ID              int             `json:"id"`
+	Kind            string          `json:"kind"`
+	OrderID         string          `json:"order_id"`
+	BalanceActionID *int            `json:"balance_action_id,omitempty"`
+	Postings        []LedgerPosting `json:"postings"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+func (e *LedgerEntry) IsBalanced() bool {
+	if len(e.Postings) < 2 {
+		return false
+	}
+
+	var sum Money
+
+	for _, posting := range e.Postings {
+		sum = sum.Add(posting.Amount)
+	}
+
+	return sum.IsZero()
+}
+
+// NewAccrualLedgerEntry issues amount points to the user.
+func NewAccrualLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    AccrualLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: IssuanceLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount.Neg()},
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount},
+		},
+	}
+}
+
+// NewWithdrawalLedgerEntry redeems amount points from the user. The user
+// account is not allowed to go negative.
+func NewWithdrawalLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    WithdrawalLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount.Neg(), NoOverdraft: true},
+			{AccountType: RedemptionSinkLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount},
+		},
+	}
+}
+
+// NewHoldLedgerEntry moves amount points from the user's spendable account to
+// their held account. The spendable account is not allowed to go negative.
+func NewHoldLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    HoldLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount.Neg(), NoOverdraft: true},
+			{AccountType: UserHeldLedgerAccount, UserID: userID, Amount: amount},
+		},
+	}
+}
+
+// NewCaptureLedgerEntry redeems previously held points.
+func NewCaptureLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    CaptureLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserHeldLedgerAccount, UserID: userID, Amount: amount.Neg(), NoOverdraft: true},
+			{AccountType: RedemptionSinkLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount},
+		},
+	}
+}
+
+// NewReleaseLedgerEntry returns previously held points to the user.
+func NewReleaseLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    ReleaseLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserHeldLedgerAccount, UserID: userID, Amount: amount.Neg(), NoOverdraft: true},
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount},
+		},
+	}
+}
+
+// NewRefundLedgerEntry gives redeemed points back to the user.
+func NewRefundLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    RefundLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: RedemptionSinkLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount.Neg()},
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount},
+		},
+	}
+}
+
+// NewClawbackLedgerEntry takes issued points back from the user. The user
+// account may go negative, which leaves the user in debt until new accruals
+// cover it.
+func NewClawbackLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    ClawbackLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount.Neg()},
+			{AccountType: IssuanceLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount},
+		},
+	}
+}
+
+// NewExpiryLedgerEntry writes off points that outlived their lot.
+func NewExpiryLedgerEntry(userID int, orderID string, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind:    ExpiryLedgerEntry,
+		OrderID: orderID,
+		Postings: []LedgerPosting{
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount.Neg()},
+			{AccountType: ExpiredPointsLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount},
+		},
+	}
+}
+
+// NewAdjustmentLedgerEntry moves amount points between the issuance account
+// and the user: a positive amount credits the user, a negative one debits
+// them and may leave them in debt.
+func NewAdjustmentLedgerEntry(userID int, amount Money) LedgerEntry {
+	return LedgerEntry{
+		Kind: AdjustmentLedgerEntry,
+		Postings: []LedgerPosting{
+			{AccountType: IssuanceLedgerAccount, UserID: SystemLedgerOwnerID, Amount: amount.Neg()},
+			{AccountType: UserPointsLedgerAccount, UserID: userID, Amount: amount},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerEntry_IsBalanced(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Entry    LedgerEntry
+		Expected bool
+	}{
+		{
+			Name:     "accrual",
+			Entry:    NewAccrualLedgerEntry(1, "123", MustParseMoney("37.5")),
+			Expected: true,
+		},
+		{
+			Name:     "withdrawal",
+			Entry:    NewWithdrawalLedgerEntry(1, "123", MustParseMoney("0.01")),
+			Expected: true,
+		},
+		{
+			Name:     "refund",
+			Entry:    NewRefundLedgerEntry(1, "123", MoneyFromInt(20)),
+			Expected: true,
+		},
+		{
+			Name:     "clawback",
+			Entry:    NewClawbackLedgerEntry(1, "123", MustParseMoney("12.34")),
+			Expected: true,
+		},
+		{
+			Name:     "expiry",
+			Entry:    NewExpiryLedgerEntry(1, "123", MustParseMoney("0.99")),
+			Expected: true,
+		},
+		{
+			Name: "unbalanced",
+			Entry: LedgerEntry{
+				Postings: []LedgerPosting{
+					{AccountType: UserPointsLedgerAccount, UserID: 1, Amount: MoneyFromInt(10)},
+					{AccountType: IssuanceLedgerAccount, Amount: MoneyFromInt(-9)},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "single posting",
+			Entry: LedgerEntry{
+				Postings: []LedgerPosting{{AccountType: UserPointsLedgerAccount, UserID: 1}},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, testCase.Entry.IsBalanced())
+		})
+	}
+}
+
+func TestNewWithdrawalLedgerEntry(t *testing.T) {
+	entry := NewWithdrawalLedgerEntry(7, "123", MoneyFromInt(10))
+
+	assert.Equal(t, WithdrawalLedgerEntry, entry.Kind)
+	assert.Len(t, entry.Postings, 2)
+	assert.Equal(t, UserPointsLedgerAccount, entry.Postings[0].AccountType)
+	assert.Equal(t, 7, entry.Postings[0].UserID)
+	assert.Equal(t, MoneyFromInt(-10), entry.Postings[0].Amount)
+	assert.True(t, entry.Postings[0].NoOverdraft)
+}
+
+func TestNewClawbackLedgerEntry(t *testing.T) {
+	entry := NewClawbackLedgerEntry(7, "123", MoneyFromInt(10))
+
+	assert.Equal(t, ClawbackLedgerEntry, entry.Kind)
+	assert.Equal(t, UserPointsLedgerAccount, entry.Postings[0].AccountType)
+	assert.Equal(t, MoneyFromInt(-10), entry.Postings[0].Amount)
+	assert.False(t, entry.Postings[0].NoOverdraft)
+}
+
+func TestNewAdjustmentLedgerEntry(t *testing.T) {
+	for _, amount := range []Money{MoneyFromInt(10), MoneyFromInt(-10)} {
+		entry := NewAdjustmentLedgerEntry(7, amount)
+
+		assert.Equal(t, AdjustmentLedgerEntry, entry.Kind)
+		assert.True(t, entry.IsBalanced())
+		assert.Equal(t, UserPointsLedgerAccount, entry.Postings[1].AccountType)
+		assert.Equal(t, amount, entry.Postings[1].Amount)
+		assert.False(t, entry.Postings[1].NoOverdraft)
+	}
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Login throttle scopes. Failed logins are counted per login and per client IP.
+const (
+	LoginThrottleScope = "LOGIN"
+	IPThrottleScope    = "IP"
+)
+
+var (
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+	ErrLoginLocked          = errors.New("login is temporarily locked")
+)
+
+// LoginThrottle is the failure counter of one login or one client IP.
+type LoginThrottle struct {
+	Scope        string     `json:"scope"`
+	Key          string     `json:"key"`
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	BlockedUntil *time.Time `json:"blocked_until,omitempty"`
+}
+
+// LoginThrottlePolicy decides how long to wait after the n-th failure in a
+// row. The first FreeAttempts failures cost nothing, then the delay doubles
+// from BaseDelay up to MaxDelay. A login that reaches LockoutThreshold
+// failures is locked for LockoutDuration after every further failure. IPs are
+// never locked, only slowed down, since many users may share one.
+type LoginThrottlePolicy struct {
+	FreeAttempts     int
+	IPFreeAttempts   int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	// FailureWindow is how long a counter survives without new failures.
+	FailureWindow time.Duration
+}
+
+func DefaultLoginThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		FreeAttempts:     3,
+		IPFreeAttempts:   20,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute * 5,
+		LockoutThreshold: 10,
+		LockoutDuration:  time.Minute * 30,
+		FailureWindow:    time.Hour,
+	}
+}
+
+// Delay returns how long the scope stays blocked after failures failures in a
+// row, and whether that block is a lockout.
+func (p LoginThrottlePolicy) Delay(scope string, failures int) (time.Duration, bool) {
+	freeAttempts := p.FreeAttempts
+
+	if scope == IPThrottleScope {
+		freeAttempts = p.IPFreeAttempts
+	} else if p.LockoutThreshold > 0 && failures >= p.LockoutThreshold {
+		return p.LockoutDuration, true
+	}
+
+	if failures <= freeAttempts {
+		return 0, false
+	}
+
+	exponent := float64(failures - freeAttempts - 1)
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(2, exponent))
+
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay, false
+}
+
+// IsLockout tells a lockout from a plain backoff for a scope that is blocked.
+func (p LoginThrottlePolicy) IsLockout(throttle *LoginThrottle) bool {
+	_, locked := p.Delay(throttle.Scope, throttle.Failures)
+	return locked
+}
+
+// LoginThrottledError wraps ErrTooManyLoginAttempts or ErrLoginLocked with the
+// time left until the next attempt is accepted.
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %d seconds", e.Err, e.RetryAfterSeconds())
+}
+
+func (e LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterSeconds rounds up, so a client that waits that long is never early.
+func (e LoginThrottledError) RetryAfterSeconds() int {
+	seconds := int(math.Ceil(e.RetryAfter.Seconds()))
+
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottlePolicy_Delay(t *testing.T) {
+	policy := DefaultLoginThrottlePolicy()
+
+	testCases := []struct {
+		Name           string
+		Scope          string
+		Failures       int
+		ExpectedDelay  time.Duration
+		ExpectedLocked bool
+	}{
+		{Name: "free attempt", Scope: LoginThrottleScope, Failures: 3},
+		{Name: "first backoff", Scope: LoginThrottleScope, Failures: 4, ExpectedDelay: time.Second},
+		{Name: "doubles", Scope: LoginThrottleScope, Failures: 6, ExpectedDelay: time.Second * 4},
+		{Name: "lockout", Scope: LoginThrottleScope, Failures: 10, ExpectedDelay: time.Minute * 30, ExpectedLocked: true},
+		{Name: "lockout again", Scope: LoginThrottleScope, Failures: 15, ExpectedDelay: time.Minute * 30, ExpectedLocked: true},
+		{Name: "ip free attempt", Scope: IPThrottleScope, Failures: 20},
+		{Name: "ip backoff", Scope: IPThrottleScope, Failures: 22, ExpectedDelay: time.Second * 2},
+		{Name: "ip capped, never locked", Scope: IPThrottleScope, Failures: 500, ExpectedDelay: time.Minute * 5},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			delay, locked := policy.Delay(testCase.Scope, testCase.Failures)
+
+			assert.Equal(t, testCase.ExpectedDelay, delay)
+			assert.Equal(t, testCase.ExpectedLocked, locked)
+		})
+	}
+}
+
+func TestLoginThrottledError(t *testing.T) {
+	err := error(LoginThrottledError{Err: ErrLoginLocked, RetryAfter: time.Millisecond * 1500})
+
+	assert.True(t, errors.Is(err, ErrLoginLocked))
+
+	var throttledErr LoginThrottledError
+	assert.True(t, errors.As(err, &throttledErr))
+	assert.Equal(t, 2, throttledErr.RetryAfterSeconds())
+}
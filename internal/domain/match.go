@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match types of a reward rule, see GoodReward.Matcher.
+const (
+	SubstringMatchType = "substring"
+	ExactMatchType     = "exact"
+	PrefixMatchType    = "prefix"
+	WordMatchType      = "word"
+	RegexMatchType     = "regex"
+)
+
+// Match fields of a reward rule: the field of an order good its match is
+// compared with. SKUs and categories are codes, so they are only matched
+// exactly.
+const (
+	DescriptionMatchField = "description"
+	SKUMatchField         = "sku"
+	CategoryMatchField    = "category"
+)
+
+var validMatchFields = map[string]struct{}{
+	DescriptionMatchField: {},
+	SKUMatchField:         {},
+	CategoryMatchField:    {},
+}
+
+// MaxMatchLength is the size of the good_rewards.match column.
+const MaxMatchLength = 255
+
+var validMatchTypes = map[string]struct{}{
+	SubstringMatchType: {},
+	ExactMatchType:     {},
+	PrefixMatchType:    {},
+	WordMatchType:      {},
+	RegexMatchType:     {},
+}
+
+var ErrInvalidMatch = errors.New("invalid match")
+
+func IsValidMatchType(matchType string) bool {
+	_, ok := validMatchTypes[matchType]
+	return ok
+}
+
+func IsValidMatchField(matchField string) bool {
+	_, ok := validMatchFields[matchField]
+	return ok
+}
+
+// Matcher tells whether a good description satisfies a rule.
+type Matcher func(description string) bool
+
+// NewMatcher builds the matcher of a rule. An empty match type means
+// substring, the only behaviour rules had before match types existed.
+//
+//   - substring: match appears anywhere in the description;
+//   - exact: the description is match;
+//   - prefix: the description starts with match;
+//   - word: match appears with no letter or digit right before or after it,
+//     so "Bork" matches "Bork kettle" but not "Borkland";
+//   - regex: the RE2 expression match is found anywhere in the description.
+//
+// With ignoreCase both sides are compared folded with FoldCase.
+func NewMatcher(matchType string, match string, ignoreCase bool) (Matcher, error) {
+	if match == "" || len(match) > MaxMatchLength {
+		return nil, fmt.Errorf("%w: must be 1 to %d bytes long", ErrInvalidMatch, MaxMatchLength)
+	}
+
+	if matchType == RegexMatchType {
+		if ignoreCase {
+			match = "(?i)" + match
+		}
+
+		expr, err := regexp.Compile(match)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMatch, err)
+		}
+
+		return expr.MatchString, nil
+	}
+
+	var compare func(description string, match string) bool
+
+	switch matchType {
+	case SubstringMatchType, "":
+		compare = strings.Contains
+	case ExactMatchType:
+		compare = func(description string, match string) bool { return description == match }
+	case PrefixMatchType:
+		compare = strings.HasPrefix
+	case WordMatchType:
+		compare = containsWord
+	default:
+		return nil, fmt.Errorf("%w: unknown match type %q", ErrInvalidMatch, matchType)
+	}
+
+	if !ignoreCase {
+		return func(description string) bool { return compare(description, match) }, nil
+	}
+
+	match = FoldCase(match)
+
+	return func(description string) bool { return compare(FoldCase(description), match) }, nil
+}
+
+// FoldCase is how rules that ignore case fold both sides before comparing
+// them. It is done here rather than with the database lower(), which only
+// folds ASCII letters under some locales.
+func FoldCase(s string) string {
+	return strings.ToLower(s)
+}
+
+// containsWord reports whether word occurs in s with no letter or digit
+// adjacent to it.
+func containsWord(s string, word string) bool {
+	for offset := 0; offset <= len(s); {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return false
+		}
+
+		start := offset + i
+		end := start + len(word)
+
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(s[start:])
+		offset = start + size
+	}
+
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMatcher(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		MatchType   string
+		Match       string
+		IgnoreCase  bool
+		Description string
+		Expected    bool
+	}{
+		{Name: "substring", MatchType: SubstringMatchType, Match: "Bork", Description: "Borkland kettle", Expected: true},
+		{Name: "substring (empty type)", Match: "Bork", Description: "Borkland kettle", Expected: true},
+		{Name: "substring (case)", MatchType: SubstringMatchType, Match: "Bork", Description: "bork kettle", Expected: false},
+		{Name: "substring (ignore case)", MatchType: SubstringMatchType, Match: "Bork", IgnoreCase: true, Description: "BORK kettle", Expected: true},
+		{Name: "substring (no wildcards)", MatchType: SubstringMatchType, Match: "50%", Description: "500 pcs", Expected: false},
+		{Name: "exact", MatchType: ExactMatchType, Match: "Bork", Description: "Bork", Expected: true},
+		{Name: "exact (longer)", MatchType: ExactMatchType, Match: "Bork", Description: "Bork kettle", Expected: false},
+		{Name: "prefix", MatchType: PrefixMatchType, Match: "Bork", Description: "Borkland", Expected: true},
+		{Name: "prefix (middle)", MatchType: PrefixMatchType, Match: "Bork", Description: "New Bork", Expected: false},
+		{Name: "word", MatchType: WordMatchType, Match: "Bork", Description: "Kettle Bork K810", Expected: true},
+		{Name: "word (part of a word)", MatchType: WordMatchType, Match: "Bork", Description: "Borkland", Expected: false},
+		{Name: "word (second occurrence)", MatchType: WordMatchType, Match: "Bork", Description: "Borkland Bork", Expected: true},
+		{Name: "word (punctuation)", MatchType: WordMatchType, Match: "Bork", Description: "(Bork)", Expected: true},
+		{Name: "substring (cyrillic ignore case)", MatchType: SubstringMatchType, Match: "яблоко", IgnoreCase: true, Description: "ЯБЛОКО", Expected: true},
+		{Name: "exact (cyrillic ignore case)", MatchType: ExactMatchType, Match: "Яблоко", IgnoreCase: true, Description: "яблоко", Expected: true},
+		{Name: "word (cyrillic)", MatchType: WordMatchType, Match: "чайник", IgnoreCase: true, Description: "Электрический Чайник", Expected: true},
+		{Name: "regex", MatchType: RegexMatchType, Match: `^Bork K\d+$`, Description: "Bork K810", Expected: true},
+		{Name: "regex (no match)", MatchType: RegexMatchType, Match: `^Bork K\d+$`, Description: "Bork Kettle", Expected: false},
+		{Name: "regex (ignore case)", MatchType: RegexMatchType, Match: `bork`, IgnoreCase: true, Description: "BORK", Expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matcher, err := NewMatcher(testCase.MatchType, testCase.Match, testCase.IgnoreCase)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, matcher(testCase.Description))
+		})
+	}
+}
+
+func TestNewMatcher_Invalid(t *testing.T) {
+	_, err := NewMatcher("fuzzy", "Bork", false)
+	assert.ErrorIs(t, err, ErrInvalidMatch)
+
+	_, err = NewMatcher(RegexMatchType, "Bork(", false)
+	assert.ErrorIs(t, err, ErrInvalidMatch)
+
+	_, err = NewMatcher(ExactMatchType, "", false)
+	assert.ErrorIs(t, err, ErrInvalidMatch)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// MerchantHeader picks the merchant a request is for. Keys bound to a
+// merchant do not need it; platform keys, such as the one gophermart uses,
+// get the default merchant without it.
+const MerchantHeader = "X-Merchant"
+
+// DefaultMerchantCode is the merchant everything belonged to before the
+// accrual system knew about merchants.
+const DefaultMerchantCode = "default"
+
+var (
+	ErrInvalidMerchantCode = errors.New("invalid merchant code")
+	ErrMerchantCodeTaken   = errors.New("merchant code already taken")
+	ErrUnknownMerchant     = errors.New("unknown merchant")
+	ErrForeignMerchant     = errors.New("api key belongs to another merchant")
+)
+
+var merchantCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Merchant owns a reward catalog and an order namespace in the accrual system.
+type Merchant struct {
+	ID        int       `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func IsValidMerchantCode(code string) bool {
+	return merchantCodePattern.MatchString(code)
+}
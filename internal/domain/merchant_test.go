@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidMerchantCode(t *testing.T) {
+	assert.True(t, IsValidMerchantCode(DefaultMerchantCode))
+	assert.True(t, IsValidMerchantCode("shop-42_eu"))
+	assert.False(t, IsValidMerchantCode(""))
+	assert.False(t, IsValidMerchantCode("-shop"))
+	assert.False(t, IsValidMerchantCode("Shop"))
+	assert.False(t, IsValidMerchantCode("shop.eu"))
+	assert.False(t, IsValidMerchantCode(strings.Repeat("a", 65)))
+}
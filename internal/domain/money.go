@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale is the number of fractional digits kept by Money.
+const MoneyScale = 2
+
+const moneyFactor = 100
+
+// MaxMoney and MinMoney are the largest and smallest amounts Money holds.
+var (
+	MaxMoney = Money{minor: math.MaxInt64}
+	MinMoney = Money{minor: math.MinInt64}
+)
+
+var (
+	ErrInvalidMoney  = errors.New("invalid money value")
+	ErrMoneyOverflow = errors.New("money value overflow")
+)
+
+// Money is an exact fixed-point amount of loyalty points stored as an integer
+// number of hundredths. Every operation that can produce more than MoneyScale
+// fractional digits rounds half away from zero, the same rule PostgreSQL applies
+// to ROUND(numeric), so values computed in Go and in SQL always agree.
+// Arithmetic saturates: results beyond the range of Money are MaxMoney or
+// MinMoney rather than wrapping around, so they stay on the right side of
+// every comparison.
+type Money struct {
+	minor int64
+}
+
+// MoneyFromMinor builds Money from an integer number of hundredths.
+func MoneyFromMinor(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// MoneyFromInt builds Money from a whole number of points.
+func MoneyFromInt(units int64) Money {
+	return Money{minor: units * moneyFactor}
+}
+
+// ParseMoney parses a decimal string such as "10", "-3.5" or "47399.99".
+// Digits beyond MoneyScale are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, ErrInvalidMoney
+	}
+
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	return moneyFromRat(rat)
+}
+
+// MustParseMoney is like ParseMoney but panics on error. It is meant for
+// constants and tests.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func moneyFromRat(rat *big.Rat) (Money, error) {
+	scaled := new(big.Rat).Mul(rat, big.NewRat(moneyFactor, 1))
+	minor := roundHalfAwayFromZero(scaled.Num(), scaled.Denom())
+
+	if !minor.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return Money{minor: minor.Int64()}, nil
+}
+
+func roundHalfAwayFromZero(num, denom *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom) >= 0 {
+		if num.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo
+}
+
+// Minor returns the amount as an integer number of hundredths.
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+func (m Money) Add(other Money) Money {
+	sum := m.minor + other.minor
+
+	if (other.minor > 0 && sum < m.minor) || (other.minor < 0 && sum > m.minor) {
+		return saturated(other.minor > 0)
+	}
+
+	return Money{minor: sum}
+}
+
+func (m Money) Sub(other Money) Money {
+	difference := m.minor - other.minor
+
+	if (other.minor < 0 && difference < m.minor) || (other.minor > 0 && difference > m.minor) {
+		return saturated(other.minor < 0)
+	}
+
+	return Money{minor: difference}
+}
+
+func (m Money) Neg() Money {
+	if m.minor == math.MinInt64 {
+		return MaxMoney
+	}
+
+	return Money{minor: -m.minor}
+}
+
+func (m Money) Abs() Money {
+	if m.minor < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// Mul multiplies the amount by an integer factor, e.g. a quantity.
+func (m Money) Mul(factor int64) Money {
+	return saturatedFromBig(new(big.Int).Mul(big.NewInt(m.minor), big.NewInt(factor)))
+}
+
+// Percent returns rate percent of the amount, where rate itself is a Money
+// value (so 7.5% is MustParseMoney("7.5")). The result is rounded half away
+// from zero to MoneyScale digits.
+func (m Money) Percent(rate Money) Money {
+	num := new(big.Int).Mul(big.NewInt(m.minor), big.NewInt(rate.minor))
+	minor := roundHalfAwayFromZero(num, big.NewInt(100*moneyFactor))
+
+	return saturatedFromBig(minor)
+}
+
+func saturatedFromBig(minor *big.Int) Money {
+	if minor.IsInt64() {
+		return Money{minor: minor.Int64()}
+	}
+
+	return saturated(minor.Sign() > 0)
+}
+
+func saturated(positive bool) Money {
+	if positive {
+		return MaxMoney
+	}
+
+	return MinMoney
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than other.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.minor < other.minor:
+		return -1
+	case m.minor > other.minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) Sign() int {
+	return m.Cmp(Money{})
+}
+
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// String formats the amount with exactly MoneyScale fractional digits.
+func (m Money) String() string {
+	sign := ""
+	minor := m.minor
+
+	if minor < 0 {
+		sign = "-"
+	}
+
+	units := minor / moneyFactor
+	frac := minor % moneyFactor
+
+	if units < 0 {
+		units = -units
+	}
+
+	if frac < 0 {
+		frac = -frac
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, units, frac)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+
+	if raw == "null" {
+		return nil
+	}
+
+	parsed, err := ParseMoney(raw)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(m).Elem()}
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(data []byte) error {
+	parsed, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so Money can be written to NUMERIC columns.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner so Money can be read from NUMERIC columns.
+// A NULL value scans as zero.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case []byte:
+		return m.UnmarshalText(v)
+	case int64:
+		if v > math.MaxInt64/moneyFactor || v < math.MinInt64/moneyFactor {
+			return ErrMoneyOverflow
+		}
+
+		*m = MoneyFromInt(v)
+		return nil
+	case float64:
+		return m.UnmarshalText([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+	}
+}
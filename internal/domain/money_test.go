@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    string
+		Expected int64
+		Err      bool
+	}{
+		{Name: "integer", Input: "10", Expected: 1000},
+		{Name: "two digits", Input: "47399.99", Expected: 4739999},
+		{Name: "one digit", Input: "-3.5", Expected: -350},
+		{Name: "round half up", Input: "0.005", Expected: 1},
+		{Name: "round half away from zero", Input: "-0.005", Expected: -1},
+		{Name: "round down", Input: "1.004999", Expected: 100},
+		{Name: "exponent", Input: "1e2", Expected: 10000},
+		{Name: "empty", Input: "", Err: true},
+		{Name: "garbage", Input: "ten", Err: true},
+		{Name: "fraction", Input: "1/3", Err: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m, err := ParseMoney(testCase.Input)
+
+			if testCase.Err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, m.Minor())
+		})
+	}
+}
+
+func TestMoney_Percent(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Amount   string
+		Rate     string
+		Expected string
+	}{
+		{Name: "whole", Amount: "100", Rate: "10", Expected: "10.00"},
+		{Name: "fractional rate", Amount: "33.33", Rate: "7.5", Expected: "2.50"},
+		{Name: "float drift case", Amount: "0.1", Rate: "30", Expected: "0.03"},
+		{Name: "negative", Amount: "-33.33", Rate: "7.5", Expected: "-2.50"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result := MustParseMoney(testCase.Amount).Percent(MustParseMoney(testCase.Rate))
+			assert.Equal(t, testCase.Expected, result.String())
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		data, err := json.Marshal([]Money{MustParseMoney("500.5"), MustParseMoney("-0.07"), {}})
+		require.NoError(t, err)
+		assert.Equal(t, `[500.50,-0.07,0.00]`, string(data))
+	})
+
+	t.Run("unmarshal number and string", func(t *testing.T) {
+		var good OrderGood
+		require.NoError(t, json.Unmarshal([]byte(`{"description":"Bork","price":0.3}`), &good))
+		assert.Equal(t, MoneyFromMinor(30), good.Price)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"description":"Bork","price":"12.34"}`), &good))
+		assert.Equal(t, MoneyFromMinor(1234), good.Price)
+	})
+
+	t.Run("unmarshal invalid", func(t *testing.T) {
+		var good OrderGood
+		assert.Error(t, json.Unmarshal([]byte(`{"price":"abc"}`), &good))
+	})
+}
+
+func TestMoney_Scan(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Src      any
+		Expected Money
+	}{
+		{Name: "numeric text", Src: "12.50", Expected: MoneyFromMinor(1250)},
+		{Name: "bytes", Src: []byte("3"), Expected: MoneyFromInt(3)},
+		{Name: "int64", Src: int64(7), Expected: MoneyFromInt(7)},
+		{Name: "float64", Src: 0.1, Expected: MoneyFromMinor(10)},
+		{Name: "null", Src: nil, Expected: Money{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m := MoneyFromInt(99)
+			require.NoError(t, m.Scan(testCase.Src))
+			assert.Equal(t, testCase.Expected, m)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		var m Money
+		assert.ErrorIs(t, m.Scan(true), ErrInvalidMoney)
+	})
+}
+
+func TestMoney_Saturation(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Result   Money
+		Expected Money
+	}{
+		{Name: "add", Result: MaxMoney.Add(MoneyFromMinor(1)), Expected: MaxMoney},
+		{Name: "add negative", Result: MinMoney.Add(MoneyFromMinor(-1)), Expected: MinMoney},
+		{Name: "add in range", Result: MaxMoney.Add(MoneyFromMinor(-1)), Expected: MoneyFromMinor(math.MaxInt64 - 1)},
+		{Name: "sub", Result: MinMoney.Sub(MoneyFromMinor(1)), Expected: MinMoney},
+		{Name: "sub negative", Result: MaxMoney.Sub(MoneyFromMinor(-1)), Expected: MaxMoney},
+		{Name: "neg", Result: MinMoney.Neg(), Expected: MaxMoney},
+		{Name: "mul", Result: MoneyFromInt(math.MaxInt64 / 1000).Mul(1000), Expected: MaxMoney},
+		{Name: "mul negative", Result: MoneyFromInt(math.MaxInt64 / 1000).Mul(-1000), Expected: MinMoney},
+		{Name: "mul in range", Result: MoneyFromInt(3).Mul(4), Expected: MoneyFromInt(12)},
+		{Name: "percent", Result: MaxMoney.Percent(MoneyFromInt(200)), Expected: MaxMoney},
+		{Name: "percent negative", Result: MinMoney.Percent(MoneyFromInt(200)), Expected: MinMoney},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, testCase.Result)
+		})
+	}
+}
@@ -10,9 +10,19 @@ const (
 )
 
 type UserOrder struct {
-	OrderID    string    `json:"order_id"`
-	UserID     int       `json:"user_id"`
-	Status     string    `json:"status"`
-	Accrual    *float64  `json:"accrual,omitempty"`
-	UploadedAt time.Time `json:"uploaded_at"`
+	OrderID        string     `json:"order_id"`
+	UserID         int        `json:"user_id"`
+	Merchant       string     `json:"merchant"`
+	Status         string     `json:"status"`
+	Accrual        *Money     `json:"accrual,omitempty" swaggertype:"number"`
+	UploadedAt     time.Time  `json:"uploaded_at"`
+	ReversalReason *string    `json:"reversal_reason,omitempty"`
+	ReversedAt     *time.Time `json:"reversed_at,omitempty"`
+}
+
+// UserOrderDetails is a user order with the breakdown of its accrual, nil
+// until the accrual system explained it.
+type UserOrderDetails struct {
+	UserOrder
+	Breakdown *AccrualBreakdown `json:"breakdown,omitempty"`
 }
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Order-level reward types. They are evaluated over the basket of goods the
+// rule matches, after the per-good rules, and give at most one reward per
+// order:
+//
+//   - order_pt: Reward points;
+//   - order_%: Reward percent of the basket;
+//   - tiered_pt: the points of the highest tier the basket reaches;
+//   - tiered_%: each tier's percent of the part of the basket between its
+//     threshold and the next one, so tiers {0: 3, 5000: 5} give 3% of the
+//     first 5000 and 5% of the rest.
+//
+// A rule with MinBasket only applies to baskets of at least that much.
+const (
+	OrderPointRewardType    = "order_pt"
+	OrderPercentRewardType  = "order_%"
+	TieredPointRewardType   = "tiered_pt"
+	TieredPercentRewardType = "tiered_%"
+)
+
+var ErrInvalidReward = errors.New("invalid reward")
+
+// RewardTier is a step of a tiered rule: Reward, points or percent depending
+// on the rule, applies once the basket reaches Threshold.
+type RewardTier struct {
+	Threshold Money `json:"threshold" swaggertype:"number"`
+	Reward    Money `json:"reward" swaggertype:"number"`
+}
+
+// IsOrderRewardType tells whether rules of the type reward the whole order
+// rather than each good.
+func IsOrderRewardType(rewardType string) bool {
+	switch rewardType {
+	case OrderPointRewardType, OrderPercentRewardType, TieredPointRewardType, TieredPercentRewardType:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTieredRewardType tells whether rules of the type take their reward from
+// tiers instead of Reward.
+func IsTieredRewardType(rewardType string) bool {
+	return rewardType == TieredPointRewardType || rewardType == TieredPercentRewardType
+}
+
+// checkReward makes sure the reward fields fit the reward type.
+func (r *GoodReward) checkReward() error {
+	if !IsValidRewardType(r.RewardType) {
+		return fmt.Errorf("%w: unknown reward type %q", ErrInvalidReward, r.RewardType)
+	}
+
+	if !IsOrderRewardType(r.RewardType) {
+		if r.MinBasket != nil || len(r.Tiers) > 0 {
+			return fmt.Errorf("%w: min_basket and tiers are only for order rules", ErrInvalidReward)
+		}
+	} else if r.MaxPerGood != nil {
+		return fmt.Errorf("%w: order rules can not have max_per_good", ErrInvalidReward)
+	}
+
+	if r.MinBasket != nil && r.MinBasket.IsNegative() {
+		return fmt.Errorf("%w: min_basket must not be negative", ErrInvalidReward)
+	}
+
+	if !IsTieredRewardType(r.RewardType) {
+		if !r.Reward.IsPositive() {
+			return fmt.Errorf("%w: reward must be positive", ErrInvalidReward)
+		}
+
+		if len(r.Tiers) > 0 {
+			return fmt.Errorf("%w: tiers are only for tiered rules", ErrInvalidReward)
+		}
+
+		return nil
+	}
+
+	if !r.Reward.IsZero() {
+		return fmt.Errorf("%w: tiered rules take their reward from tiers", ErrInvalidReward)
+	}
+
+	if len(r.Tiers) == 0 {
+		return fmt.Errorf("%w: tiered rules need tiers", ErrInvalidReward)
+	}
+
+	for i, tier := range r.Tiers {
+		if tier.Threshold.IsNegative() || !tier.Reward.IsPositive() {
+			return fmt.Errorf("%w: tier thresholds must not be negative and rewards must be positive", ErrInvalidReward)
+		}
+
+		if i > 0 && tier.Threshold.Cmp(r.Tiers[i-1].Threshold) <= 0 {
+			return fmt.Errorf("%w: tier thresholds must go up", ErrInvalidReward)
+		}
+	}
+
+	return nil
+}
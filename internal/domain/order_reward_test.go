@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoodReward_Check_Reward(t *testing.T) {
+	minBasket := MoneyFromInt(10000)
+	maxPerGood := MoneyFromInt(10)
+	tiers := []RewardTier{
+		{Threshold: MoneyFromInt(0), Reward: MoneyFromInt(3)},
+		{Threshold: MoneyFromInt(5000), Reward: MoneyFromInt(5)},
+	}
+
+	testCases := []struct {
+		Name   string
+		Reward GoodReward
+		Valid  bool
+	}{
+		{
+			Name:   "per-good rule",
+			Reward: GoodReward{Match: "Bork", RewardType: PercentRewardType, Reward: MoneyFromInt(5)},
+			Valid:  true,
+		},
+		{
+			Name:   "whole basket bonus",
+			Reward: GoodReward{RewardType: OrderPointRewardType, Reward: MoneyFromInt(500), MinBasket: &minBasket},
+			Valid:  true,
+		},
+		{
+			Name:   "tiered",
+			Reward: GoodReward{RewardType: TieredPercentRewardType, Tiers: tiers},
+			Valid:  true,
+		},
+		{
+			Name:   "per-good rule with a minimum basket",
+			Reward: GoodReward{Match: "Bork", RewardType: PointRewardType, Reward: MoneyFromInt(5), MinBasket: &minBasket},
+		},
+		{
+			Name:   "order rule with a per-good cap",
+			Reward: GoodReward{RewardType: OrderPercentRewardType, Reward: MoneyFromInt(5), MaxPerGood: &maxPerGood},
+		},
+		{
+			Name:   "tiered rule without tiers",
+			Reward: GoodReward{RewardType: TieredPointRewardType},
+		},
+		{
+			Name:   "tiered rule with a reward",
+			Reward: GoodReward{RewardType: TieredPointRewardType, Reward: MoneyFromInt(5), Tiers: tiers},
+		},
+		{
+			Name: "tiers going down",
+			Reward: GoodReward{RewardType: TieredPercentRewardType, Tiers: []RewardTier{
+				{Threshold: MoneyFromInt(5000), Reward: MoneyFromInt(5)},
+				{Threshold: MoneyFromInt(0), Reward: MoneyFromInt(3)},
+			}},
+		},
+		{
+			Name:   "unknown reward type",
+			Reward: GoodReward{Match: "Bork", RewardType: "coupon", Reward: MoneyFromInt(5)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Reward.Check()
+
+			if testCase.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidReward)
+			}
+		})
+	}
+}
+
+func TestGoodReward_Matcher_WholeBasket(t *testing.T) {
+	reward := GoodReward{RewardType: OrderPointRewardType}
+
+	matches, err := reward.Matcher()
+	assert.NoError(t, err)
+	assert.True(t, matches("anything"))
+
+	reward.RewardType = PointRewardType
+
+	_, err = reward.Matcher()
+	assert.ErrorIs(t, err, ErrInvalidMatch)
+}
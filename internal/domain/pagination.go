@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+const (
+	AscSortDirection  = "asc"
+	DescSortDirection = "desc"
+)
+
+var (
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidHistoryFilter = errors.New("invalid history filter")
+)
+
+// PageCursor points at the last row of a page. Rows are ordered by Time and
+// then by Key, so the pair identifies a position even when times collide.
+type PageCursor struct {
+	Time time.Time `json:"t"`
+	Key  string    `json:"k"`
+}
+
+// Encode returns the opaque string clients pass back to get the next page.
+func (c PageCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodePageCursor(s string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor PageCursor
+
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Key == "" || cursor.Time.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// HistoryFilter describes one page of a user history list. From is inclusive
+// and To is exclusive.
+type HistoryFilter struct {
+	Cursor *PageCursor
+	Limit  int
+	Status string
+	From   *time.Time
+	To     *time.Time
+	Sort   string
+}
+
+// Normalize fills in the default limit and sort direction and checks the
+// filter. An empty status matches everything, otherwise it must be one of
+// statuses.
+func (f *HistoryFilter) Normalize(defaultSort string, statuses ...string) error {
+	if f.Limit == 0 {
+		f.Limit = DefaultPageLimit
+	}
+
+	if f.Limit < 0 || f.Limit > MaxPageLimit {
+		return ErrInvalidHistoryFilter
+	}
+
+	if f.Sort == "" {
+		f.Sort = defaultSort
+	}
+
+	f.Sort = strings.ToLower(f.Sort)
+
+	if f.Sort != AscSortDirection && f.Sort != DescSortDirection {
+		return ErrInvalidHistoryFilter
+	}
+
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidHistoryFilter
+	}
+
+	if f.Status == "" {
+		return nil
+	}
+
+	for _, status := range statuses {
+		if f.Status == status {
+			return nil
+		}
+	}
+
+	return ErrInvalidHistoryFilter
+}
+
+func (f *HistoryFilter) IsAscending() bool {
+	return strings.EqualFold(f.Sort, AscSortDirection)
+}
+
+type UserOrdersPage struct {
+	Items      []UserOrder
+	NextCursor *string
+}
+
+type BalanceActionsPage struct {
+	Items      []BalanceAction
+	NextCursor *string
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor(t *testing.T) {
+	t.Run("valid (round trip)", func(t *testing.T) {
+		cursor := PageCursor{Time: time.Date(2026, 10, 17, 9, 30, 0, 123456000, time.UTC), Key: "12345678903"}
+
+		decoded, err := DecodePageCursor(cursor.Encode())
+		require.NoError(t, err)
+		assert.True(t, cursor.Time.Equal(decoded.Time))
+		assert.Equal(t, cursor.Key, decoded.Key)
+	})
+
+	t.Run("invalid (not base64)", func(t *testing.T) {
+		decoded, err := DecodePageCursor("%%%")
+		require.ErrorIs(t, err, ErrInvalidCursor)
+		assert.Nil(t, decoded)
+	})
+
+	t.Run("invalid (empty key)", func(t *testing.T) {
+		decoded, err := DecodePageCursor(PageCursor{Time: time.Now()}.Encode())
+		require.ErrorIs(t, err, ErrInvalidCursor)
+		assert.Nil(t, decoded)
+	})
+}
+
+func TestHistoryFilter_Normalize(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour * 24)
+
+	testCases := []struct {
+		Name          string
+		Filter        HistoryFilter
+		ExpectedErr   error
+		ExpectedLimit int
+		ExpectedSort  string
+	}{
+		{
+			Name:          "valid (defaults)",
+			Filter:        HistoryFilter{},
+			ExpectedLimit: DefaultPageLimit,
+			ExpectedSort:  DescSortDirection,
+		},
+		{
+			Name:          "valid (all set)",
+			Filter:        HistoryFilter{Limit: 10, Sort: "ASC", Status: ProcessedOrderStatus, From: &from, To: &to},
+			ExpectedLimit: 10,
+			ExpectedSort:  AscSortDirection,
+		},
+		{
+			Name:        "invalid (limit too big)",
+			Filter:      HistoryFilter{Limit: MaxPageLimit + 1},
+			ExpectedErr: ErrInvalidHistoryFilter,
+		},
+		{
+			Name:        "invalid (unknown sort)",
+			Filter:      HistoryFilter{Sort: "sideways"},
+			ExpectedErr: ErrInvalidHistoryFilter,
+		},
+		{
+			Name:        "invalid (unknown status)",
+			Filter:      HistoryFilter{Status: "LOST"},
+			ExpectedErr: ErrInvalidHistoryFilter,
+		},
+		{
+			Name:        "invalid (empty range)",
+			Filter:      HistoryFilter{From: &to, To: &from},
+			ExpectedErr: ErrInvalidHistoryFilter,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			filter := testCase.Filter
+			err := filter.Normalize(DescSortDirection, ProcessedOrderStatus, InvalidOrderStatus)
+
+			if testCase.ExpectedErr != nil {
+				require.ErrorIs(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedLimit, filter.Limit)
+			assert.Equal(t, testCase.ExpectedSort, filter.Sort)
+		})
+	}
+}
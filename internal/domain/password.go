@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes is the most bcrypt looks at, anything longer would be
+// silently cut off.
+const MaxPasswordBytes = 72
+
+var (
+	ErrWeakPassword         = errors.New("password does not meet the policy")
+	ErrWrongPassword        = errors.New("current password is wrong")
+	ErrInvalidResetToken    = errors.New("invalid or expired password reset token")
+	ErrPasswordNotChanged   = errors.New("new password must differ from the current one")
+	ErrInvalidPasswordRules = errors.New("invalid password policy")
+)
+
+// PasswordPolicy is what a new password has to satisfy, both on registration
+// and when it is changed or reset. The default only asks for the six
+// characters registration has always required.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireLetter bool
+	RequireDigit  bool
+	// RejectLogin refuses passwords that contain the login.
+	RejectLogin bool
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength: 6,
+	}
+}
+
+// Check makes sure the policy itself is usable.
+func (p PasswordPolicy) Check() error {
+	if p.MinLength < 1 || p.MinLength > MaxPasswordBytes {
+		return fmt.Errorf("%w: minimum length must be between 1 and %d", ErrInvalidPasswordRules, MaxPasswordBytes)
+	}
+
+	return nil
+}
+
+// Validate returns ErrWeakPassword, wrapped with the broken rule, when
+// password does not satisfy the policy.
+func (p PasswordPolicy) Validate(login string, password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("%w: at least %d characters required", ErrWeakPassword, p.MinLength)
+	}
+
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("%w: at most %d bytes allowed", ErrWeakPassword, MaxPasswordBytes)
+	}
+
+	if p.RequireLetter && strings.IndexFunc(password, unicode.IsLetter) < 0 {
+		return fmt.Errorf("%w: a letter is required", ErrWeakPassword)
+	}
+
+	if p.RequireDigit && strings.IndexFunc(password, unicode.IsDigit) < 0 {
+		return fmt.Errorf("%w: a digit is required", ErrWeakPassword)
+	}
+
+	if p.RejectLogin && login != "" && strings.Contains(strings.ToLower(password), strings.ToLower(login)) {
+		return fmt.Errorf("%w: must not contain the login", ErrWeakPassword)
+	}
+
+	return nil
+}
+
+// PasswordResetToken lets a user set a new password without knowing the
+// current one. Only the hash of Token is stored; the token itself is shown
+// once, when it is issued.
+type PasswordResetToken struct {
+	UserID    int       `json:"user_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
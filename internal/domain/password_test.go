@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:     8,
+		RequireLetter: true,
+		RequireDigit:  true,
+		RejectLogin:   true,
+	}
+
+	testCases := []struct {
+		Name     string
+		Policy   PasswordPolicy
+		Login    string
+		Password string
+		Valid    bool
+	}{
+		{Name: "default", Policy: DefaultPasswordPolicy(), Login: "alice", Password: "secret", Valid: true},
+		{Name: "default too short", Policy: DefaultPasswordPolicy(), Login: "alice", Password: "short"},
+		{Name: "length counts characters", Policy: DefaultPasswordPolicy(), Login: "alice", Password: "пароль", Valid: true},
+		{Name: "too long for bcrypt", Policy: DefaultPasswordPolicy(), Login: "alice", Password: strings.Repeat("a", 73)},
+		{Name: "strict", Policy: strict, Login: "alice", Password: "s3cretpass", Valid: true},
+		{Name: "strict without digit", Policy: strict, Login: "alice", Password: "secretpass"},
+		{Name: "strict without letter", Policy: strict, Login: "alice", Password: "1234567890"},
+		{Name: "strict with login", Policy: strict, Login: "alice", Password: "xALICE2024x"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Policy.Validate(testCase.Login, testCase.Password)
+
+			if testCase.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrWeakPassword)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	assert.NoError(t, DefaultPasswordPolicy().Check())
+	assert.ErrorIs(t, PasswordPolicy{}.Check(), ErrInvalidPasswordRules)
+	assert.ErrorIs(t, PasswordPolicy{MinLength: 100}.Check(), ErrInvalidPasswordRules)
+}
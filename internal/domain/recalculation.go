@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxRecalculatedOrders is how many orders one recalculation may cover.
+const MaxRecalculatedOrders = 1000
+
+// MaxRecalculationReasonLength is the size of the
+// order_accrual_adjustments.reason column.
+const MaxRecalculationReasonLength = 255
+
+var (
+	ErrInvalidRecalculation = errors.New("invalid recalculation")
+	ErrTooManyOrders        = errors.New("too many orders to recalculate")
+)
+
+// Recalculation picks processed orders of a merchant to calculate again with
+// the rules they have now: either the orders listed in OrderIDs, or those
+// evaluated in [From, To), see RegisteredOrder.EvaluatedAt. Orders that are
+// not processed yet are left to the calculation worker.
+type Recalculation struct {
+	OrderIDs []string
+	From     *time.Time
+	To       *time.Time
+	Reason   string
+}
+
+// Check trims the reason and makes sure the recalculation picks orders one
+// way only.
+func (r *Recalculation) Check() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+
+	if r.Reason == "" || len(r.Reason) > MaxRecalculationReasonLength {
+		return fmt.Errorf("%w: reason is required, at most %d bytes", ErrInvalidRecalculation, MaxRecalculationReasonLength)
+	}
+
+	if len(r.OrderIDs) > 0 {
+		if r.From != nil || r.To != nil {
+			return fmt.Errorf("%w: give either orders or a time range", ErrInvalidRecalculation)
+		}
+
+		if len(r.OrderIDs) > MaxRecalculatedOrders {
+			return fmt.Errorf("%w: at most %d orders", ErrTooManyOrders, MaxRecalculatedOrders)
+		}
+
+		return nil
+	}
+
+	if r.From == nil || r.To == nil || !r.From.Before(*r.To) {
+		return fmt.Errorf("%w: give orders, or from before to", ErrInvalidRecalculation)
+	}
+
+	return nil
+}
+
+// AccrualAdjustment is the change a recalculation made to the accrual of a
+// processed order. Gophermart posts Delta to the balance of whoever uploaded
+// the order, see the adjustment feed of the accrual service.
+type AccrualAdjustment struct {
+	ID         int       `json:"id"`
+	MerchantID int       `json:"-"`
+	Merchant   string    `json:"merchant"`
+	OrderID    string    `json:"order"`
+	OldAccrual Money     `json:"old_accrual" swaggertype:"number"`
+	NewAccrual Money     `json:"new_accrual" swaggertype:"number"`
+	Delta      Money     `json:"delta" swaggertype:"number"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RecalculationResult lists the orders a recalculation covered and the
+// adjustments it made; orders whose accrual stayed the same have none. When an
+// order fails, FailedOrder names it and the orders before it are listed as
+// they were saved.
+type RecalculationResult struct {
+	Orders      []string            `json:"orders"`
+	Adjustments []AccrualAdjustment `json:"adjustments"`
+	FailedOrder string              `json:"failed_order,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecalculation_Check(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	testCases := []struct {
+		Name          string
+		Recalculation Recalculation
+		Err           error
+	}{
+		{
+			Name:          "orders",
+			Recalculation: Recalculation{OrderIDs: []string{"1"}, Reason: "wrong reward"},
+		},
+		{
+			Name:          "time range",
+			Recalculation: Recalculation{From: &from, To: &to, Reason: "wrong reward"},
+		},
+		{
+			Name:          "no reason",
+			Recalculation: Recalculation{OrderIDs: []string{"1"}, Reason: "  "},
+			Err:           ErrInvalidRecalculation,
+		},
+		{
+			Name:          "reason too long",
+			Recalculation: Recalculation{OrderIDs: []string{"1"}, Reason: strings.Repeat("a", MaxRecalculationReasonLength+1)},
+			Err:           ErrInvalidRecalculation,
+		},
+		{
+			Name:          "orders and time range",
+			Recalculation: Recalculation{OrderIDs: []string{"1"}, From: &from, Reason: "wrong reward"},
+			Err:           ErrInvalidRecalculation,
+		},
+		{
+			Name:          "open time range",
+			Recalculation: Recalculation{From: &from, Reason: "wrong reward"},
+			Err:           ErrInvalidRecalculation,
+		},
+		{
+			Name:          "empty time range",
+			Recalculation: Recalculation{From: &to, To: &from, Reason: "wrong reward"},
+			Err:           ErrInvalidRecalculation,
+		},
+		{
+			Name:          "too many orders",
+			Recalculation: Recalculation{OrderIDs: make([]string, MaxRecalculatedOrders+1), Reason: "wrong reward"},
+			Err:           ErrTooManyOrders,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Recalculation.Check()
+
+			if testCase.Err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, testCase.Err)
+			}
+		})
+	}
+}
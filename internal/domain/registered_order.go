@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 const (
 	NewRegisteredOrderStatus        = "REGISTERED"
@@ -10,14 +14,97 @@ const (
 )
 
 type RegisteredOrder struct {
-	OrderID   string      `json:"order_id"`
-	Status    string      `json:"status"`
-	Accrual   *float64    `json:"accrual"`
-	CreatedAt time.Time   `json:"created_at"`
-	Goods     []OrderGood `json:"goods"`
+	MerchantID int    `json:"merchant_id"`
+	OrderID    string `json:"order_id"`
+	Status     string `json:"status"`
+	Accrual    *Money `json:"accrual" swaggertype:"number"`
+	// PurchasedAt is when the customer bought the goods, if the merchant
+	// told us.
+	PurchasedAt *time.Time  `json:"purchased_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Goods       []OrderGood `json:"goods"`
 }
 
+// EvaluatedAt is the moment reward rules are evaluated for: the purchase,
+// or the registration when the purchase time is unknown.
+func (o *RegisteredOrder) EvaluatedAt() time.Time {
+	if o.PurchasedAt != nil {
+		return *o.PurchasedAt
+	}
+
+	return o.CreatedAt
+}
+
+// OrderGood is a line of an order. Price is what the whole line costs,
+// Quantity is 1 when left out.
 type OrderGood struct {
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Description string `json:"description"`
+	SKU         string `json:"sku,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Quantity    int    `json:"quantity,omitempty"`
+	UnitPrice   *Money `json:"unit_price,omitempty" swaggertype:"number"`
+	Price       Money  `json:"price" swaggertype:"number"`
+}
+
+// MaxOrderGoodQuantity is the largest quantity of a good, so that prices and
+// rewards per unit times the quantity stay within Money.
+const MaxOrderGoodQuantity = 1_000_000
+
+var ErrInvalidOrderGood = errors.New("invalid order good")
+
+// SetDefaults fills in the quantity, and the price from the unit price when
+// only the latter is given.
+func (g *OrderGood) SetDefaults() {
+	if g.Quantity == 0 {
+		g.Quantity = 1
+	}
+
+	if g.Price.IsZero() && g.UnitPrice != nil {
+		g.Price = g.UnitPrice.Mul(int64(g.Quantity))
+	}
+}
+
+// Check makes sure the quantity and prices of a good with defaults set add
+// up.
+func (g *OrderGood) Check() error {
+	if g.Quantity <= 0 || g.Quantity > MaxOrderGoodQuantity {
+		return fmt.Errorf("%w: quantity must be from 1 to %d", ErrInvalidOrderGood, MaxOrderGoodQuantity)
+	}
+
+	if g.Price == MaxMoney || (g.UnitPrice != nil && g.UnitPrice.Mul(int64(g.Quantity)) == MaxMoney) {
+		return fmt.Errorf("%w: price is too large", ErrInvalidOrderGood)
+	}
+
+	if g.Price.IsNegative() || (g.UnitPrice != nil && g.UnitPrice.IsNegative()) {
+		return fmt.Errorf("%w: prices must not be negative", ErrInvalidOrderGood)
+	}
+
+	if g.UnitPrice != nil && g.UnitPrice.Mul(int64(g.Quantity)).Cmp(g.Price) != 0 {
+		return fmt.Errorf("%w: price must be unit_price times quantity", ErrInvalidOrderGood)
+	}
+
+	return nil
+}
+
+// Units is the quantity of the good, goods registered without one count
+// once.
+func (g *OrderGood) Units() int64 {
+	if g.Quantity <= 0 {
+		return 1
+	}
+
+	return int64(g.Quantity)
+}
+
+// Field returns the value rules with matchField are compared with, see
+// IsValidMatchField.
+func (g *OrderGood) Field(matchField string) string {
+	switch matchField {
+	case SKUMatchField:
+		return g.SKU
+	case CategoryMatchField:
+		return g.Category
+	default:
+		return g.Description
+	}
 }
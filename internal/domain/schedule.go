@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrInvalidValidity = errors.New("valid_to must be after valid_from")
+)
+
+// Schedule limits a rule to some days of the week and hours of the day.
+// Days are time.Weekday numbers, 0 for Sunday; none means every day. The
+// hours are [FromHour, ToHour): equal hours mean the whole day and a FromHour
+// after ToHour wraps past midnight, so 22 to 2 is a night promotion. The day
+// and the hour are those of the purchase in Timezone, UTC when empty.
+type Schedule struct {
+	Days     []int  `json:"days,omitempty"`
+	FromHour int    `json:"from_hour"`
+	ToHour   int    `json:"to_hour"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// IsZero tells whether the schedule allows every moment, which is the same as
+// having no schedule.
+func (s Schedule) IsZero() bool {
+	return len(s.Days) == 0 && s.FromHour == s.ToHour
+}
+
+// Check makes sure the schedule can be evaluated.
+func (s Schedule) Check() error {
+	seen := make(map[int]struct{}, len(s.Days))
+
+	for _, day := range s.Days {
+		if day < int(time.Sunday) || day > int(time.Saturday) {
+			return fmt.Errorf("%w: days must be 0 (Sunday) to 6 (Saturday)", ErrInvalidSchedule)
+		}
+
+		if _, ok := seen[day]; ok {
+			return fmt.Errorf("%w: day %d is listed twice", ErrInvalidSchedule, day)
+		}
+
+		seen[day] = struct{}{}
+	}
+
+	if s.FromHour < 0 || s.FromHour > 23 || s.ToHour < 0 || s.ToHour > 24 {
+		return fmt.Errorf("%w: hours must be 0 to 24", ErrInvalidSchedule)
+	}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, s.Timezone)
+	}
+
+	return nil
+}
+
+// Contains tells whether t falls on one of the scheduled days and hours. A
+// schedule that does not pass Check contains nothing.
+func (s Schedule) Contains(t time.Time) bool {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+
+	t = t.In(location)
+
+	if len(s.Days) > 0 && !s.hasDay(t.Weekday()) {
+		return false
+	}
+
+	hour := t.Hour()
+
+	switch {
+	case s.FromHour == s.ToHour:
+		return true
+	case s.FromHour < s.ToHour:
+		return hour >= s.FromHour && hour < s.ToHour
+	default:
+		return hour >= s.FromHour || hour < s.ToHour
+	}
+}
+
+func (s Schedule) hasDay(day time.Weekday) bool {
+	for _, d := range s.Days {
+		if d == int(day) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckValidity makes sure a validity window is not empty.
+func CheckValidity(from *time.Time, to *time.Time) error {
+	if from != nil && to != nil && !to.After(*from) {
+		return ErrInvalidValidity
+	}
+
+	return nil
+}
+
+// withinValidity tells whether t is in [from, to), a nil bound being open.
+func withinValidity(t time.Time, from *time.Time, to *time.Time) bool {
+	if from != nil && t.Before(*from) {
+		return false
+	}
+
+	if to != nil && !t.Before(*to) {
+		return false
+	}
+
+	return true
+}
+
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+
+	return &t
+}
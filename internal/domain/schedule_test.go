@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Check(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Schedule Schedule
+		Valid    bool
+	}{
+		{Name: "whole week", Schedule: Schedule{}, Valid: true},
+		{Name: "weekend nights", Schedule: Schedule{Days: []int{5, 6}, FromHour: 22, ToHour: 2}, Valid: true},
+		{Name: "until midnight", Schedule: Schedule{FromHour: 18, ToHour: 24}, Valid: true},
+		{Name: "with timezone", Schedule: Schedule{FromHour: 9, ToHour: 12, Timezone: "Europe/Moscow"}, Valid: true},
+		{Name: "unknown day", Schedule: Schedule{Days: []int{7}}},
+		{Name: "day twice", Schedule: Schedule{Days: []int{1, 1}}},
+		{Name: "hour out of range", Schedule: Schedule{FromHour: 24, ToHour: 2}},
+		{Name: "unknown timezone", Schedule: Schedule{Timezone: "Mars/Olympus"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Schedule.Check()
+
+			if testCase.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+			}
+		})
+	}
+}
+
+func TestSchedule_Contains(t *testing.T) {
+	// 2026-10-16 is a Friday.
+	friday := func(hour int) time.Time {
+		return time.Date(2026, time.October, 16, hour, 30, 0, 0, time.UTC)
+	}
+
+	assert.True(t, Schedule{}.Contains(friday(3)))
+
+	daytime := Schedule{FromHour: 9, ToHour: 18}
+	assert.True(t, daytime.Contains(friday(9)))
+	assert.False(t, daytime.Contains(friday(18)))
+
+	night := Schedule{FromHour: 22, ToHour: 2}
+	assert.True(t, night.Contains(friday(23)))
+	assert.True(t, night.Contains(friday(1)))
+	assert.False(t, night.Contains(friday(12)))
+
+	weekend := Schedule{Days: []int{int(time.Saturday), int(time.Sunday)}}
+	assert.False(t, weekend.Contains(friday(12)))
+	assert.True(t, weekend.Contains(friday(12).AddDate(0, 0, 1)))
+
+	// 23:30 on Friday in UTC is already Saturday in Moscow.
+	moscowWeekend := Schedule{Days: []int{int(time.Saturday)}, Timezone: "Europe/Moscow"}
+	assert.True(t, moscowWeekend.Contains(friday(23)))
+}
+
+func TestCheckValidity(t *testing.T) {
+	from := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	assert.NoError(t, CheckValidity(nil, nil))
+	assert.NoError(t, CheckValidity(&from, nil))
+	assert.NoError(t, CheckValidity(&from, &to))
+	assert.ErrorIs(t, CheckValidity(&to, &from), ErrInvalidValidity)
+	assert.ErrorIs(t, CheckValidity(&from, &from), ErrInvalidValidity)
+}
+
+func TestGoodReward_ActiveAt(t *testing.T) {
+	from := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	reward := GoodReward{
+		ValidFrom: &from,
+		ValidTo:   &to,
+		Schedule:  &Schedule{FromHour: 10, ToHour: 20},
+	}
+
+	assert.False(t, reward.ActiveAt(from.Add(-time.Hour)))
+	assert.False(t, reward.ActiveAt(from.Add(2*time.Hour)))
+	assert.True(t, reward.ActiveAt(from.Add(12*time.Hour)))
+	assert.False(t, reward.ActiveAt(to.Add(12*time.Hour)))
+
+	reward.Disabled = true
+	assert.False(t, reward.ActiveAt(from.Add(12*time.Hour)))
+}
+
+func TestGoodRewardUpdate_Apply_RemovesValidity(t *testing.T) {
+	from := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	campaignID := 3
+
+	reward := GoodReward{
+		CampaignID: &campaignID,
+		ValidFrom:  &from,
+		Schedule:   &Schedule{FromHour: 10, ToHour: 20},
+	}
+
+	noCampaign := 0
+	update := GoodRewardUpdate{
+		CampaignID: &noCampaign,
+		ValidFrom:  &time.Time{},
+		Schedule:   &Schedule{},
+	}
+
+	update.Apply(&reward)
+
+	require.Nil(t, reward.CampaignID)
+	require.Nil(t, reward.ValidFrom)
+	require.Nil(t, reward.Schedule)
+}
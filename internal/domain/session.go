@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Session is a server side login. Access tokens carry its ID and are only
+// accepted while the session is active; the refresh token rotates on every use.
+type Session struct {
+	ID                   string     `json:"id"`
+	UserID               int        `json:"user_id"`
+	RefreshTokenHash     string     `json:"-"`
+	PrevRefreshTokenHash *string    `json:"-"`
+	ExpiresAt            time.Time  `json:"expires_at"`
+	RevokedAt            *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	RefreshedAt          *time.Time `json:"refreshed_at,omitempty"`
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type TokenPair struct {
+	AccessToken           string    `json:"access_token"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// StatementLine is one movement of the user's spendable points together with
+// the balance right after it.
+type StatementLine struct {
+	EntryID   int       `json:"entry_id"`
+	Kind      string    `json:"kind"`
+	OrderID   string    `json:"order"`
+	Amount    Money     `json:"amount" swaggertype:"number"`
+	Balance   Money     `json:"balance" swaggertype:"number"`
+	CreatedAt time.Time `json:"created_at"`
+}
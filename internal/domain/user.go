@@ -2,14 +2,48 @@ package domain
 
 import "time"
 
+// User roles. Staff roles open the admin API: support can look users up and
+// fix their orders, admin can also move points and change roles.
+const (
+	CustomerRole = "customer"
+	SupportRole  = "support"
+	AdminRole    = "admin"
+)
+
+// validRoles maps every role to its rank, higher ranks can do more.
+var validRoles = map[string]int{
+	CustomerRole: 0,
+	SupportRole:  1,
+	AdminRole:    2,
+}
+
 type User struct {
-	ID        int       `json:"id"`
-	Login     string    `json:"login"`
-	Password  string    `json:"-"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int        `json:"id"`
+	Login      string     `json:"login"`
+	Password   string     `json:"-"`
+	Role       string     `json:"role"`
+	LockedAt   *time.Time `json:"locked_at,omitempty"`
+	LockReason *string    `json:"lock_reason,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (u *User) IsLocked() bool {
+	return u.LockedAt != nil
 }
 
 type UserBalance struct {
-	Current   float64 `json:"current"`
-	Withdrawn float64 `json:"withdrawn"`
+	Current      Money            `json:"current" swaggertype:"number"`
+	Withdrawn    Money            `json:"withdrawn" swaggertype:"number"`
+	Held         Money            `json:"held" swaggertype:"number"`
+	ExpiringSoon []ExpiringPoints `json:"expiring_soon,omitempty"`
+}
+
+func IsValidRole(role string) bool {
+	_, ok := validRoles[role]
+	return ok
+}
+
+// RoleOutranks reports whether role can do more than other.
+func RoleOutranks(role string, other string) bool {
+	return validRoles[role] > validRoles[other]
 }
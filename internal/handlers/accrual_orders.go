@@ -5,17 +5,30 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
 )
 
 type accrualOrdersService interface {
-	RegisterOrder(ctx context.Context, orderID string, goods []domain.OrderGood) (*domain.RegisteredOrder, error)
-	GetOrderInfo(ctx context.Context, orderID string) (*domain.RegisteredOrder, error)
+	RegisterOrder(
+		ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood,
+	) (*domain.RegisteredOrder, error)
+	GetOrderInfo(ctx context.Context, merchantID int, orderID string) (*domain.RegisteredOrder, error)
+	GetOrderBreakdown(ctx context.Context, merchantID int, orderID string) (*domain.AccrualBreakdown, error)
+	QuoteOrder(
+		ctx context.Context, merchantID int, at time.Time, goods []domain.OrderGood,
+	) (*domain.AccrualBreakdown, error)
+	RecalculateOrders(
+		ctx context.Context, merchantID int, recalculation domain.Recalculation,
+	) (*domain.RecalculationResult, error)
+	GetAdjustments(ctx context.Context, merchantID *int, after int, limit int) ([]domain.AccrualAdjustment, error)
 }
 
 type AccrualOrdersHandler struct {
@@ -29,9 +42,9 @@ func NewAccrualOrdersHandler(service accrualOrdersService) *AccrualOrdersHandler
 }
 
 type getRegisteredOrderInfoResponse struct {
-	Order   string   `json:"order"`
-	Status  string   `json:"status"`
-	Accrual *float64 `json:"accrual,omitempty"`
+	Order   string        `json:"order"`
+	Status  string        `json:"status"`
+	Accrual *domain.Money `json:"accrual,omitempty" swaggertype:"number"`
 }
 
 // GetRegisteredOrderInfo godoc
@@ -39,13 +52,24 @@ type getRegisteredOrderInfoResponse struct {
 // @Tags order
 // @Produce json
 // @Param orderID path string true "Order ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
 // @Success 200 {object} getRegisteredOrderInfoResponse
 // @Failure 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
 // @Failure 500 {object} httputils.HTTPError
 // @Router /orders/{orderID} [get]
 func (h *AccrualOrdersHandler) GetRegisteredOrderInfo(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
 	orderID := chi.URLParam(r, "orderID")
-	order, err := h.service.GetOrderInfo(r.Context(), orderID)
+	order, err := h.service.GetOrderInfo(r.Context(), merchantID, orderID)
 
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
@@ -65,9 +89,57 @@ func (h *AccrualOrdersHandler) GetRegisteredOrderInfo(w http.ResponseWriter, r *
 	})
 }
 
+// GetOrderBreakdown godoc
+// @Summary Get registered order accrual breakdown
+// @Description Explains the accrual of a processed order: goods lists every good with the rules it matched,
+// @Description the rule versions used and what each gave, order what order-level rules gave. After a
+// @Description recalculation it explains the recalculated accrual.
+// @Tags order
+// @Produce json
+// @Param orderID path string true "Order ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.AccrualBreakdown
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /orders/{orderID}/breakdown [get]
+func (h *AccrualOrdersHandler) GetOrderBreakdown(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	breakdown, err := h.service.GetOrderBreakdown(r.Context(), merchantID, chi.URLParam(r, "orderID"))
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, "order not found")
+			return
+		}
+
+		if errors.Is(err, domain.ErrNoBreakdown) {
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		log.Println("[GetOrderBreakdown]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, breakdown)
+}
+
 type registerOrderForAccrualBody struct {
-	Order string             `json:"order"`
-	Goods []domain.OrderGood `json:"goods"`
+	Order string `json:"order"`
+	// PurchasedAt is when the goods were bought, the registration time when
+	// left out. Reward rules are evaluated as of this moment.
+	PurchasedAt *time.Time         `json:"purchased_at,omitempty"`
+	Goods       []domain.OrderGood `json:"goods"`
 }
 
 func (b *registerOrderForAccrualBody) Valid() bool {
@@ -75,21 +147,38 @@ func (b *registerOrderForAccrualBody) Valid() bool {
 		return false
 	}
 
+	if b.PurchasedAt != nil && b.PurchasedAt.IsZero() {
+		return false
+	}
+
 	return true
 }
 
 // RegisterOrderForAccrual godoc
 // @Summary Register order for accrual
+// @Description price is what a line of goods costs in total; it can be left out when unit_price is given, and
+// @Description then is unit_price times quantity, 1 by default and 1000000 at most. Rules can match the sku
+// @Description or category of goods, and point rewards are given per unit.
 // @Tags order
 // @Accept json
 // @Produce json
 // @Param dto body registerOrderForAccrualBody true "Register Order for accrual"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
 // @Success 202 {object} domain.RegisteredOrder
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 409 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
 // @Failure 500 {object} httputils.HTTPError
 // @Router /orders [post]
 func (h *AccrualOrdersHandler) RegisterOrderForAccrual(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
 	var body registerOrderForAccrualBody
 
 	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
@@ -102,7 +191,7 @@ func (h *AccrualOrdersHandler) RegisterOrderForAccrual(w http.ResponseWriter, r
 		return
 	}
 
-	registeredOrder, err := h.service.RegisterOrder(r.Context(), body.Order, body.Goods)
+	registeredOrder, err := h.service.RegisterOrder(r.Context(), merchantID, body.Order, body.PurchasedAt, body.Goods)
 
 	if err != nil {
 		if errors.Is(err, domain.ErrOrderAlreadyRegisteredForAccrual) {
@@ -110,6 +199,11 @@ func (h *AccrualOrdersHandler) RegisterOrderForAccrual(w http.ResponseWriter, r
 			return
 		}
 
+		if errors.Is(err, domain.ErrInvalidOrderGood) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		log.Println("[RegisterOrderForAccrual]", err)
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
 		return
@@ -117,3 +211,211 @@ func (h *AccrualOrdersHandler) RegisterOrderForAccrual(w http.ResponseWriter, r
 
 	httputils.SendJSONResponse(w, http.StatusAccepted, registeredOrder)
 }
+
+type quoteOrderBody struct {
+	// PurchasedAt is the moment reward rules are evaluated for, now when left
+	// out.
+	PurchasedAt *time.Time         `json:"purchased_at,omitempty"`
+	Goods       []domain.OrderGood `json:"goods"`
+}
+
+func (b *quoteOrderBody) Valid() bool {
+	if len(b.Goods) == 0 {
+		return false
+	}
+
+	if b.PurchasedAt != nil && b.PurchasedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
+
+// QuoteOrder godoc
+// @Summary Quote order accrual
+// @Description Calculates what an order of the goods would earn without registering it, the same way
+// @Description registered orders are calculated. goods lists every good with the rules it matched and what
+// @Description each gave, order what order-level rules gave. The result holds as long as the rules do.
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param dto body quoteOrderBody true "Goods to quote"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.AccrualBreakdown
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} httputils.HTTPError
+// @Router /accrual/quote [post]
+func (h *AccrualOrdersHandler) QuoteOrder(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body quoteOrderBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	at := time.Now()
+
+	if body.PurchasedAt != nil {
+		at = *body.PurchasedAt
+	}
+
+	quote, err := h.service.QuoteOrder(r.Context(), merchantID, at, body.Goods)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderGood) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[QuoteOrder]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, quote)
+}
+
+type recalculateOrdersBody struct {
+	// Orders lists the orders to recalculate. Without it, the orders
+	// purchased in [From, To) are, or registered then when the purchase time
+	// is unknown.
+	Orders []string   `json:"orders,omitempty"`
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+	Reason string     `json:"reason"`
+}
+
+// RecalculateOrders godoc
+// @Summary Recalculate processed orders
+// @Description Calculates processed orders again with the rules as they are now, as of the moments they were
+// @Description evaluated for, and replaces their accrual. The breakdown of every calculation is kept, the
+// @Description breakdown endpoint shows the latest one. Give either orders or from and to,
+// @Description at most 1000 orders are recalculated at once. Every changed accrual is kept as an adjustment,
+// @Description which gophermart picks up from /adjustments and posts to the balance of the user.
+// @Description Orders are saved one by one: when one fails, the 500 response still lists the orders
+// @Description and adjustments saved before it, and names the order that failed in failed_order.
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param dto body recalculateOrdersBody true "Orders to recalculate"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.RecalculationResult
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} domain.RecalculationResult
+// @Router /orders/recalculate [post]
+func (h *AccrualOrdersHandler) RecalculateOrders(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body recalculateOrdersBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	result, err := h.service.RecalculateOrders(r.Context(), merchantID, domain.Recalculation{
+		OrderIDs: body.Orders,
+		From:     body.From,
+		To:       body.To,
+		Reason:   body.Reason,
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRecalculation) || errors.Is(err, domain.ErrTooManyOrders) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[RecalculateOrders]", err)
+
+		if result == nil {
+			httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+			return
+		}
+
+		result.Error = domain.ErrInternalServer.Error()
+		httputils.SendJSONResponse(w, http.StatusInternalServerError, result)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, result)
+}
+
+// GetAdjustments godoc
+// @Summary Get accrual adjustments
+// @Description Lists what recalculations changed in the accrual of processed orders, oldest first.
+// @Description Pass the id of the last adjustment seen as after to get the next ones; the list is empty
+// @Description when there are none. Keys bound to a merchant only see the adjustments of that merchant.
+// @Tags order
+// @Produce json
+// @Param after query int false "Id of the last adjustment seen, 0 by default"
+// @Param limit query int false "Page size, 50 by default, 500 at most"
+// @Security APIKey
+// @Success 200 {array} domain.AccrualAdjustment
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} httputils.HTTPError
+// @Router /adjustments [get]
+func (h *AccrualOrdersHandler) GetAdjustments(w http.ResponseWriter, r *http.Request) {
+	var after, limit int
+	var err error
+
+	if value := r.URL.Query().Get("after"); value != "" {
+		if after, err = strconv.Atoi(value); err != nil {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid after")
+			return
+		}
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	// Without API keys there is no key in the context, and every merchant is
+	// listed.
+	var merchantID *int
+
+	if key, err := contextutil.GetAPIKeyFromContext(r.Context()); err == nil {
+		merchantID = key.MerchantID
+	}
+
+	adjustments, err := h.service.GetAdjustments(r.Context(), merchantID, after, limit)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHistoryFilter) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[GetAdjustments]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, adjustments)
+}
@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
 )
@@ -22,6 +26,8 @@ func TestAccrualOrdersHandler_RegisterOrderForAccrual(t *testing.T) {
 	accrualOrderService := servicemock.NewMockaccrualOrdersService(ctrl)
 	accrualOrdersHandler := NewAccrualOrdersHandler(accrualOrderService)
 
+	purchasedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
 	type TestCase struct {
 		Name               string
 		Body               *registerOrderForAccrualBody
@@ -37,18 +43,61 @@ func TestAccrualOrdersHandler_RegisterOrderForAccrual(t *testing.T) {
 				Goods: []domain.OrderGood{
 					{
 						Description: "Bork",
-						Price:       1000.0,
+						Price:       domain.MoneyFromInt(1000),
 					},
 				},
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *registerOrderForAccrualBody) {
 				service.
 					EXPECT().
-					RegisterOrder(ctx, body.Order, body.Goods).
+					RegisterOrder(ctx, 1, body.Order, body.PurchasedAt, body.Goods).
 					Return(&domain.RegisteredOrder{OrderID: body.Order}, nil)
 			},
 			ExpectedStatusCode: http.StatusAccepted,
 		},
+		{
+			Name: "valid (with purchase time)",
+			Body: &registerOrderForAccrualBody{
+				Order:       "1235",
+				PurchasedAt: &purchasedAt,
+				Goods: []domain.OrderGood{
+					{
+						Description: "Bork",
+						Price:       domain.MoneyFromInt(1000),
+					},
+				},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *registerOrderForAccrualBody) {
+				service.
+					EXPECT().
+					RegisterOrder(ctx, 1, body.Order, &purchasedAt, body.Goods).
+					Return(&domain.RegisteredOrder{OrderID: body.Order, PurchasedAt: &purchasedAt}, nil)
+			},
+			ExpectedStatusCode: http.StatusAccepted,
+		},
+		{
+			Name: "invalid (zero purchase time)",
+			Body: &registerOrderForAccrualBody{
+				Order:       "1235",
+				PurchasedAt: &time.Time{},
+				Goods:       []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(1000)}},
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (negative quantity)",
+			Body: &registerOrderForAccrualBody{
+				Order: "1236",
+				Goods: []domain.OrderGood{{Description: "Bork", SKU: "BK-810", Quantity: -1, Price: domain.MoneyFromInt(1000)}},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *registerOrderForAccrualBody) {
+				service.
+					EXPECT().
+					RegisterOrder(ctx, 1, body.Order, body.PurchasedAt, body.Goods).
+					Return(nil, domain.ErrInvalidOrderGood)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			Name:               "invalid (invalid body)",
 			Body:               &registerOrderForAccrualBody{},
@@ -68,14 +117,14 @@ func TestAccrualOrdersHandler_RegisterOrderForAccrual(t *testing.T) {
 				Goods: []domain.OrderGood{
 					{
 						Description: "Bork",
-						Price:       1000.0,
+						Price:       domain.MoneyFromInt(1000),
 					},
 				},
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *registerOrderForAccrualBody) {
 				service.
 					EXPECT().
-					RegisterOrder(ctx, body.Order, body.Goods).
+					RegisterOrder(ctx, 1, body.Order, body.PurchasedAt, body.Goods).
 					Return(nil, domain.ErrOrderAlreadyRegisteredForAccrual)
 			},
 			ExpectedStatusCode: http.StatusConflict,
@@ -94,6 +143,7 @@ func TestAccrualOrdersHandler_RegisterOrderForAccrual(t *testing.T) {
 
 			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
 			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
 			w := httptest.NewRecorder()
 
 			if testCase.PrepareServiceFunc != nil {
@@ -129,7 +179,7 @@ func TestAccrualOrdersHandler_GetRegisteredOrderInfo(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string) {
 				service.
 					EXPECT().
-					GetOrderInfo(ctx, orderID).
+					GetOrderInfo(ctx, 1, orderID).
 					Return(&domain.RegisteredOrder{OrderID: orderID}, nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
@@ -140,7 +190,7 @@ func TestAccrualOrdersHandler_GetRegisteredOrderInfo(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string) {
 				service.
 					EXPECT().
-					GetOrderInfo(ctx, orderID).
+					GetOrderInfo(ctx, 1, orderID).
 					Return(nil, domain.ErrNotFound)
 			},
 			ExpectedStatusCode: http.StatusNoContent,
@@ -155,6 +205,7 @@ func TestAccrualOrdersHandler_GetRegisteredOrderInfo(t *testing.T) {
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("orderID", testCase.OrderID)
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
 
 			if testCase.PrepareServiceFunc != nil {
 				testCase.PrepareServiceFunc(r.Context(), accrualOrderService, testCase.OrderID)
@@ -169,3 +220,333 @@ func TestAccrualOrdersHandler_GetRegisteredOrderInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestAccrualOrdersHandler_QuoteOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accrualOrderService := servicemock.NewMockaccrualOrdersService(ctrl)
+	accrualOrdersHandler := NewAccrualOrdersHandler(accrualOrderService)
+
+	purchasedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	unitPrice := domain.MoneyFromInt(500)
+
+	type TestCase struct {
+		Name               string
+		Body               *quoteOrderBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *quoteOrderBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &quoteOrderBody{
+				Goods: []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(1000)}},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *quoteOrderBody) {
+				service.
+					EXPECT().
+					QuoteOrder(ctx, 1, gomock.Any(), body.Goods).
+					Return(&domain.AccrualBreakdown{Accrual: domain.MoneyFromInt(100)}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "valid (as of the purchase time)",
+			Body: &quoteOrderBody{
+				PurchasedAt: &purchasedAt,
+				Goods:       []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(1000)}},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *quoteOrderBody) {
+				service.
+					EXPECT().
+					QuoteOrder(ctx, 1, purchasedAt, body.Goods).
+					Return(&domain.AccrualBreakdown{}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (no goods)",
+			Body:               &quoteOrderBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (price does not add up)",
+			Body: &quoteOrderBody{
+				Goods: []domain.OrderGood{{Description: "Bork", Quantity: 2, UnitPrice: &unitPrice, Price: domain.MoneyFromInt(1)}},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, body *quoteOrderBody) {
+				service.
+					EXPECT().
+					QuoteOrder(ctx, 1, gomock.Any(), body.Goods).
+					Return(nil, domain.ErrInvalidOrderGood)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(*testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), accrualOrderService, testCase.Body)
+			}
+
+			accrualOrdersHandler.QuoteOrder(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestAccrualOrdersHandler_GetOrderBreakdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accrualOrderService := servicemock.NewMockaccrualOrdersService(ctrl)
+	accrualOrdersHandler := NewAccrualOrdersHandler(accrualOrderService)
+
+	type TestCase struct {
+		Name               string
+		OrderID            string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:    "valid",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string) {
+				service.
+					EXPECT().
+					GetOrderBreakdown(ctx, 1, orderID).
+					Return(&domain.AccrualBreakdown{Accrual: domain.MoneyFromInt(10)}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:    "invalid (not found)",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string) {
+				service.
+					EXPECT().
+					GetOrderBreakdown(ctx, 1, orderID).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:    "invalid (not calculated yet)",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService, orderID string) {
+				service.
+					EXPECT().
+					GetOrderBreakdown(ctx, 1, orderID).
+					Return(nil, domain.ErrNoBreakdown)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("orderID", testCase.OrderID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), accrualOrderService, testCase.OrderID)
+			}
+
+			accrualOrdersHandler.GetOrderBreakdown(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestAccrualOrdersHandler_RecalculateOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accrualOrderService := servicemock.NewMockaccrualOrdersService(ctrl)
+	accrualOrdersHandler := NewAccrualOrdersHandler(accrualOrderService)
+
+	type TestCase struct {
+		Name               string
+		Body               any
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockaccrualOrdersService)
+		ExpectedStatusCode int
+		ExpectedBody       string
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: map[string]any{"orders": []string{"1234"}, "reason": "wrong reward"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					RecalculateOrders(ctx, 1, domain.Recalculation{OrderIDs: []string{"1234"}, Reason: "wrong reward"}).
+					Return(&domain.RecalculationResult{Orders: []string{"1234"}}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "invalid (no orders)",
+			Body: map[string]any{"reason": "wrong reward"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					RecalculateOrders(ctx, 1, gomock.Any()).
+					Return(nil, domain.ErrInvalidRecalculation)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (too many orders)",
+			Body: map[string]any{"from": "2026-10-01T00:00:00Z", "to": "2026-10-02T00:00:00Z", "reason": "wrong reward"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					RecalculateOrders(ctx, 1, gomock.Any()).
+					Return(nil, domain.ErrTooManyOrders)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (order fails midway)",
+			Body: map[string]any{"orders": []string{"1234", "5678"}, "reason": "wrong reward"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					RecalculateOrders(ctx, 1, gomock.Any()).
+					Return(&domain.RecalculationResult{Orders: []string{"1234"}, FailedOrder: "5678"}, fmt.Errorf("random error"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+			ExpectedBody:       `{"orders":["1234"],"adjustments":null,"failed_order":"5678","error":"internal server error"}`,
+		},
+		{
+			Name:               "invalid (bad body)",
+			Body:               "orders",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodPost, "/", testCase.Body, "")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), accrualOrderService)
+			}
+
+			accrualOrdersHandler.RecalculateOrders(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if testCase.ExpectedBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, testCase.ExpectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestAccrualOrdersHandler_GetAdjustments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accrualOrderService := servicemock.NewMockaccrualOrdersService(ctrl)
+	accrualOrdersHandler := NewAccrualOrdersHandler(accrualOrderService)
+
+	merchantID := 2
+
+	type TestCase struct {
+		Name               string
+		Query              string
+		Key                *domain.APIKey
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockaccrualOrdersService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "valid (platform key)",
+			Query: "?after=10&limit=5",
+			Key:   &domain.APIKey{ID: "gophermart"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					GetAdjustments(ctx, nil, 10, 5).
+					Return([]domain.AccrualAdjustment{{ID: 11}}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "valid (merchant key)",
+			Key:  &domain.APIKey{ID: "shop", MerchantID: &merchantID},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					GetAdjustments(ctx, &merchantID, 0, 0).
+					Return([]domain.AccrualAdjustment{}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (bad after)",
+			Query:              "?after=last",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:  "invalid (limit too big)",
+			Query: "?limit=100000",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockaccrualOrdersService) {
+				service.
+					EXPECT().
+					GetAdjustments(ctx, nil, 0, 100000).
+					Return(nil, domain.ErrInvalidHistoryFilter)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+testCase.Query, nil)
+			w := httptest.NewRecorder()
+
+			if testCase.Key != nil {
+				r = r.WithContext(contextutil.SetAPIKeyToContext(r.Context(), testCase.Key))
+			}
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), accrualOrderService)
+			}
+
+			accrualOrdersHandler.GetAdjustments(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
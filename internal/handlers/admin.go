@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
+)
+
+type adminService interface {
+	GetUser(ctx context.Context, userID int) (*domain.User, error)
+	FindUserByLogin(ctx context.Context, login string) (*domain.User, error)
+	GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error)
+	AdjustBalance(ctx context.Context, actorID int, userID int, amount domain.Money, reason string) (*domain.BalanceAction, error)
+	RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error)
+	LockUser(ctx context.Context, actorRole string, userID int, reason string) (*domain.User, error)
+	UnlockUser(ctx context.Context, actorRole string, userID int) (*domain.User, error)
+	ClearLoginLockout(ctx context.Context, userID int) error
+	SetUserRole(ctx context.Context, userID int, role string) (*domain.User, error)
+}
+
+type AdminHandler struct {
+	service adminService
+}
+
+func NewAdminHandler(service adminService) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+	}
+}
+
+// FindUser godoc
+// @Summary Find a user by login
+// @Tags admin
+// @Produce json
+// @Param login query string true "User login"
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users [get]
+func (h *AdminHandler) FindUser(w http.ResponseWriter, r *http.Request) {
+	login := r.URL.Query().Get("login")
+
+	if len(login) == 0 {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "login is required")
+		return
+	}
+
+	user, err := h.service.FindUserByLogin(r.Context(), login)
+
+	if err != nil {
+		h.sendAdminError(w, "[FindUser]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, user)
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Tags admin
+// @Produce json
+// @Param userID path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID} [get]
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetUser(r.Context(), userID)
+
+	if err != nil {
+		h.sendAdminError(w, "[GetUser]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, user)
+}
+
+// GetUserBalance godoc
+// @Summary Get the balance of a user
+// @Tags admin
+// @Produce json
+// @Param userID path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.UserBalance
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/balance [get]
+func (h *AdminHandler) GetUserBalance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	balance, err := h.service.GetUserBalance(r.Context(), userID)
+
+	if err != nil {
+		h.sendAdminError(w, "[GetUserBalance]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, balance)
+}
+
+type adjustBalanceBody struct {
+	Sum    domain.Money `json:"sum" swaggertype:"number"`
+	Reason string       `json:"reason"`
+}
+
+func (b *adjustBalanceBody) Valid() bool {
+	return !b.Sum.IsZero() && len(b.Reason) != 0
+}
+
+type adjustmentForResponse struct {
+	ID          int          `json:"id"`
+	UserID      int          `json:"user_id"`
+	Sum         domain.Money `json:"sum" swaggertype:"number"`
+	Reason      *string      `json:"reason,omitempty"`
+	ActorID     *int         `json:"actor_id,omitempty"`
+	ProcessedAt *time.Time   `json:"processed_at,omitempty"`
+}
+
+// AdjustBalance godoc
+// @Summary Credit or debit a user by hand
+// @Description A positive sum credits the user, a negative one debits them and may leave them in debt.
+// @Description The reason is stored with the adjustment together with the staff member who made it.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param userID path int true "User ID"
+// @Param dto body adjustBalanceBody true "Signed sum and reason"
+// @Security BearerAuth
+// @Success 201 {object} adjustmentForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 422 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/balance/adjustments [post]
+func (h *AdminHandler) AdjustBalance(w http.ResponseWriter, r *http.Request) {
+	actorID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var body adjustBalanceBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	adjustment, err := h.service.AdjustBalance(r.Context(), actorID, userID, body.Sum, body.Reason)
+
+	if err != nil {
+		h.sendAdminError(w, "[AdjustBalance]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, adjustmentForResponse{
+		ID:          adjustment.ID,
+		UserID:      adjustment.UserID,
+		Sum:         adjustment.Amount,
+		Reason:      adjustment.Reason,
+		ActorID:     adjustment.ActorID,
+		ProcessedAt: adjustment.ProcessedAt,
+	})
+}
+
+// RequeueOrder godoc
+// @Summary Send an invalid order to the accrual system again
+// @Description Orders that are still waiting for accrual are returned unchanged.
+// @Tags admin
+// @Produce json
+// @Param orderID path string true "Order number"
+// @Security BearerAuth
+// @Success 200 {object} domain.UserOrder
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/orders/{orderID}/requeue [post]
+func (h *AdminHandler) RequeueOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderID")
+
+	if len(orderID) == 0 {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	order, err := h.service.RequeueOrder(r.Context(), orderID)
+
+	if err != nil {
+		h.sendAdminError(w, "[RequeueOrder]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, order)
+}
+
+type lockUserBody struct {
+	Reason string `json:"reason"`
+}
+
+func (b *lockUserBody) Valid() bool {
+	return len(b.Reason) != 0
+}
+
+// LockUser godoc
+// @Summary Lock a user out
+// @Description The user can not log in until unlocked and every session they have ends right away. Users with a higher role than the caller can not be locked.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param userID path int true "User ID"
+// @Param dto body lockUserBody true "Reason"
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 422 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/lock [post]
+func (h *AdminHandler) LockUser(w http.ResponseWriter, r *http.Request) {
+	actorRole, err := contextutil.GetRoleFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var body lockUserBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	user, err := h.service.LockUser(r.Context(), actorRole, userID, body.Reason)
+
+	if err != nil {
+		h.sendAdminError(w, "[LockUser]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, user)
+}
+
+// UnlockUser godoc
+// @Summary Unlock a user
+// @Description Users with a higher role than the caller can not be unlocked.
+// @Tags admin
+// @Produce json
+// @Param userID path int true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/unlock [post]
+func (h *AdminHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	actorRole, err := contextutil.GetRoleFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.UnlockUser(r.Context(), actorRole, userID)
+
+	if err != nil {
+		h.sendAdminError(w, "[UnlockUser]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, user)
+}
+
+// ClearLoginLockout godoc
+// @Summary Clear the failed login lockout of a user
+// @Description Lifts the backoff or lockout caused by wrong passwords. It does not unlock a user locked by staff.
+// @Tags admin
+// @Param userID path int true "User ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/login-lockout [delete]
+func (h *AdminHandler) ClearLoginLockout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ClearLoginLockout(r.Context(), userID); err != nil {
+		h.sendAdminError(w, "[ClearLoginLockout]", err)
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
+
+type setUserRoleBody struct {
+	Role string `json:"role"`
+}
+
+func (b *setUserRoleBody) Valid() bool {
+	return len(b.Role) != 0
+}
+
+// SetUserRole godoc
+// @Summary Change the role of a user
+// @Description One of customer, support or admin. The user has to log in again.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param userID path int true "User ID"
+// @Param dto body setUserRoleBody true "Role"
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 422 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/role [put]
+func (h *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var body setUserRoleBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	user, err := h.service.SetUserRole(r.Context(), userID, body.Role)
+
+	if err != nil {
+		h.sendAdminError(w, "[SetUserRole]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, user)
+}
+
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid user id")
+		return 0, false
+	}
+
+	return userID, true
+}
+
+func (h *AdminHandler) sendAdminError(w http.ResponseWriter, logPrefix string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		httputils.SendJSONErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrReasonRequired),
+		errors.Is(err, domain.ErrInvalidAdjustment),
+		errors.Is(err, domain.ErrInvalidRole):
+		httputils.SendJSONErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, domain.ErrUserOutranksActor):
+		httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrOrderNotRequeueable):
+		httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		log.Println(logPrefix, err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+	}
+}
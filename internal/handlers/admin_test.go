@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func newAdminRequest(t *testing.T, method string, body any, params map[string]string) *http.Request {
+	var rawBody []byte
+
+	if body != nil {
+		var err error
+		rawBody, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	r := httptest.NewRequest(method, "/", bytes.NewReader(rawBody))
+	r.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+	ctx = contextutil.SetUserIDToContext(ctx, 1)
+	ctx = contextutil.SetRoleToContext(ctx, domain.SupportRole)
+
+	return r.WithContext(ctx)
+}
+
+func TestAdminHandler_FindUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users?login=alice", nil)
+		w := httptest.NewRecorder()
+
+		adminServiceMock.
+			EXPECT().
+			FindUserByLogin(r.Context(), "alice").
+			Return(&domain.User{ID: 7, Login: "alice", Role: domain.CustomerRole}, nil)
+
+		adminHandler.FindUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var user domain.User
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&user))
+		assert.Equal(t, 7, user.ID)
+		assert.Equal(t, domain.CustomerRole, user.Role)
+	})
+
+	t.Run("invalid (no login)", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		w := httptest.NewRecorder()
+
+		adminHandler.FindUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users?login=bob", nil)
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().FindUserByLogin(r.Context(), "bob").Return(nil, domain.ErrNotFound)
+
+		adminHandler.FindUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestAdminHandler_AdjustBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	type TestCase struct {
+		Name               string
+		UserID             string
+		Body               *adjustBalanceBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockadminService, body *adjustBalanceBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:   "valid",
+			UserID: "7",
+			Body:   &adjustBalanceBody{Sum: domain.MoneyFromInt(-5), Reason: "duplicate accrual"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockadminService, body *adjustBalanceBody) {
+				service.
+					EXPECT().
+					AdjustBalance(ctx, 1, 7, body.Sum, body.Reason).
+					Return(&domain.BalanceAction{ID: 3, UserID: 7, Amount: body.Sum, Kind: domain.AdjustmentBalanceActionKind}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "invalid (bad user id)",
+			UserID:             "abc",
+			Body:               &adjustBalanceBody{Sum: domain.MoneyFromInt(5), Reason: "goodwill"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (no reason)",
+			UserID:             "7",
+			Body:               &adjustBalanceBody{Sum: domain.MoneyFromInt(5)},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (zero sum)",
+			UserID:             "7",
+			Body:               &adjustBalanceBody{Reason: "goodwill"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "invalid (blank reason)",
+			UserID: "7",
+			Body:   &adjustBalanceBody{Sum: domain.MoneyFromInt(5), Reason: "  "},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockadminService, body *adjustBalanceBody) {
+				service.
+					EXPECT().
+					AdjustBalance(ctx, 1, 7, body.Sum, body.Reason).
+					Return(nil, domain.ErrReasonRequired)
+			},
+			ExpectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			Name:   "invalid (unknown user)",
+			UserID: "8",
+			Body:   &adjustBalanceBody{Sum: domain.MoneyFromInt(5), Reason: "goodwill"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockadminService, body *adjustBalanceBody) {
+				service.
+					EXPECT().
+					AdjustBalance(ctx, 1, 8, body.Sum, body.Reason).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newAdminRequest(t, http.MethodPost, testCase.Body, map[string]string{"userID": testCase.UserID})
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), adminServiceMock, testCase.Body)
+			}
+
+			adminHandler.AdjustBalance(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestAdminHandler_LockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, &lockUserBody{Reason: "fraud"}, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().LockUser(r.Context(), domain.SupportRole, 7, "fraud").Return(&domain.User{ID: 7}, nil)
+
+		adminHandler.LockUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("invalid (user outranks actor)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, &lockUserBody{Reason: "fraud"}, map[string]string{"userID": "9"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.
+			EXPECT().
+			LockUser(r.Context(), domain.SupportRole, 9, "fraud").
+			Return(nil, domain.ErrUserOutranksActor)
+
+		adminHandler.LockUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("invalid (invalid body)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, &lockUserBody{}, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		adminHandler.LockUser(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestAdminHandler_SetUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPut, &setUserRoleBody{Role: domain.SupportRole}, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.
+			EXPECT().
+			SetUserRole(r.Context(), 7, domain.SupportRole).
+			Return(&domain.User{ID: 7, Role: domain.SupportRole}, nil)
+
+		adminHandler.SetUserRole(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("invalid (unknown role)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPut, &setUserRoleBody{Role: "root"}, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().SetUserRole(r.Context(), 7, "root").Return(nil, domain.ErrInvalidRole)
+
+		adminHandler.SetUserRole(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	})
+}
+
+func TestAdminHandler_RequeueOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, nil, map[string]string{"orderID": "123"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.
+			EXPECT().
+			RequeueOrder(r.Context(), "123").
+			Return(&domain.UserOrder{OrderID: "123", Status: domain.NewOrderStatus}, nil)
+
+		adminHandler.RequeueOrder(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("invalid (processed)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, nil, map[string]string{"orderID": "124"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().RequeueOrder(r.Context(), "124").Return(nil, domain.ErrOrderNotRequeueable)
+
+		adminHandler.RequeueOrder(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+}
+
+func TestAdminHandler_ClearLoginLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	adminServiceMock := servicemock.NewMockadminService(ctrl)
+	adminHandler := NewAdminHandler(adminServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodDelete, nil, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().ClearLoginLockout(r.Context(), 7).Return(nil)
+
+		adminHandler.ClearLoginLockout(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodDelete, nil, map[string]string{"userID": "8"})
+		w := httptest.NewRecorder()
+
+		adminServiceMock.EXPECT().ClearLoginLockout(r.Context(), 8).Return(domain.ErrNotFound)
+
+		adminHandler.ClearLoginLockout(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
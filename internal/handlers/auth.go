@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
-	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
 )
@@ -17,13 +19,33 @@ type userServiceForAuth interface {
 	Auth(ctx context.Context, login string, password string) (*domain.User, error)
 }
 
+type sessionServiceForAuth interface {
+	StartSession(ctx context.Context, user *domain.User) (*domain.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error)
+	Logout(ctx context.Context, sessionID string) error
+}
+
+type loginThrottleForAuth interface {
+	Check(ctx context.Context, login string, clientIP string) error
+	RegisterFailure(ctx context.Context, login string, clientIP string) error
+	RegisterSuccess(ctx context.Context, login string) error
+}
+
 type AuthHandler struct {
-	userService userServiceForAuth
+	userService    userServiceForAuth
+	sessionService sessionServiceForAuth
+	loginThrottle  loginThrottleForAuth
 }
 
-func NewAuthHandler(userService userServiceForAuth) *AuthHandler {
+func NewAuthHandler(
+	userService userServiceForAuth,
+	sessionService sessionServiceForAuth,
+	loginThrottle loginThrottleForAuth,
+) *AuthHandler {
 	return &AuthHandler{
-		userService: userService,
+		userService:    userService,
+		sessionService: sessionService,
+		loginThrottle:  loginThrottle,
 	}
 }
 
@@ -32,8 +54,10 @@ type registerBody struct {
 	Password string `json:"password"`
 }
 
+// Valid only checks the shape of the body, the password policy is up to the
+// service.
 func (b *registerBody) Valid() bool {
-	if len(b.Login) < 4 || len(b.Password) < 6 {
+	if len(b.Login) < 4 || len(b.Password) == 0 {
 		return false
 	}
 
@@ -41,7 +65,8 @@ func (b *registerBody) Valid() bool {
 }
 
 type registerResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // Register godoc
@@ -54,7 +79,7 @@ type registerResponse struct {
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 409 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /register [post]
+// @Router /user/register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var body registerBody
 
@@ -76,23 +101,30 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, domain.ErrWeakPassword) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		log.Println(err)
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
 		return
 	}
 
-	accessToken, err := jwt.GenerateToken(user.ID)
+	tokens, err := h.sessionService.StartSession(r.Context(), user)
 
 	if err != nil {
+		log.Println("[Register]", err)
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, "can not generate token")
 		return
 	}
 
 	response := registerResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 
-	w.Header().Set("Authorization", "Bearer "+accessToken)
+	w.Header().Set("Authorization", "Bearer "+tokens.AccessToken)
 
 	httputils.SendJSONResponse(w, http.StatusOK, response)
 }
@@ -111,11 +143,14 @@ func (b *loginBody) Valid() bool {
 }
 
 type loginResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // Login godoc
 // @Summary Login to account by credentials
+// @Description Repeated failures slow further attempts down (429), too many lock the login for a while (423).
+// @Description Both responses carry Retry-After.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -123,8 +158,11 @@ type loginResponse struct {
 // @Success 200 {object} loginResponse
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 423 {object} httputils.HTTPError
+// @Failure 429 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /login [post]
+// @Router /user/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var body loginBody
 	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
@@ -137,9 +175,35 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ip := clientIP(r)
+
+	if err := h.loginThrottle.Check(r.Context(), body.Login, ip); err != nil {
+		var throttledErr domain.LoginThrottledError
+
+		if errors.As(err, &throttledErr) {
+			status := http.StatusTooManyRequests
+
+			if errors.Is(err, domain.ErrLoginLocked) {
+				status = http.StatusLocked
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(throttledErr.RetryAfterSeconds()))
+			httputils.SendJSONErrorResponse(w, status, throttledErr.Err.Error())
+			return
+		}
+
+		log.Println("[Login]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
 	user, err := h.userService.Auth(r.Context(), body.Login, body.Password)
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidLoginOrPassword) {
+			if err := h.loginThrottle.RegisterFailure(r.Context(), body.Login, ip); err != nil {
+				log.Println("[Login]", err)
+			}
+
 			httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, err.Error())
 			return
 		}
@@ -148,17 +212,126 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, err := jwt.GenerateToken(user.ID)
+	if err := h.loginThrottle.RegisterSuccess(r.Context(), body.Login); err != nil {
+		log.Println("[Login]", err)
+	}
+
+	tokens, err := h.sessionService.StartSession(r.Context(), user)
 	if err != nil {
+		if errors.Is(err, domain.ErrUserLocked) {
+			httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+			return
+		}
+
+		log.Println("[Login]", err)
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, "can not generate token")
 		return
 	}
 
 	response := loginResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 
-	w.Header().Set("Authorization", "Bearer "+accessToken)
+	w.Header().Set("Authorization", "Bearer "+tokens.AccessToken)
 
 	httputils.SendJSONResponse(w, http.StatusOK, response)
 }
+
+type refreshTokenBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (b *refreshTokenBody) Valid() bool {
+	return len(b.RefreshToken) != 0
+}
+
+// RefreshToken godoc
+// @Summary Exchange a refresh token for a new token pair
+// @Description The refresh token is single use. Presenting an already rotated one revokes the session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body refreshTokenBody true "Refresh token"
+// @Success 200 {object} loginResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/token/refresh [post]
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var body refreshTokenBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(r.Context(), body.RefreshToken)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		if errors.Is(err, domain.ErrUserLocked) {
+			httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+			return
+		}
+
+		log.Println("[RefreshToken]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	w.Header().Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	httputils.SendJSONResponse(w, http.StatusOK, loginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+// Logout godoc
+// @Summary Revoke the current session
+// @Tags auth
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := contextutil.GetSessionIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.sessionService.Logout(r.Context(), sessionID); err != nil {
+		log.Println("[Logout]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
+
+// clientIP is the address the request came from. Forwarding headers are
+// ignored on purpose: they are set by the client and would let it pick a fresh
+// IP for every guess.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
 )
@@ -19,7 +21,9 @@ import (
 func TestAuthHandler_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userService := servicemock.NewMockuserServiceForAuth(ctrl)
-	authHandler := NewAuthHandler(userService)
+	sessionService := servicemock.NewMocksessionServiceForAuth(ctrl)
+	loginThrottle := servicemock.NewMockloginThrottleForAuth(ctrl)
+	authHandler := NewAuthHandler(userService, sessionService, loginThrottle)
 
 	type TestCase struct {
 		Name               string
@@ -39,7 +43,11 @@ func TestAuthHandler_Register(t *testing.T) {
 				userService.
 					EXPECT().
 					Register(ctx, body.Login, body.Password).
-					Return(&domain.User{Login: body.Login}, nil)
+					Return(&domain.User{ID: 1, Login: body.Login}, nil)
+				sessionService.
+					EXPECT().
+					StartSession(ctx, gomock.Any()).
+					Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "sid.secret"}, nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
@@ -72,6 +80,20 @@ func TestAuthHandler_Register(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusConflict,
 		},
+		{
+			Name: "invalid (weak password)",
+			Body: &registerBody{
+				Login:    "Login123",
+				Password: "short",
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *registerBody) {
+				userService.
+					EXPECT().
+					Register(ctx, body.Login, body.Password).
+					Return(nil, domain.ErrWeakPassword)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -105,13 +127,16 @@ func TestAuthHandler_Register(t *testing.T) {
 func TestAuthHandler_Login(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userService := servicemock.NewMockuserServiceForAuth(ctrl)
-	authHandler := NewAuthHandler(userService)
+	sessionService := servicemock.NewMocksessionServiceForAuth(ctrl)
+	loginThrottle := servicemock.NewMockloginThrottleForAuth(ctrl)
+	authHandler := NewAuthHandler(userService, sessionService, loginThrottle)
 
 	type TestCase struct {
 		Name               string
 		Body               *loginBody
 		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody)
 		ExpectedStatusCode int
+		ExpectedRetryAfter string
 	}
 
 	testCases := []TestCase{
@@ -122,10 +147,16 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "TestPassword",
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody) {
+				loginThrottle.EXPECT().Check(ctx, body.Login, "192.0.2.1").Return(nil)
 				userService.
 					EXPECT().
 					Auth(ctx, body.Login, body.Password).
-					Return(&domain.User{Login: body.Login}, nil)
+					Return(&domain.User{ID: 1, Login: body.Login}, nil)
+				loginThrottle.EXPECT().RegisterSuccess(ctx, body.Login).Return(nil)
+				sessionService.
+					EXPECT().
+					StartSession(ctx, gomock.Any()).
+					Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "sid.secret"}, nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
@@ -151,13 +182,65 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "ValidPassword123",
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody) {
+				loginThrottle.EXPECT().Check(ctx, body.Login, "192.0.2.1").Return(nil)
 				userService.
 					EXPECT().
 					Auth(ctx, body.Login, body.Password).
 					Return(nil, domain.ErrInvalidLoginOrPassword)
+				loginThrottle.EXPECT().RegisterFailure(ctx, body.Login, "192.0.2.1").Return(nil)
 			},
 			ExpectedStatusCode: http.StatusUnauthorized,
 		},
+		{
+			Name: "invalid (too many attempts)",
+			Body: &loginBody{
+				Login:    "Login123",
+				Password: "ValidPassword123",
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody) {
+				loginThrottle.
+					EXPECT().
+					Check(ctx, body.Login, "192.0.2.1").
+					Return(domain.LoginThrottledError{Err: domain.ErrTooManyLoginAttempts, RetryAfter: time.Second * 4})
+			},
+			ExpectedStatusCode: http.StatusTooManyRequests,
+			ExpectedRetryAfter: "4",
+		},
+		{
+			Name: "invalid (login locked)",
+			Body: &loginBody{
+				Login:    "Login123",
+				Password: "ValidPassword123",
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody) {
+				loginThrottle.
+					EXPECT().
+					Check(ctx, body.Login, "192.0.2.1").
+					Return(domain.LoginThrottledError{Err: domain.ErrLoginLocked, RetryAfter: time.Minute * 30})
+			},
+			ExpectedStatusCode: http.StatusLocked,
+			ExpectedRetryAfter: "1800",
+		},
+		{
+			Name: "invalid (locked user)",
+			Body: &loginBody{
+				Login:    "Login123",
+				Password: "ValidPassword123",
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockuserServiceForAuth, body *loginBody) {
+				loginThrottle.EXPECT().Check(ctx, body.Login, "192.0.2.1").Return(nil)
+				userService.
+					EXPECT().
+					Auth(ctx, body.Login, body.Password).
+					Return(&domain.User{ID: 1, Login: body.Login}, nil)
+				loginThrottle.EXPECT().RegisterSuccess(ctx, body.Login).Return(nil)
+				sessionService.
+					EXPECT().
+					StartSession(ctx, gomock.Any()).
+					Return(nil, domain.ErrUserLocked)
+			},
+			ExpectedStatusCode: http.StatusForbidden,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -183,7 +266,110 @@ func TestAuthHandler_Login(t *testing.T) {
 			res := w.Result()
 			defer res.Body.Close()
 
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+			assert.Equal(t, testCase.ExpectedRetryAfter, res.Header.Get("Retry-After"))
+		})
+	}
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userService := servicemock.NewMockuserServiceForAuth(ctrl)
+	sessionService := servicemock.NewMocksessionServiceForAuth(ctrl)
+	loginThrottle := servicemock.NewMockloginThrottleForAuth(ctrl)
+	authHandler := NewAuthHandler(userService, sessionService, loginThrottle)
+
+	type TestCase struct {
+		Name               string
+		Body               *refreshTokenBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MocksessionServiceForAuth, body *refreshTokenBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &refreshTokenBody{RefreshToken: "sid.secret"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MocksessionServiceForAuth, body *refreshTokenBody) {
+				service.
+					EXPECT().
+					Refresh(ctx, body.RefreshToken).
+					Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "sid.new-secret"}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			Body:               &refreshTokenBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (reused token)",
+			Body: &refreshTokenBody{RefreshToken: "sid.old-secret"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MocksessionServiceForAuth, body *refreshTokenBody) {
+				service.
+					EXPECT().
+					Refresh(ctx, body.RefreshToken).
+					Return(nil, domain.ErrRefreshTokenReused)
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), sessionService, testCase.Body)
+			}
+
+			authHandler.RefreshToken(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
 			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
 		})
 	}
 }
+
+func TestAuthHandler_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userService := servicemock.NewMockuserServiceForAuth(ctrl)
+	sessionService := servicemock.NewMocksessionServiceForAuth(ctrl)
+	loginThrottle := servicemock.NewMockloginThrottleForAuth(ctrl)
+	authHandler := NewAuthHandler(userService, sessionService, loginThrottle)
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r = r.WithContext(contextutil.SetSessionIDToContext(r.Context(), "sid"))
+		w := httptest.NewRecorder()
+
+		sessionService.EXPECT().Logout(r.Context(), "sid").Return(nil)
+
+		authHandler.Logout(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("invalid (no session)", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		w := httptest.NewRecorder()
+
+		authHandler.Logout(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -19,7 +20,8 @@ type userServiceForBalance interface {
 
 type withdrawalServiceForBalance interface {
 	GetWithdrawalsHistory(ctx context.Context, userID int) ([]domain.BalanceAction, error)
-	WithdrawBalance(ctx context.Context, userID int, orderID string, amount float64) error
+	GetWithdrawalsHistoryPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.BalanceActionsPage, error)
+	WithdrawBalance(ctx context.Context, userID int, orderID string, amount domain.Money) error
 }
 
 type BalanceHandler struct {
@@ -42,7 +44,7 @@ func NewBalanceHandler(userService userServiceForBalance, withdrawalService with
 // @Success 200 {object} domain.UserBalance
 // @Failure 401 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /balance [get]
+// @Router /user/balance [get]
 func (h *BalanceHandler) GetUserBalance(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextutil.GetUserIDFromContext(r.Context())
 
@@ -61,12 +63,12 @@ func (h *BalanceHandler) GetUserBalance(w http.ResponseWriter, r *http.Request)
 }
 
 type withdrawBalanceBody struct {
-	Order string  `json:"order"`
-	Sum   float64 `json:"sum"`
+	Order string       `json:"order"`
+	Sum   domain.Money `json:"sum" swaggertype:"number"`
 }
 
 func (b *withdrawBalanceBody) Valid() bool {
-	if b.Sum <= 0 || len(b.Order) == 0 {
+	if !b.Sum.IsPositive() || len(b.Order) == 0 {
 		return false
 	}
 
@@ -85,7 +87,7 @@ func (b *withdrawBalanceBody) Valid() bool {
 // @Failure 401 {object} httputils.HTTPError
 // @Failure 402 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /balance/withdraw [post]
+// @Router /user/balance/withdraw [post]
 func (h *BalanceHandler) WithdrawBalance(w http.ResponseWriter, r *http.Request) {
 	var body withdrawBalanceBody
 
@@ -126,21 +128,55 @@ func (h *BalanceHandler) WithdrawBalance(w http.ResponseWriter, r *http.Request)
 }
 
 type userWithdrawalForResponse struct {
-	Order       string     `json:"order"`
-	Sum         float64    `json:"sum"`
-	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	ID           int          `json:"id"`
+	Order        string       `json:"order"`
+	Sum          domain.Money `json:"sum" swaggertype:"number"`
+	ProcessedAt  *time.Time   `json:"processed_at,omitempty"`
+	RefundReason *string      `json:"refund_reason,omitempty"`
+	RefundedAt   *time.Time   `json:"refunded_at,omitempty"`
+}
+
+func newUserWithdrawalForResponse(withdrawal domain.BalanceAction) userWithdrawalForResponse {
+	responseWithdrawal := userWithdrawalForResponse{
+		ID:          withdrawal.ID,
+		Order:       withdrawal.OrderID,
+		Sum:         withdrawal.Amount,
+		ProcessedAt: withdrawal.ProcessedAt,
+	}
+
+	if withdrawal.ReversedBy != nil {
+		responseWithdrawal.RefundReason = withdrawal.ReversedBy.Reason
+		responseWithdrawal.RefundedAt = withdrawal.ReversedBy.ProcessedAt
+	}
+
+	return responseWithdrawal
+}
+
+type userWithdrawalsPageForResponse struct {
+	Items      []userWithdrawalForResponse `json:"items"`
+	NextCursor *string                     `json:"next_cursor"`
 }
 
 // GetWithdrawalHistory godoc
 // @Summary Get user withdrawals history
+// @Description Without query parameters the whole history is returned as a list.
+// @Description With any of them one page is returned as {"items": [...], "next_cursor": "..."},
+// @Description next_cursor is null on the last page.
 // @Tags balance
 // @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, 50 by default, 500 at most"
+// @Param status query string false "PROCESSED for withdrawals without a refund, REFUNDED for refunded ones" Enums(PROCESSED, REFUNDED)
+// @Param from query string false "Created at or after, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Created before, RFC 3339 or YYYY-MM-DD (the whole day)"
+// @Param sort query string false "Sort direction by creation time, desc by default" Enums(asc, desc)
 // @Security BearerAuth
 // @Success 200 {array} userWithdrawalForResponse
 // @Success 204
+// @Failure 400 {object} httputils.HTTPError
 // @Failure 401 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /withdrawals [get]
+// @Router /user/withdrawals [get]
 func (h *BalanceHandler) GetWithdrawalHistory(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextutil.GetUserIDFromContext(r.Context())
 
@@ -148,6 +184,18 @@ func (h *BalanceHandler) GetWithdrawalHistory(w http.ResponseWriter, r *http.Req
 		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
 	}
 
+	filter, paged, err := parseHistoryFilter(r)
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if paged {
+		h.getWithdrawalHistoryPage(w, r, userID, filter)
+		return
+	}
+
 	withdrawals, err := h.withdrawalService.GetWithdrawalsHistory(r.Context(), userID)
 
 	if err != nil {
@@ -164,12 +212,41 @@ func (h *BalanceHandler) GetWithdrawalHistory(w http.ResponseWriter, r *http.Req
 	responseWithdrawals := make([]userWithdrawalForResponse, 0)
 
 	for _, withdrawal := range withdrawals {
-		responseWithdrawals = append(responseWithdrawals, userWithdrawalForResponse{
-			Order:       withdrawal.OrderID,
-			Sum:         withdrawal.Amount,
-			ProcessedAt: withdrawal.ProcessedAt,
-		})
+		responseWithdrawals = append(responseWithdrawals, newUserWithdrawalForResponse(withdrawal))
 	}
 
 	httputils.SendJSONResponse(w, http.StatusOK, responseWithdrawals)
 }
+
+func (h *BalanceHandler) getWithdrawalHistoryPage(
+	w http.ResponseWriter, r *http.Request, userID int, filter domain.HistoryFilter,
+) {
+	page, err := h.withdrawalService.GetWithdrawalsHistoryPage(r.Context(), userID, filter)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHistoryFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[GetWithdrawalHistory]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, "can not get withdrawals")
+		return
+	}
+
+	if len(page.Items) == 0 {
+		httputils.SendStatusCode(w, http.StatusNoContent)
+		return
+	}
+
+	response := userWithdrawalsPageForResponse{
+		Items:      make([]userWithdrawalForResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+
+	for _, withdrawal := range page.Items {
+		response.Items = append(response.Items, newUserWithdrawalForResponse(withdrawal))
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, response)
+}
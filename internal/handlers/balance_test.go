@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,7 +44,7 @@ func TestBalanceHandler_GetUserBalance(t *testing.T) {
 				userService.
 					EXPECT().
 					GetUserBalance(ctx, userID).
-					Return(&domain.UserBalance{Current: 100.00, Withdrawn: 0.00}, nil)
+					Return(&domain.UserBalance{Current: domain.MoneyFromInt(100), Withdrawn: domain.Money{}}, nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
@@ -96,7 +97,7 @@ func TestBalanceHandler_WithdrawBalance(t *testing.T) {
 			Name: "valid",
 			Body: &withdrawBalanceBody{
 				Order: "12344",
-				Sum:   100.0,
+				Sum:   domain.MoneyFromInt(100),
 			},
 			UserID: 1,
 			PrepareServiceFunc: func(ctx context.Context, userService *servicemock.MockuserServiceForBalance, withdrawalService *servicemock.MockwithdrawalServiceForBalance, body *withdrawBalanceBody, userID int) {
@@ -123,7 +124,7 @@ func TestBalanceHandler_WithdrawBalance(t *testing.T) {
 			Name: "invalid (not enough balance)",
 			Body: &withdrawBalanceBody{
 				Order: "12344",
-				Sum:   100.0,
+				Sum:   domain.MoneyFromInt(100),
 			},
 			UserID: 1,
 			PrepareServiceFunc: func(ctx context.Context, userService *servicemock.MockuserServiceForBalance, withdrawalService *servicemock.MockwithdrawalServiceForBalance, body *withdrawBalanceBody, userID int) {
@@ -174,6 +175,7 @@ func TestBalanceHandler_GetWithdrawalHistory(t *testing.T) {
 
 	type TestCase struct {
 		Name               string
+		Query              string
 		UserID             int
 		PrepareServiceFunc func(
 			ctx context.Context,
@@ -207,11 +209,34 @@ func TestBalanceHandler_GetWithdrawalHistory(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusNoContent,
 		},
+		{
+			Name:   "valid (page)",
+			Query:  "?limit=2&status=REFUNDED&to=2026-10-17",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, userService *servicemock.MockuserServiceForBalance, withdrawalService *servicemock.MockwithdrawalServiceForBalance, userID int) {
+				withdrawalService.
+					EXPECT().
+					GetWithdrawalsHistoryPage(ctx, userID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.BalanceActionsPage, error) {
+						assert.Equal(t, 2, filter.Limit)
+						assert.Equal(t, domain.RefundedWithdrawalStatus, filter.Status)
+						assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), *filter.To)
+						return &domain.BalanceActionsPage{Items: []domain.BalanceAction{{UserID: userID}}}, nil
+					})
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (bad date)",
+			Query:              "?from=yesterday",
+			UserID:             1,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
-			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r := httptest.NewRequest(http.MethodGet, "/"+testCase.Query, nil)
 			r.Header.Set("Content-Type", "application/json")
 			ctx := contextutil.SetUserIDToContext(r.Context(), testCase.UserID)
 			r = r.WithContext(ctx)
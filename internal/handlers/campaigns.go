@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
+)
+
+type campaignsService interface {
+	CreateCampaign(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error)
+	GetCampaign(ctx context.Context, merchantID int, id int) (*domain.Campaign, error)
+	GetCampaigns(ctx context.Context, merchantID int) ([]domain.Campaign, error)
+	UpdateCampaign(ctx context.Context, merchantID int, id int, update domain.CampaignUpdate) (*domain.Campaign, error)
+}
+
+type CampaignsHandler struct {
+	service campaignsService
+}
+
+func NewCampaignsHandler(service campaignsService) *CampaignsHandler {
+	return &CampaignsHandler{
+		service: service,
+	}
+}
+
+type createCampaignBody struct {
+	Name      string     `json:"name"`
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+}
+
+func (b *createCampaignBody) Valid() bool {
+	return len(strings.TrimSpace(b.Name)) > 0
+}
+
+// CreateCampaign godoc
+// @Summary Create campaign
+// @Description A campaign groups reward rules: they only apply while it is enabled and within [valid_from, valid_to).
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param dto body createCampaignBody true "New campaign"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 201 {object} domain.Campaign
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /campaigns [post]
+func (h *CampaignsHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body createCampaignBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	campaign, err := h.service.CreateCampaign(r.Context(), domain.Campaign{
+		MerchantID: merchantID,
+		Name:       body.Name,
+		ValidFrom:  body.ValidFrom,
+		ValidTo:    body.ValidTo,
+		Disabled:   body.Disabled,
+	})
+
+	if err != nil {
+		sendCampaignError(w, "[CreateCampaign]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, campaign)
+}
+
+// GetCampaigns godoc
+// @Summary Get campaigns
+// @Tags campaigns
+// @Produce json
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {array} domain.Campaign
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} httputils.HTTPError
+// @Router /campaigns [get]
+func (h *CampaignsHandler) GetCampaigns(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	campaigns, err := h.service.GetCampaigns(r.Context(), merchantID)
+
+	if err != nil {
+		log.Println("[GetCampaigns]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	if len(campaigns) == 0 {
+		httputils.SendStatusCode(w, http.StatusNoContent)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, campaigns)
+}
+
+// GetCampaign godoc
+// @Summary Get campaign
+// @Tags campaigns
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.Campaign
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /campaigns/{id} [get]
+func (h *CampaignsHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := campaignIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	campaign, err := h.service.GetCampaign(r.Context(), merchantID, id)
+
+	if err != nil {
+		sendCampaignError(w, "[GetCampaign]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, campaign)
+}
+
+type updateCampaignBody struct {
+	Name *string `json:"name,omitempty"`
+	// A zero time ("0001-01-01T00:00:00Z") removes the bound.
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	Disabled  *bool      `json:"disabled,omitempty"`
+}
+
+func (b *updateCampaignBody) Valid() bool {
+	if b.Name == nil && b.ValidFrom == nil && b.ValidTo == nil && b.Disabled == nil {
+		return false
+	}
+
+	if b.Name != nil && len(strings.TrimSpace(*b.Name)) == 0 {
+		return false
+	}
+
+	return true
+}
+
+// UpdateCampaign godoc
+// @Summary Update campaign
+// @Description Changes only the fields present in the body, {"disabled": true} stops every rule of the campaign.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param dto body updateCampaignBody true "Fields to change"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.Campaign
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /campaigns/{id} [patch]
+func (h *CampaignsHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := campaignIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body updateCampaignBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	campaign, err := h.service.UpdateCampaign(r.Context(), merchantID, id, domain.CampaignUpdate{
+		Name:      body.Name,
+		ValidFrom: body.ValidFrom,
+		ValidTo:   body.ValidTo,
+		Disabled:  body.Disabled,
+	})
+
+	if err != nil {
+		sendCampaignError(w, "[UpdateCampaign]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, campaign)
+}
+
+func sendCampaignError(w http.ResponseWriter, prefix string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		httputils.SendJSONErrorResponse(w, http.StatusNotFound, "campaign not found")
+	case errors.Is(err, domain.ErrCampaignNameTaken):
+		httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidValidity):
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Println(prefix, err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+	}
+}
+
+func campaignIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid campaign id")
+		return 0, false
+	}
+
+	return id, true
+}
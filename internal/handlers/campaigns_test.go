@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func TestCampaignsHandler_CreateCampaign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	campaignsService := servicemock.NewMockcampaignsService(ctrl)
+	campaignsHandler := NewCampaignsHandler(campaignsService)
+
+	type TestCase struct {
+		Name               string
+		Body               *createCampaignBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockcampaignsService, body *createCampaignBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &createCampaignBody{Name: "Winter"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockcampaignsService, body *createCampaignBody) {
+				service.
+					EXPECT().
+					CreateCampaign(ctx, domain.Campaign{MerchantID: 1, Name: body.Name}).
+					Return(&domain.Campaign{ID: 1, MerchantID: 1, Name: body.Name}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "invalid (no name)",
+			Body:               &createCampaignBody{Name: " "},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (name taken)",
+			Body: &createCampaignBody{Name: "Winter"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockcampaignsService, body *createCampaignBody) {
+				service.
+					EXPECT().
+					CreateCampaign(ctx, gomock.Any()).
+					Return(nil, domain.ErrCampaignNameTaken)
+			},
+			ExpectedStatusCode: http.StatusConflict,
+		},
+		{
+			Name: "invalid (empty validity window)",
+			Body: &createCampaignBody{Name: "Winter"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockcampaignsService, body *createCampaignBody) {
+				service.
+					EXPECT().
+					CreateCampaign(ctx, gomock.Any()).
+					Return(nil, domain.ErrInvalidValidity)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodPost, "/", testCase.Body, "")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), campaignsService, testCase.Body)
+			}
+
+			campaignsHandler.CreateCampaign(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestCampaignsHandler_UpdateCampaign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	campaignsService := servicemock.NewMockcampaignsService(ctrl)
+	campaignsHandler := NewCampaignsHandler(campaignsService)
+
+	disabled := true
+
+	type TestCase struct {
+		Name               string
+		ID                 string
+		Body               *updateCampaignBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockcampaignsService, body *updateCampaignBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid (disable)",
+			ID:   "2",
+			Body: &updateCampaignBody{Disabled: &disabled},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockcampaignsService, body *updateCampaignBody) {
+				service.
+					EXPECT().
+					UpdateCampaign(ctx, 1, 2, domain.CampaignUpdate{Disabled: body.Disabled}).
+					Return(&domain.Campaign{ID: 2, Disabled: true}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (empty body)",
+			ID:                 "2",
+			Body:               &updateCampaignBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (id)",
+			ID:                 "winter",
+			Body:               &updateCampaignBody{Disabled: &disabled},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (not found)",
+			ID:   "3",
+			Body: &updateCampaignBody{Disabled: &disabled},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockcampaignsService, body *updateCampaignBody) {
+				service.
+					EXPECT().
+					UpdateCampaign(ctx, 1, 3, gomock.Any()).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodPatch, "/", testCase.Body, testCase.ID)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), campaignsService, testCase.Body)
+			}
+
+			campaignsHandler.UpdateCampaign(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
@@ -1,20 +1,48 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/rulefile"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
 )
 
 type goodRewardsService interface {
-	SaveNewGoodReward(ctx context.Context, match string, reward float64, rewardType string) (*domain.GoodReward, error)
+	SaveNewGoodReward(ctx context.Context, reward domain.GoodReward) (*domain.GoodReward, error)
+	GetGoodReward(ctx context.Context, merchantID int, id int) (*domain.GoodReward, error)
+	GetGoodRewardsPage(
+		ctx context.Context, merchantID int, filter domain.GoodRewardsFilter,
+	) (*domain.GoodRewardsPage, error)
+	UpdateGoodReward(
+		ctx context.Context, merchantID int, id int, update domain.GoodRewardUpdate,
+	) (*domain.GoodReward, error)
+	DeleteGoodReward(ctx context.Context, merchantID int, id int) error
+	GetGoodRewardVersions(ctx context.Context, merchantID int, id int) ([]domain.GoodRewardVersion, error)
+	DiffGoodRewardVersions(
+		ctx context.Context, merchantID int, id int, from int, to int,
+	) (*domain.GoodRewardDiff, error)
+	ImportGoodRewards(
+		ctx context.Context, merchantID int, rows []domain.ImportedGoodReward, dryRun bool,
+	) (*domain.GoodRewardImportReport, error)
+	ExportGoodRewards(ctx context.Context, merchantID int) ([]domain.GoodReward, error)
 }
 
+// maxImportBytes is the largest rule file the import accepts, big enough for
+// MaxImportedGoodRewards rules.
+const maxImportBytes = 10 << 20
+
 type GoodsHandler struct {
 	goodRewardsService goodRewardsService
 }
@@ -26,31 +54,120 @@ func NewGoodsHandler(goodRewardsService goodRewardsService) *GoodsHandler {
 }
 
 type saveNewGoodRewardBody struct {
-	Match      string  `json:"match"`
-	Reward     float64 `json:"reward"`
-	RewardType string  `json:"reward_type"`
+	Match string `json:"match"`
+	// MatchField is description when empty.
+	MatchField string `json:"match_field,omitempty" enums:"description,sku,category"`
+	// MatchType is substring when empty, exact for sku and category.
+	MatchType  string       `json:"match_type,omitempty" enums:"substring,exact,prefix,word,regex"`
+	IgnoreCase bool         `json:"ignore_case,omitempty"`
+	Reward     domain.Money `json:"reward" swaggertype:"number"`
+	RewardType string       `json:"reward_type"`
+	Priority   int          `json:"priority,omitempty"`
+	// Stacking is stackable when empty.
+	Stacking    string           `json:"stacking,omitempty" enums:"stackable,exclusive,best_of"`
+	MaxPerGood  *domain.Money    `json:"max_per_good,omitempty" swaggertype:"number"`
+	MaxPerOrder *domain.Money    `json:"max_per_order,omitempty" swaggertype:"number"`
+	CampaignID  *int             `json:"campaign_id,omitempty"`
+	ValidFrom   *time.Time       `json:"valid_from,omitempty"`
+	ValidTo     *time.Time       `json:"valid_to,omitempty"`
+	Schedule    *domain.Schedule `json:"schedule,omitempty"`
+	// MinBasket and Tiers are only for order rules.
+	MinBasket *domain.Money       `json:"min_basket,omitempty" swaggertype:"number"`
+	Tiers     []domain.RewardTier `json:"tiers,omitempty"`
 }
 
 func (b *saveNewGoodRewardBody) Valid() bool {
-	if len(b.Match) == 0 || b.Reward <= 0 || !domain.IsValidRewardType(b.RewardType) {
+	if !domain.IsValidRewardType(b.RewardType) {
+		return false
+	}
+
+	// An order rule with no match applies to the whole order.
+	if len(b.Match) == 0 && !domain.IsOrderRewardType(b.RewardType) {
+		return false
+	}
+
+	if domain.IsTieredRewardType(b.RewardType) != b.Reward.IsZero() || b.Reward.IsNegative() {
+		return false
+	}
+
+	if b.MatchType != "" && !domain.IsValidMatchType(b.MatchType) {
+		return false
+	}
+
+	if b.MatchField != "" && !domain.IsValidMatchField(b.MatchField) {
+		return false
+	}
+
+	if b.Stacking != "" && !domain.IsValidStackingMode(b.Stacking) {
+		return false
+	}
+
+	if (b.MaxPerGood != nil && !b.MaxPerGood.IsPositive()) || (b.MaxPerOrder != nil && !b.MaxPerOrder.IsPositive()) {
+		return false
+	}
+
+	if b.CampaignID != nil && *b.CampaignID <= 0 {
 		return false
 	}
 
 	return true
 }
 
+func (b *saveNewGoodRewardBody) goodReward(merchantID int) domain.GoodReward {
+	return domain.GoodReward{
+		MerchantID:  merchantID,
+		Match:       b.Match,
+		MatchType:   b.MatchType,
+		MatchField:  b.MatchField,
+		IgnoreCase:  b.IgnoreCase,
+		Reward:      b.Reward,
+		RewardType:  b.RewardType,
+		Priority:    b.Priority,
+		Stacking:    b.Stacking,
+		MaxPerGood:  b.MaxPerGood,
+		MaxPerOrder: b.MaxPerOrder,
+		CampaignID:  b.CampaignID,
+		ValidFrom:   b.ValidFrom,
+		ValidTo:     b.ValidTo,
+		Schedule:    b.Schedule,
+		MinBasket:   b.MinBasket,
+		Tiers:       b.Tiers,
+	}
+}
+
 // SaveNewGoodReward godoc
 // @Summary Save new good reward
+// @Description match_type decides how match is compared with good descriptions: substring (default),
+// @Description exact, prefix, word (not part of a longer word) or regex (RE2 syntax, unanchored).
+// @Description ignore_case compares both in lower case. match_field picks what match is compared with:
+// @Description the description (default), or the sku or category of goods, which are only matched exactly.
+// @Description The rule applies to purchases made in [valid_from, valid_to) and within schedule, whose days
+// @Description are 0 (Sunday) to 6 and hours [from_hour, to_hour) in timezone (UTC by default); hours past
+// @Description midnight wrap. A rule of a campaign also needs the campaign to be enabled and valid.
+// @Description reward_type % and pt reward each matching good. order_pt and order_% reward the basket of
+// @Description matching goods once per order (an empty match takes every good) when it reaches min_basket;
+// @Description tiered_pt gives the points of the highest tier reached and tiered_% each tier's percent of
+// @Description the basket part between its threshold and the next one. Tiered rules have no reward.
 // @Tags goods
 // @Accept json
 // @Produce json
 // @Param dto body saveNewGoodRewardBody true "Add new Good Reward"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
 // @Success 200 {object} domain.GoodReward
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 409 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
 // @Failure 500 {object} httputils.HTTPError
 // @Router /goods [post]
 func (h *GoodsHandler) SaveNewGoodReward(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
 	var body saveNewGoodRewardBody
 
 	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
@@ -63,18 +180,667 @@ func (h *GoodsHandler) SaveNewGoodReward(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	reward, err := h.goodRewardsService.SaveNewGoodReward(r.Context(), body.Match, body.Reward, body.RewardType)
+	reward, err := h.goodRewardsService.SaveNewGoodReward(r.Context(), body.goodReward(merchantID))
+
+	if err != nil {
+		sendGoodRewardError(w, "[SaveNewGoodReward]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, reward)
+}
+
+type goodRewardsPageForResponse struct {
+	Items      []domain.GoodReward `json:"items"`
+	NextCursor *string             `json:"next_cursor"`
+}
+
+// GetGoodRewards godoc
+// @Summary Get good rewards
+// @Description One page of the merchant rules as {"items": [...], "next_cursor": "..."},
+// @Description next_cursor is null on the last page. Deleted rules are not listed.
+// @Tags goods
+// @Produce json
+// @Param search query string false "Part of the match text, case insensitive"
+// @Param campaign query int false "Only rules of this campaign"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, 50 by default, 500 at most"
+// @Param status query string false "Rule status" Enums(ACTIVE, DISABLED)
+// @Param from query string false "Created at or after, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Created before, RFC 3339 or YYYY-MM-DD (the whole day)"
+// @Param sort query string false "Sort direction by creation time, asc by default" Enums(asc, desc)
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} goodRewardsPageForResponse
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods [get]
+func (h *GoodsHandler) GetGoodRewards(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	historyFilter, _, err := parseHistoryFilter(r)
 
 	if err != nil {
-		if errors.Is(err, domain.ErrMatchKeyAlreadyExists) {
-			httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := domain.GoodRewardsFilter{
+		HistoryFilter: historyFilter,
+		Search:        r.URL.Query().Get("search"),
+	}
+
+	if value := r.URL.Query().Get("campaign"); value != "" {
+		campaignID, err := strconv.Atoi(value)
+
+		if err != nil {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid campaign id")
+			return
+		}
+
+		filter.CampaignID = &campaignID
+	}
+
+	page, err := h.goodRewardsService.GetGoodRewardsPage(r.Context(), merchantID, filter)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHistoryFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		log.Println("[SaveNewGoodReward]", err)
+		log.Println("[GetGoodRewards]", err)
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
 		return
 	}
 
+	if len(page.Items) == 0 {
+		httputils.SendStatusCode(w, http.StatusNoContent)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, goodRewardsPageForResponse{
+		Items:      page.Items,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// GetGoodReward godoc
+// @Summary Get good reward
+// @Tags goods
+// @Produce json
+// @Param id path int true "Good reward ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.GoodReward
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id} [get]
+func (h *GoodsHandler) GetGoodReward(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	reward, err := h.goodRewardsService.GetGoodReward(r.Context(), merchantID, id)
+
+	if err != nil {
+		sendGoodRewardError(w, "[GetGoodReward]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, reward)
+}
+
+// replaceGoodRewardBody is the whole new state of a rule: fields left out
+// get their defaults, caps, campaign, validity and schedule left out are
+// removed.
+type replaceGoodRewardBody struct {
+	saveNewGoodRewardBody
+	Disabled bool `json:"disabled"`
+}
+
+// ReplaceGoodReward godoc
+// @Summary Replace good reward
+// @Tags goods
+// @Accept json
+// @Produce json
+// @Param id path int true "Good reward ID"
+// @Param dto body replaceGoodRewardBody true "New state of the Good Reward"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.GoodReward
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id} [put]
+func (h *GoodsHandler) ReplaceGoodReward(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body replaceGoodRewardBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	replacement := body.goodReward(merchantID)
+	replacement.Disabled = body.Disabled
+	replacement.SetDefaults()
+
+	reward, err := h.goodRewardsService.UpdateGoodReward(r.Context(), merchantID, id, replacement.Replacement())
+
+	if err != nil {
+		sendGoodRewardError(w, "[ReplaceGoodReward]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, reward)
+}
+
+type updateGoodRewardBody struct {
+	Match      *string       `json:"match,omitempty"`
+	MatchType  *string       `json:"match_type,omitempty" enums:"substring,exact,prefix,word,regex"`
+	MatchField *string       `json:"match_field,omitempty" enums:"description,sku,category"`
+	IgnoreCase *bool         `json:"ignore_case,omitempty"`
+	Reward     *domain.Money `json:"reward,omitempty" swaggertype:"number"`
+	RewardType *string       `json:"reward_type,omitempty"`
+	Priority   *int          `json:"priority,omitempty"`
+	Stacking   *string       `json:"stacking,omitempty" enums:"stackable,exclusive,best_of"`
+	// A zero cap removes the cap.
+	MaxPerGood  *domain.Money `json:"max_per_good,omitempty" swaggertype:"number"`
+	MaxPerOrder *domain.Money `json:"max_per_order,omitempty" swaggertype:"number"`
+	// A zero campaign_id, a zero time ("0001-01-01T00:00:00Z") or an empty
+	// schedule removes it from the rule.
+	CampaignID *int             `json:"campaign_id,omitempty"`
+	ValidFrom  *time.Time       `json:"valid_from,omitempty"`
+	ValidTo    *time.Time       `json:"valid_to,omitempty"`
+	Schedule   *domain.Schedule `json:"schedule,omitempty"`
+	// A zero min_basket or empty tiers removes them.
+	MinBasket *domain.Money        `json:"min_basket,omitempty" swaggertype:"number"`
+	Tiers     *[]domain.RewardTier `json:"tiers,omitempty"`
+	Disabled  *bool                `json:"disabled,omitempty"`
+}
+
+func (b *updateGoodRewardBody) Valid() bool {
+	if b.Match == nil && b.MatchType == nil && b.MatchField == nil && b.IgnoreCase == nil && b.Reward == nil && b.RewardType == nil &&
+		b.Priority == nil && b.Stacking == nil && b.MaxPerGood == nil && b.MaxPerOrder == nil &&
+		b.CampaignID == nil && b.ValidFrom == nil && b.ValidTo == nil && b.Schedule == nil &&
+		b.MinBasket == nil && b.Tiers == nil && b.Disabled == nil {
+		return false
+	}
+
+	if b.CampaignID != nil && *b.CampaignID < 0 {
+		return false
+	}
+
+	if b.Stacking != nil && !domain.IsValidStackingMode(*b.Stacking) {
+		return false
+	}
+
+	if (b.MaxPerGood != nil && b.MaxPerGood.IsNegative()) || (b.MaxPerOrder != nil && b.MaxPerOrder.IsNegative()) {
+		return false
+	}
+
+	if b.MatchType != nil && !domain.IsValidMatchType(*b.MatchType) {
+		return false
+	}
+
+	if b.MatchField != nil && !domain.IsValidMatchField(*b.MatchField) {
+		return false
+	}
+
+	if (b.Reward != nil && b.Reward.IsNegative()) || (b.MinBasket != nil && b.MinBasket.IsNegative()) {
+		return false
+	}
+
+	if b.RewardType != nil && !domain.IsValidRewardType(*b.RewardType) {
+		return false
+	}
+
+	return true
+}
+
+// UpdateGoodReward godoc
+// @Summary Update good reward
+// @Description Changes only the fields present in the body, {"disabled": true} disables the rule.
+// @Tags goods
+// @Accept json
+// @Produce json
+// @Param id path int true "Good reward ID"
+// @Param dto body updateGoodRewardBody true "Fields to change"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.GoodReward
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id} [patch]
+func (h *GoodsHandler) UpdateGoodReward(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	var body updateGoodRewardBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	reward, err := h.goodRewardsService.UpdateGoodReward(r.Context(), merchantID, id, domain.GoodRewardUpdate{
+		Match:       body.Match,
+		MatchType:   body.MatchType,
+		MatchField:  body.MatchField,
+		IgnoreCase:  body.IgnoreCase,
+		Reward:      body.Reward,
+		RewardType:  body.RewardType,
+		Priority:    body.Priority,
+		Stacking:    body.Stacking,
+		MaxPerGood:  body.MaxPerGood,
+		MaxPerOrder: body.MaxPerOrder,
+		CampaignID:  body.CampaignID,
+		ValidFrom:   body.ValidFrom,
+		ValidTo:     body.ValidTo,
+		Schedule:    body.Schedule,
+		MinBasket:   body.MinBasket,
+		Tiers:       body.Tiers,
+		Disabled:    body.Disabled,
+	})
+
+	if err != nil {
+		sendGoodRewardError(w, "[UpdateGoodReward]", err)
+		return
+	}
+
 	httputils.SendJSONResponse(w, http.StatusOK, reward)
 }
+
+// DeleteGoodReward godoc
+// @Summary Delete good reward
+// @Description The rule stops applying and is no longer listed, but is kept for past calculations.
+// @Description The deletion is recorded as the last version of the rule.
+// @Tags goods
+// @Param id path int true "Good reward ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id} [delete]
+func (h *GoodsHandler) DeleteGoodReward(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	if err := h.goodRewardsService.DeleteGoodReward(r.Context(), merchantID, id); err != nil {
+		sendGoodRewardError(w, "[DeleteGoodReward]", err)
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
+
+// GetGoodRewardVersions godoc
+// @Summary Get good reward version history
+// @Description Every change of a rule creates a new version, effective from the time of the change.
+// @Description Versions are never changed, breakdowns name the version every reward came from.
+// @Description Deleted rules keep their history.
+// @Tags goods
+// @Produce json
+// @Param id path int true "Good reward ID"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {array} domain.GoodRewardVersion
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id}/versions [get]
+func (h *GoodsHandler) GetGoodRewardVersions(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	versions, err := h.goodRewardsService.GetGoodRewardVersions(r.Context(), merchantID, id)
+
+	if err != nil {
+		sendGoodRewardError(w, "[GetGoodRewardVersions]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, versions)
+}
+
+// DiffGoodRewardVersions godoc
+// @Summary Diff two good reward versions
+// @Description changes lists the fields, by their JSON names, that differ between the versions.
+// @Tags goods
+// @Produce json
+// @Param id path int true "Good reward ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.GoodRewardDiff
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/{id}/versions/diff [get]
+func (h *GoodsHandler) DiffGoodRewardVersions(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, ok := goodRewardIDFromPath(w, r)
+
+	if !ok {
+		return
+	}
+
+	from, fromErr := strconv.Atoi(r.URL.Query().Get("from"))
+	to, toErr := strconv.Atoi(r.URL.Query().Get("to"))
+
+	if fromErr != nil || toErr != nil || from <= 0 || to <= 0 {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid versions")
+		return
+	}
+
+	diff, err := h.goodRewardsService.DiffGoodRewardVersions(r.Context(), merchantID, id, from, to)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, "good reward version not found")
+			return
+		}
+
+		sendGoodRewardError(w, "[DiffGoodRewardVersions]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, diff)
+}
+
+// ImportGoodRewards godoc
+// @Summary Import good rewards
+// @Description Creates or replaces many rules at once from a CSV file with a header row or a JSON array,
+// @Description with the fields of the rule API; in CSV, tiers and schedule are JSON cells. A rule with an id
+// @Description replaces that rule, one without replaces the rule with the same match_field, match, match_type
+// @Description and ignore_case (per-good and order rules apart), or is created. Every row is checked, and
+// @Description either every rule is imported or none is: when a row fails the response is 422 and the
+// @Description other rows tell what would have been done. dry_run=true only checks the file.
+// @Description The format is taken from the Content-Type when not given.
+// @Tags goods
+// @Accept text/csv,json
+// @Produce json
+// @Param format query string false "File format" Enums(csv, json)
+// @Param dry_run query bool false "Check the file without saving anything"
+// @Param file body string true "Rule file"
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {object} domain.GoodRewardImportReport
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 413 {object} httputils.HTTPError
+// @Failure 422 {object} domain.GoodRewardImportReport
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/import [post]
+func (h *GoodsHandler) ImportGoodRewards(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("content-type"))
+	}
+
+	if !rulefile.IsValidFormat(format) {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid format")
+		return
+	}
+
+	dryRun := false
+
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid dry_run")
+			return
+		}
+	}
+
+	defer r.Body.Close()
+
+	rows, err := rulefile.Read(format, http.MaxBytesReader(w, r.Body, maxImportBytes))
+
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidImport):
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.As(err, &maxBytesErr):
+			httputils.SendJSONErrorResponse(w, http.StatusRequestEntityTooLarge, err.Error())
+		default:
+			log.Println("[ImportGoodRewards]", err)
+			httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		}
+
+		return
+	}
+
+	report, err := h.goodRewardsService.ImportGoodRewards(r.Context(), merchantID, rows, dryRun)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImport) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[ImportGoodRewards]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	if report.Failed > 0 {
+		httputils.SendJSONResponse(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, report)
+}
+
+// ExportGoodRewards godoc
+// @Summary Export good rewards
+// @Description Every rule that is not deleted, in the format the import reads, so the file can be edited
+// @Description and imported again.
+// @Tags goods
+// @Produce text/csv,json
+// @Param format query string false "File format, csv by default" Enums(csv, json)
+// @Param X-Merchant header string false "Merchant code, ignored for keys bound to a merchant"
+// @Security APIKey
+// @Success 200 {string} string "Rule file"
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401
+// @Failure 403
+// @Failure 500 {object} httputils.HTTPError
+// @Router /goods/export [get]
+func (h *GoodsHandler) ExportGoodRewards(w http.ResponseWriter, r *http.Request) {
+	merchantID, ok := merchantIDFromContext(w, r)
+
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+
+	if format == "" {
+		format = rulefile.CSVFormat
+	}
+
+	if !rulefile.IsValidFormat(format) {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid format")
+		return
+	}
+
+	rewards, err := h.goodRewardsService.ExportGoodRewards(r.Context(), merchantID)
+
+	if err != nil {
+		log.Println("[ExportGoodRewards]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	var file bytes.Buffer
+
+	if err := rulefile.Write(format, &file, rewards); err != nil {
+		log.Println("[ExportGoodRewards]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	contentType := "application/json"
+
+	if format == rulefile.CSVFormat {
+		contentType = "text/csv; charset=utf-8"
+	}
+
+	w.Header().Set("content-type", contentType)
+	w.Header().Set("content-disposition", `attachment; filename="good_rewards.`+format+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Bytes())
+}
+
+// formatFromContentType returns the rule file format of a content type, or
+// an empty string.
+func formatFromContentType(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return rulefile.CSVFormat
+	case strings.HasPrefix(contentType, "application/json"):
+		return rulefile.JSONFormat
+	default:
+		return ""
+	}
+}
+
+func sendGoodRewardError(w http.ResponseWriter, prefix string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		httputils.SendJSONErrorResponse(w, http.StatusNotFound, "good reward not found")
+	case errors.Is(err, domain.ErrMatchKeyAlreadyExists):
+		httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidMatch), errors.Is(err, domain.ErrInvalidValidity),
+		errors.Is(err, domain.ErrInvalidSchedule), errors.Is(err, domain.ErrUnknownCampaign),
+		errors.Is(err, domain.ErrInvalidReward):
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Println(prefix, err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+	}
+}
+
+func goodRewardIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid good reward id")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// merchantIDFromContext returns the merchant put into the context by the
+// merchant middleware.
+func merchantIDFromContext(w http.ResponseWriter, r *http.Request) (int, bool) {
+	merchantID, err := contextutil.GetMerchantIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, domain.ErrUnknownMerchant.Error())
+		return 0, false
+	}
+
+	return merchantID, true
+}
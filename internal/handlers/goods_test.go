@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
 )
@@ -21,6 +24,8 @@ func TestGoodsHandler_SaveNewGoodReward(t *testing.T) {
 	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
 	goodsHandler := NewGoodsHandler(goodsRewardsService)
 
+	unknownCampaignID := 42
+
 	type TestCase struct {
 		Name               string
 		Body               *saveNewGoodRewardBody
@@ -33,17 +38,60 @@ func TestGoodsHandler_SaveNewGoodReward(t *testing.T) {
 			Name: "valid",
 			Body: &saveNewGoodRewardBody{
 				Match:      "Bork",
-				Reward:     10.0,
+				Reward:     domain.MoneyFromInt(10),
 				RewardType: domain.PercentRewardType,
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *saveNewGoodRewardBody) {
 				service.
 					EXPECT().
-					SaveNewGoodReward(ctx, body.Match, body.Reward, body.RewardType).
+					SaveNewGoodReward(ctx, domain.GoodReward{
+						MerchantID: 1,
+						Match:      body.Match,
+						MatchType:  body.MatchType,
+						Reward:     body.Reward,
+						RewardType: body.RewardType,
+					}).
 					Return(&domain.GoodReward{Match: body.Match, Reward: body.Reward, RewardType: body.RewardType}, nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
+		{
+			Name: "valid (tiered order rule without match)",
+			Body: &saveNewGoodRewardBody{
+				RewardType: domain.TieredPercentRewardType,
+				Tiers: []domain.RewardTier{
+					{Threshold: domain.Money{}, Reward: domain.MoneyFromInt(3)},
+					{Threshold: domain.MoneyFromInt(5000), Reward: domain.MoneyFromInt(5)},
+				},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *saveNewGoodRewardBody) {
+				service.
+					EXPECT().
+					SaveNewGoodReward(ctx, domain.GoodReward{
+						MerchantID: 1,
+						RewardType: body.RewardType,
+						Tiers:      body.Tiers,
+					}).
+					Return(&domain.GoodReward{RewardType: body.RewardType, Tiers: body.Tiers}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "invalid (tiered rule with a reward)",
+			Body: &saveNewGoodRewardBody{
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.TieredPointRewardType,
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (per-good rule without match)",
+			Body: &saveNewGoodRewardBody{
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PointRewardType,
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			Name:               "invalid (invalid body)",
 			Body:               &saveNewGoodRewardBody{},
@@ -60,17 +108,79 @@ func TestGoodsHandler_SaveNewGoodReward(t *testing.T) {
 			Name: "invalid (match key already exists)",
 			Body: &saveNewGoodRewardBody{
 				Match:      "Bork",
-				Reward:     10.0,
+				Reward:     domain.MoneyFromInt(10),
 				RewardType: domain.PercentRewardType,
 			},
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *saveNewGoodRewardBody) {
 				service.
 					EXPECT().
-					SaveNewGoodReward(ctx, body.Match, body.Reward, body.RewardType).
+					SaveNewGoodReward(ctx, gomock.Any()).
 					Return(nil, domain.ErrMatchKeyAlreadyExists)
 			},
 			ExpectedStatusCode: http.StatusConflict,
 		},
+		{
+			Name: "invalid (unknown match type)",
+			Body: &saveNewGoodRewardBody{
+				Match:      "Bork",
+				MatchType:  "fuzzy",
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (unknown stacking mode)",
+			Body: &saveNewGoodRewardBody{
+				Match:      "Bork",
+				Stacking:   "sometimes",
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (zero cap)",
+			Body: &saveNewGoodRewardBody{
+				Match:      "Bork",
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+				MaxPerGood: &domain.Money{},
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (unknown campaign)",
+			Body: &saveNewGoodRewardBody{
+				Match:      "Bork",
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+				CampaignID: &unknownCampaignID,
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *saveNewGoodRewardBody) {
+				service.
+					EXPECT().
+					SaveNewGoodReward(ctx, gomock.Any()).
+					Return(nil, domain.ErrUnknownCampaign)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (bad regex)",
+			Body: &saveNewGoodRewardBody{
+				Match:      "Bork(",
+				MatchType:  domain.RegexMatchType,
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *saveNewGoodRewardBody) {
+				service.
+					EXPECT().
+					SaveNewGoodReward(ctx, gomock.Any()).
+					Return(nil, domain.ErrInvalidMatch)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -85,6 +195,7 @@ func TestGoodsHandler_SaveNewGoodReward(t *testing.T) {
 
 			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
 			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
 			w := httptest.NewRecorder()
 
 			if testCase.PrepareServiceFunc != nil {
@@ -100,3 +211,611 @@ func TestGoodsHandler_SaveNewGoodReward(t *testing.T) {
 		})
 	}
 }
+
+func newMerchantRequest(t *testing.T, method string, target string, body any, id string) *http.Request {
+	t.Helper()
+
+	var rawBody []byte
+
+	if body != nil {
+		var err error
+
+		rawBody, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	r := httptest.NewRequest(method, target, bytes.NewReader(rawBody))
+	r.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	return r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
+}
+
+func TestGoodsHandler_GetGoodRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		Query              string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "valid",
+			Query: "?search=bork&status=ACTIVE",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					GetGoodRewardsPage(ctx, 1, domain.GoodRewardsFilter{
+						HistoryFilter: domain.HistoryFilter{Status: domain.ActiveGoodRewardStatus},
+						Search:        "bork",
+					}).
+					Return(&domain.GoodRewardsPage{Items: []domain.GoodReward{{ID: 1, Match: "Bork"}}}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "valid (no rules)",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					GetGoodRewardsPage(ctx, 1, domain.GoodRewardsFilter{}).
+					Return(&domain.GoodRewardsPage{Items: []domain.GoodReward{}}, nil)
+			},
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "invalid (bad cursor)",
+			Query:              "?cursor=@@",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:  "invalid (unknown status)",
+			Query: "?status=DELETED",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					GetGoodRewardsPage(ctx, 1, domain.GoodRewardsFilter{HistoryFilter: domain.HistoryFilter{Status: "DELETED"}}).
+					Return(nil, domain.ErrInvalidHistoryFilter)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodGet, "/"+testCase.Query, nil, "")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.GetGoodRewards(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_GetGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		ID                 string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			ID:   "5",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().GetGoodReward(ctx, 1, 5).Return(&domain.GoodReward{ID: 5}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "invalid (not found)",
+			ID:   "6",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().GetGoodReward(ctx, 1, 6).Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "invalid (bad id)",
+			ID:                 "five",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodGet, "/", nil, testCase.ID)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.GetGoodReward(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_ReplaceGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		Body               *replaceGoodRewardBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *replaceGoodRewardBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &replaceGoodRewardBody{
+				saveNewGoodRewardBody: saveNewGoodRewardBody{
+					Match:      "Bork",
+					MatchType:  domain.WordMatchType,
+					Reward:     domain.MoneyFromInt(15),
+					RewardType: domain.PercentRewardType,
+					Priority:   3,
+				},
+				Disabled: true,
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *replaceGoodRewardBody) {
+				stacking := domain.StackableStackingMode
+				matchField := domain.DescriptionMatchField
+				noCap := domain.Money{}
+				noCampaign := 0
+				noTime := time.Time{}
+
+				service.
+					EXPECT().
+					UpdateGoodReward(ctx, 1, 5, domain.GoodRewardUpdate{
+						Match:       &body.Match,
+						MatchType:   &body.MatchType,
+						MatchField:  &matchField,
+						IgnoreCase:  &body.IgnoreCase,
+						Reward:      &body.Reward,
+						RewardType:  &body.RewardType,
+						Priority:    &body.Priority,
+						Stacking:    &stacking,
+						MaxPerGood:  &noCap,
+						MaxPerOrder: &noCap,
+						CampaignID:  &noCampaign,
+						ValidFrom:   &noTime,
+						ValidTo:     &noTime,
+						Schedule:    &domain.Schedule{},
+						MinBasket:   &noCap,
+						Tiers:       &[]domain.RewardTier{},
+						Disabled:    &body.Disabled,
+					}).
+					Return(&domain.GoodReward{ID: 5, Match: body.Match}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			Body:               &replaceGoodRewardBody{saveNewGoodRewardBody: saveNewGoodRewardBody{Match: "Bork"}},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (match key already exists)",
+			Body: &replaceGoodRewardBody{
+				saveNewGoodRewardBody: saveNewGoodRewardBody{
+					Match:      "Bork",
+					Reward:     domain.MoneyFromInt(15),
+					RewardType: domain.PercentRewardType,
+				},
+			},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService, body *replaceGoodRewardBody) {
+				service.
+					EXPECT().
+					UpdateGoodReward(ctx, 1, 5, gomock.Any()).
+					Return(nil, domain.ErrMatchKeyAlreadyExists)
+			},
+			ExpectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodPut, "/", testCase.Body, "5")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService, testCase.Body)
+			}
+
+			goodsHandler.ReplaceGoodReward(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_UpdateGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	disabled := true
+	emptyMatch := ""
+	negativeReward := domain.MoneyFromInt(-1)
+
+	type TestCase struct {
+		Name               string
+		Body               *updateGoodRewardBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid (disable)",
+			Body: &updateGoodRewardBody{Disabled: &disabled},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					UpdateGoodReward(ctx, 1, 5, domain.GoodRewardUpdate{Disabled: &disabled}).
+					Return(&domain.GoodReward{ID: 5, Disabled: true}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (empty body)",
+			Body:               &updateGoodRewardBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (empty match of a per-good rule)",
+			Body: &updateGoodRewardBody{Match: &emptyMatch},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					UpdateGoodReward(ctx, 1, 5, domain.GoodRewardUpdate{Match: &emptyMatch}).
+					Return(nil, domain.ErrInvalidMatch)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (negative reward)",
+			Body:               &updateGoodRewardBody{Reward: &negativeReward},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (not found)",
+			Body: &updateGoodRewardBody{Disabled: &disabled},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					UpdateGoodReward(ctx, 1, 5, domain.GoodRewardUpdate{Disabled: &disabled}).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodPatch, "/", testCase.Body, "5")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.UpdateGoodReward(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_DeleteGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		ID                 string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			ID:   "5",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().DeleteGoodReward(ctx, 1, 5).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name: "invalid (already deleted)",
+			ID:   "5",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().DeleteGoodReward(ctx, 1, 5).Return(domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodDelete, "/", nil, testCase.ID)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.DeleteGoodReward(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_DiffGoodRewardVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		Query              string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "valid",
+			Query: "?from=1&to=3",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					DiffGoodRewardVersions(ctx, 1, 5, 1, 3).
+					Return(&domain.GoodRewardDiff{RuleID: 5, Changes: []domain.GoodRewardChange{}}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:  "invalid (unknown version)",
+			Query: "?from=1&to=9",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().DiffGoodRewardVersions(ctx, 1, 5, 1, 9).Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "invalid (no versions)",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (bad version)",
+			Query:              "?from=0&to=two",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodGet, "/"+testCase.Query, nil, "5")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.DiffGoodRewardVersions(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_ImportGoodRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	type TestCase struct {
+		Name               string
+		Query              string
+		ContentType        string
+		Body               string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:        "valid (csv)",
+			ContentType: "text/csv",
+			Body:        "match,reward,reward_type\nBork,5,%\n",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					ImportGoodRewards(ctx, 1, []domain.ImportedGoodReward{{
+						Row: 2,
+						Reward: domain.GoodReward{
+							Match: "Bork", Reward: domain.MoneyFromInt(5), RewardType: domain.PercentRewardType,
+						},
+					}}, false).
+					Return(&domain.GoodRewardImportReport{Imported: true, Created: 1}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:        "valid (json dry run)",
+			Query:       "?format=json&dry_run=true",
+			ContentType: "application/octet-stream",
+			Body:        `[{"match": "Bork", "reward": 5, "reward_type": "%"}]`,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					ImportGoodRewards(ctx, 1, gomock.Any(), true).
+					Return(&domain.GoodRewardImportReport{Created: 1}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:        "invalid (failed rows)",
+			ContentType: "text/csv",
+			Body:        "match,reward,reward_type\nBork,five,%\n",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.
+					EXPECT().
+					ImportGoodRewards(ctx, 1, gomock.Any(), false).
+					Return(&domain.GoodRewardImportReport{Failed: 1}, nil)
+			},
+			ExpectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			Name:               "invalid (unknown column)",
+			ContentType:        "text/csv",
+			Body:               "match,points\nBork,5\n",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (unknown format)",
+			ContentType:        "text/plain",
+			Body:               "Bork 5%",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (bad dry_run)",
+			Query:              "?dry_run=maybe",
+			ContentType:        "text/csv",
+			Body:               "match,reward,reward_type\nBork,5,%\n",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/"+testCase.Query, bytes.NewBufferString(testCase.Body))
+			r.Header.Set("Content-Type", testCase.ContentType)
+			r = r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), 1))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.ImportGoodRewards(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGoodsHandler_ExportGoodRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	goodsRewardsService := servicemock.NewMockgoodRewardsService(ctrl)
+	goodsHandler := NewGoodsHandler(goodsRewardsService)
+
+	rewards := []domain.GoodReward{{
+		ID: 1, MerchantID: 1, Match: "Bork", MatchField: domain.DescriptionMatchField,
+		MatchType: domain.SubstringMatchType, Reward: domain.MoneyFromInt(5), RewardType: domain.PercentRewardType,
+		Stacking: domain.StackableStackingMode,
+	}}
+
+	type TestCase struct {
+		Name                string
+		Query               string
+		PrepareServiceFunc  func(ctx context.Context, service *servicemock.MockgoodRewardsService)
+		ExpectedStatusCode  int
+		ExpectedContentType string
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid (csv by default)",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().ExportGoodRewards(ctx, 1).Return(rewards, nil)
+			},
+			ExpectedStatusCode:  http.StatusOK,
+			ExpectedContentType: "text/csv; charset=utf-8",
+		},
+		{
+			Name:  "valid (json)",
+			Query: "?format=json",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockgoodRewardsService) {
+				service.EXPECT().ExportGoodRewards(ctx, 1).Return(rewards, nil)
+			},
+			ExpectedStatusCode:  http.StatusOK,
+			ExpectedContentType: "application/json",
+		},
+		{
+			Name:                "invalid (unknown format)",
+			Query:               "?format=xlsx",
+			ExpectedStatusCode:  http.StatusBadRequest,
+			ExpectedContentType: "application/json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := newMerchantRequest(t, http.MethodGet, "/"+testCase.Query, nil, "")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), goodsRewardsService)
+			}
+
+			goodsHandler.ExportGoodRewards(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+			assert.Equal(t, testCase.ExpectedContentType, res.Header.Get("Content-Type"))
+		})
+	}
+}
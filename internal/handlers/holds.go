@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
+)
+
+type holdsService interface {
+	HoldBalance(ctx context.Context, userID int, orderID string, amount domain.Money) (*domain.BalanceAction, error)
+	CaptureHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error)
+	ReleaseHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error)
+}
+
+type HoldsHandler struct {
+	service holdsService
+}
+
+func NewHoldsHandler(service holdsService) *HoldsHandler {
+	return &HoldsHandler{
+		service: service,
+	}
+}
+
+type holdBalanceBody struct {
+	Order string       `json:"order"`
+	Sum   domain.Money `json:"sum" swaggertype:"number"`
+}
+
+func (b *holdBalanceBody) Valid() bool {
+	if !b.Sum.IsPositive() || len(b.Order) == 0 {
+		return false
+	}
+
+	return true
+}
+
+type holdForResponse struct {
+	ID          int          `json:"id"`
+	Order       string       `json:"order"`
+	Sum         domain.Money `json:"sum" swaggertype:"number"`
+	Status      string       `json:"status"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+	ProcessedAt *time.Time   `json:"processed_at,omitempty"`
+}
+
+func newHoldForResponse(hold *domain.BalanceAction) holdForResponse {
+	return holdForResponse{
+		ID:          hold.ID,
+		Order:       hold.OrderID,
+		Sum:         hold.Amount.Abs(),
+		Status:      hold.Status,
+		ExpiresAt:   hold.ExpiresAt,
+		ProcessedAt: hold.ProcessedAt,
+	}
+}
+
+// HoldBalance godoc
+// @Summary Reserve points for an order
+// @Tags balance
+// @Accept json
+// @Produce json
+// @Param dto body holdBalanceBody true "Reserve points"
+// @Security BearerAuth
+// @Success 201 {object} holdForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 402 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/balance/holds [post]
+func (h *HoldsHandler) HoldBalance(w http.ResponseWriter, r *http.Request) {
+	var body holdBalanceBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	userID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	hold, err := h.service.HoldBalance(r.Context(), userID, body.Order, body.Sum)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInsufficientFunds) {
+			httputils.SendJSONErrorResponse(w, http.StatusPaymentRequired, err.Error())
+			return
+		}
+
+		log.Println("[HoldBalance]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, newHoldForResponse(hold))
+}
+
+// CaptureHold godoc
+// @Summary Capture reserved points
+// @Tags balance
+// @Produce json
+// @Param holdID path int true "Hold ID"
+// @Security BearerAuth
+// @Success 200 {object} holdForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 410 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/balance/holds/{holdID}/capture [post]
+func (h *HoldsHandler) CaptureHold(w http.ResponseWriter, r *http.Request) {
+	h.finishHold(w, r, "[CaptureHold]", h.service.CaptureHold)
+}
+
+// ReleaseHold godoc
+// @Summary Release reserved points back to the balance
+// @Tags balance
+// @Produce json
+// @Param holdID path int true "Hold ID"
+// @Security BearerAuth
+// @Success 200 {object} holdForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/balance/holds/{holdID}/release [post]
+func (h *HoldsHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	h.finishHold(w, r, "[ReleaseHold]", h.service.ReleaseHold)
+}
+
+func (h *HoldsHandler) finishHold(
+	w http.ResponseWriter,
+	r *http.Request,
+	logPrefix string,
+	action func(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error),
+) {
+	holdID, err := strconv.Atoi(chi.URLParam(r, "holdID"))
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid hold id")
+		return
+	}
+
+	userID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	hold, err := action(r.Context(), userID, holdID)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrHoldNotActive):
+			httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, domain.ErrHoldExpired):
+			httputils.SendJSONErrorResponse(w, http.StatusGone, err.Error())
+		default:
+			log.Println(logPrefix, err)
+			httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		}
+
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, newHoldForResponse(hold))
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func TestHoldsHandler_HoldBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	holdsServiceMock := servicemock.NewMockholdsService(ctrl)
+	holdsHandler := NewHoldsHandler(holdsServiceMock)
+
+	type TestCase struct {
+		Name               string
+		Body               *holdBalanceBody
+		UserID             int
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockholdsService, body *holdBalanceBody, userID int)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &holdBalanceBody{
+				Order: "12344",
+				Sum:   domain.MoneyFromInt(100),
+			},
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, body *holdBalanceBody, userID int) {
+				service.
+					EXPECT().
+					HoldBalance(ctx, userID, body.Order, body.Sum).
+					Return(&domain.BalanceAction{ID: 1, OrderID: body.Order, Amount: body.Sum.Neg(), Status: domain.HeldBalanceActionStatus}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			Body:               &holdBalanceBody{},
+			PrepareServiceFunc: nil,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (not enough balance)",
+			Body: &holdBalanceBody{
+				Order: "12344",
+				Sum:   domain.MoneyFromInt(100),
+			},
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, body *holdBalanceBody, userID int) {
+				service.
+					EXPECT().
+					HoldBalance(ctx, userID, body.Order, body.Sum).
+					Return(nil, domain.ErrInsufficientFunds)
+			},
+			ExpectedStatusCode: http.StatusPaymentRequired,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			ctx := contextutil.SetUserIDToContext(r.Context(), testCase.UserID)
+			r = r.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), holdsServiceMock, testCase.Body, testCase.UserID)
+			}
+
+			holdsHandler.HoldBalance(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestHoldsHandler_CaptureHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	holdsServiceMock := servicemock.NewMockholdsService(ctrl)
+	holdsHandler := NewHoldsHandler(holdsServiceMock)
+
+	type TestCase struct {
+		Name               string
+		HoldID             string
+		UserID             int
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockholdsService, userID int)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:   "valid",
+			HoldID: "10",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, userID int) {
+				service.
+					EXPECT().
+					CaptureHold(ctx, userID, 10).
+					Return(&domain.BalanceAction{ID: 10, Status: domain.ProcessedBalanceActionStatus}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (bad hold id)",
+			HoldID:             "abc",
+			UserID:             1,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "invalid (not found)",
+			HoldID: "11",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, userID int) {
+				service.
+					EXPECT().
+					CaptureHold(ctx, userID, 11).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:   "invalid (already released)",
+			HoldID: "12",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, userID int) {
+				service.
+					EXPECT().
+					CaptureHold(ctx, userID, 12).
+					Return(nil, domain.ErrHoldNotActive)
+			},
+			ExpectedStatusCode: http.StatusConflict,
+		},
+		{
+			Name:   "invalid (expired)",
+			HoldID: "13",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockholdsService, userID int) {
+				service.
+					EXPECT().
+					CaptureHold(ctx, userID, 13).
+					Return(nil, domain.ErrHoldExpired)
+			},
+			ExpectedStatusCode: http.StatusGone,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			ctx := contextutil.SetUserIDToContext(r.Context(), testCase.UserID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("holdID", testCase.HoldID)
+			r = r.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), holdsServiceMock, testCase.UserID)
+			}
+
+			holdsHandler.CaptureHold(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestHoldsHandler_ReleaseHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	holdsServiceMock := servicemock.NewMockholdsService(ctrl)
+	holdsHandler := NewHoldsHandler(holdsServiceMock)
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		ctx := contextutil.SetUserIDToContext(r.Context(), 1)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("holdID", "10")
+		r = r.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+
+		holdsServiceMock.
+			EXPECT().
+			ReleaseHold(r.Context(), 1, 10).
+			Return(&domain.BalanceAction{ID: 10, Status: domain.ReleasedBalanceActionStatus}, nil)
+
+		holdsHandler.ReleaseHold(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
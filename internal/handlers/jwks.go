@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+)
+
+type jwksProvider interface {
+	JWKS() jwt.JWKS
+}
+
+type JWKSHandler struct {
+	provider jwksProvider
+}
+
+func NewJWKSHandler(provider jwksProvider) *JWKSHandler {
+	return &JWKSHandler{
+		provider: provider,
+	}
+}
+
+// GetJWKS godoc
+// @Summary Get access token verification keys
+// @Description Public keys other services use to verify access tokens, matched by the kid header.
+// @Description Retired keys stay listed while tokens signed by them can still be valid.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /user/.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	httputils.SendJSONResponse(w, http.StatusOK, h.provider.JWKS())
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
+)
+
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := servicemock.NewMockjwksProvider(ctrl)
+	handler := NewJWKSHandler(provider)
+
+	t.Run("valid", func(t *testing.T) {
+		provider.
+			EXPECT().
+			JWKS().
+			Return(jwt.JWKS{Keys: []jwt.JWK{{KeyType: "OKP", KeyID: "kid", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "x"}}})
+
+		r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetJWKS(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var jwks jwt.JWKS
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&jwks))
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "kid", jwks.Keys[0].KeyID)
+	})
+
+	t.Run("no public keys", func(t *testing.T) {
+		provider.
+			EXPECT().
+			JWKS().
+			Return(jwt.JWKS{Keys: []jwt.JWK{}})
+
+		r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetJWKS(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var jwks jwt.JWKS
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&jwks))
+		assert.Empty(t, jwks.Keys)
+	})
+}
@@ -11,6 +11,7 @@ package servicemock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -39,32 +40,92 @@ func (m *MockaccrualOrdersService) EXPECT() *MockaccrualOrdersServiceMockRecorde
 	return m.recorder
 }
 
+// GetAdjustments mocks base method.
+func (m *MockaccrualOrdersService) GetAdjustments(ctx context.Context, merchantID *int, after, limit int) ([]domain.AccrualAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustments", ctx, merchantID, after, limit)
+	ret0, _ := ret[0].([]domain.AccrualAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustments indicates an expected call of GetAdjustments.
+func (mr *MockaccrualOrdersServiceMockRecorder) GetAdjustments(ctx, merchantID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustments", reflect.TypeOf((*MockaccrualOrdersService)(nil).GetAdjustments), ctx, merchantID, after, limit)
+}
+
+// GetOrderBreakdown mocks base method.
+func (m *MockaccrualOrdersService) GetOrderBreakdown(ctx context.Context, merchantID int, orderID string) (*domain.AccrualBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderBreakdown", ctx, merchantID, orderID)
+	ret0, _ := ret[0].(*domain.AccrualBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderBreakdown indicates an expected call of GetOrderBreakdown.
+func (mr *MockaccrualOrdersServiceMockRecorder) GetOrderBreakdown(ctx, merchantID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderBreakdown", reflect.TypeOf((*MockaccrualOrdersService)(nil).GetOrderBreakdown), ctx, merchantID, orderID)
+}
+
 // GetOrderInfo mocks base method.
-func (m *MockaccrualOrdersService) GetOrderInfo(ctx context.Context, orderID string) (*domain.RegisteredOrder, error) {
+func (m *MockaccrualOrdersService) GetOrderInfo(ctx context.Context, merchantID int, orderID string) (*domain.RegisteredOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderInfo", ctx, orderID)
+	ret := m.ctrl.Call(m, "GetOrderInfo", ctx, merchantID, orderID)
 	ret0, _ := ret[0].(*domain.RegisteredOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrderInfo indicates an expected call of GetOrderInfo.
-func (mr *MockaccrualOrdersServiceMockRecorder) GetOrderInfo(ctx, orderID any) *gomock.Call {
+func (mr *MockaccrualOrdersServiceMockRecorder) GetOrderInfo(ctx, merchantID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderInfo", reflect.TypeOf((*MockaccrualOrdersService)(nil).GetOrderInfo), ctx, merchantID, orderID)
+}
+
+// QuoteOrder mocks base method.
+func (m *MockaccrualOrdersService) QuoteOrder(ctx context.Context, merchantID int, at time.Time, goods []domain.OrderGood) (*domain.AccrualBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteOrder", ctx, merchantID, at, goods)
+	ret0, _ := ret[0].(*domain.AccrualBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteOrder indicates an expected call of QuoteOrder.
+func (mr *MockaccrualOrdersServiceMockRecorder) QuoteOrder(ctx, merchantID, at, goods any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteOrder", reflect.TypeOf((*MockaccrualOrdersService)(nil).QuoteOrder), ctx, merchantID, at, goods)
+}
+
+// RecalculateOrders mocks base method.
+func (m *MockaccrualOrdersService) RecalculateOrders(ctx context.Context, merchantID int, recalculation domain.Recalculation) (*domain.RecalculationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateOrders", ctx, merchantID, recalculation)
+	ret0, _ := ret[0].(*domain.RecalculationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecalculateOrders indicates an expected call of RecalculateOrders.
+func (mr *MockaccrualOrdersServiceMockRecorder) RecalculateOrders(ctx, merchantID, recalculation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderInfo", reflect.TypeOf((*MockaccrualOrdersService)(nil).GetOrderInfo), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateOrders", reflect.TypeOf((*MockaccrualOrdersService)(nil).RecalculateOrders), ctx, merchantID, recalculation)
 }
 
 // RegisterOrder mocks base method.
-func (m *MockaccrualOrdersService) RegisterOrder(ctx context.Context, orderID string, goods []domain.OrderGood) (*domain.RegisteredOrder, error) {
+func (m *MockaccrualOrdersService) RegisterOrder(ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood) (*domain.RegisteredOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterOrder", ctx, orderID, goods)
+	ret := m.ctrl.Call(m, "RegisterOrder", ctx, merchantID, orderID, purchasedAt, goods)
 	ret0, _ := ret[0].(*domain.RegisteredOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterOrder indicates an expected call of RegisterOrder.
-func (mr *MockaccrualOrdersServiceMockRecorder) RegisterOrder(ctx, orderID, goods any) *gomock.Call {
+func (mr *MockaccrualOrdersServiceMockRecorder) RegisterOrder(ctx, merchantID, orderID, purchasedAt, goods any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockaccrualOrdersService)(nil).RegisterOrder), ctx, orderID, goods)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockaccrualOrdersService)(nil).RegisterOrder), ctx, merchantID, orderID, purchasedAt, goods)
 }
@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin.go -destination=./mocks/admin.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockadminService is a mock of adminService interface.
+type MockadminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockadminServiceMockRecorder
+}
+
+// MockadminServiceMockRecorder is the mock recorder for MockadminService.
+type MockadminServiceMockRecorder struct {
+	mock *MockadminService
+}
+
+// NewMockadminService creates a new mock instance.
+func NewMockadminService(ctrl *gomock.Controller) *MockadminService {
+	mock := &MockadminService{ctrl: ctrl}
+	mock.recorder = &MockadminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockadminService) EXPECT() *MockadminServiceMockRecorder {
+	return m.recorder
+}
+
+// AdjustBalance mocks base method.
+func (m *MockadminService) AdjustBalance(ctx context.Context, actorID, userID int, amount domain.Money, reason string) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBalance", ctx, actorID, userID, amount, reason)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustBalance indicates an expected call of AdjustBalance.
+func (mr *MockadminServiceMockRecorder) AdjustBalance(ctx, actorID, userID, amount, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBalance", reflect.TypeOf((*MockadminService)(nil).AdjustBalance), ctx, actorID, userID, amount, reason)
+}
+
+// ClearLoginLockout mocks base method.
+func (m *MockadminService) ClearLoginLockout(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLoginLockout", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLoginLockout indicates an expected call of ClearLoginLockout.
+func (mr *MockadminServiceMockRecorder) ClearLoginLockout(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLoginLockout", reflect.TypeOf((*MockadminService)(nil).ClearLoginLockout), ctx, userID)
+}
+
+// FindUserByLogin mocks base method.
+func (m *MockadminService) FindUserByLogin(ctx context.Context, login string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByLogin", ctx, login)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByLogin indicates an expected call of FindUserByLogin.
+func (mr *MockadminServiceMockRecorder) FindUserByLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByLogin", reflect.TypeOf((*MockadminService)(nil).FindUserByLogin), ctx, login)
+}
+
+// GetUser mocks base method.
+func (m *MockadminService) GetUser(ctx context.Context, userID int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockadminServiceMockRecorder) GetUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockadminService)(nil).GetUser), ctx, userID)
+}
+
+// GetUserBalance mocks base method.
+func (m *MockadminService) GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockadminServiceMockRecorder) GetUserBalance(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockadminService)(nil).GetUserBalance), ctx, userID)
+}
+
+// LockUser mocks base method.
+func (m *MockadminService) LockUser(ctx context.Context, actorRole string, userID int, reason string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, actorRole, userID, reason)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockadminServiceMockRecorder) LockUser(ctx, actorRole, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockadminService)(nil).LockUser), ctx, actorRole, userID, reason)
+}
+
+// RequeueOrder mocks base method.
+func (m *MockadminService) RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOrder", ctx, orderID)
+	ret0, _ := ret[0].(*domain.UserOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueOrder indicates an expected call of RequeueOrder.
+func (mr *MockadminServiceMockRecorder) RequeueOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOrder", reflect.TypeOf((*MockadminService)(nil).RequeueOrder), ctx, orderID)
+}
+
+// SetUserRole mocks base method.
+func (m *MockadminService) SetUserRole(ctx context.Context, userID int, role string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockadminServiceMockRecorder) SetUserRole(ctx, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockadminService)(nil).SetUserRole), ctx, userID, role)
+}
+
+// UnlockUser mocks base method.
+func (m *MockadminService) UnlockUser(ctx context.Context, actorRole string, userID int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, actorRole, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockadminServiceMockRecorder) UnlockUser(ctx, actorRole, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockadminService)(nil).UnlockUser), ctx, actorRole, userID)
+}
@@ -68,3 +68,135 @@ func (mr *MockuserServiceForAuthMockRecorder) Register(ctx, login, password any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockuserServiceForAuth)(nil).Register), ctx, login, password)
 }
+
+// MocksessionServiceForAuth is a mock of sessionServiceForAuth interface.
+type MocksessionServiceForAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionServiceForAuthMockRecorder
+}
+
+// MocksessionServiceForAuthMockRecorder is the mock recorder for MocksessionServiceForAuth.
+type MocksessionServiceForAuthMockRecorder struct {
+	mock *MocksessionServiceForAuth
+}
+
+// NewMocksessionServiceForAuth creates a new mock instance.
+func NewMocksessionServiceForAuth(ctrl *gomock.Controller) *MocksessionServiceForAuth {
+	mock := &MocksessionServiceForAuth{ctrl: ctrl}
+	mock.recorder = &MocksessionServiceForAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionServiceForAuth) EXPECT() *MocksessionServiceForAuthMockRecorder {
+	return m.recorder
+}
+
+// Logout mocks base method.
+func (m *MocksessionServiceForAuth) Logout(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MocksessionServiceForAuthMockRecorder) Logout(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MocksessionServiceForAuth)(nil).Logout), ctx, sessionID)
+}
+
+// Refresh mocks base method.
+func (m *MocksessionServiceForAuth) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MocksessionServiceForAuthMockRecorder) Refresh(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MocksessionServiceForAuth)(nil).Refresh), ctx, refreshToken)
+}
+
+// StartSession mocks base method.
+func (m *MocksessionServiceForAuth) StartSession(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, user)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MocksessionServiceForAuthMockRecorder) StartSession(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MocksessionServiceForAuth)(nil).StartSession), ctx, user)
+}
+
+// MockloginThrottleForAuth is a mock of loginThrottleForAuth interface.
+type MockloginThrottleForAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginThrottleForAuthMockRecorder
+}
+
+// MockloginThrottleForAuthMockRecorder is the mock recorder for MockloginThrottleForAuth.
+type MockloginThrottleForAuthMockRecorder struct {
+	mock *MockloginThrottleForAuth
+}
+
+// NewMockloginThrottleForAuth creates a new mock instance.
+func NewMockloginThrottleForAuth(ctrl *gomock.Controller) *MockloginThrottleForAuth {
+	mock := &MockloginThrottleForAuth{ctrl: ctrl}
+	mock.recorder = &MockloginThrottleForAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginThrottleForAuth) EXPECT() *MockloginThrottleForAuthMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockloginThrottleForAuth) Check(ctx context.Context, login, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, login, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockloginThrottleForAuthMockRecorder) Check(ctx, login, clientIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockloginThrottleForAuth)(nil).Check), ctx, login, clientIP)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockloginThrottleForAuth) RegisterFailure(ctx context.Context, login, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", ctx, login, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockloginThrottleForAuthMockRecorder) RegisterFailure(ctx, login, clientIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockloginThrottleForAuth)(nil).RegisterFailure), ctx, login, clientIP)
+}
+
+// RegisterSuccess mocks base method.
+func (m *MockloginThrottleForAuth) RegisterSuccess(ctx context.Context, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSuccess", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterSuccess indicates an expected call of RegisterSuccess.
+func (mr *MockloginThrottleForAuthMockRecorder) RegisterSuccess(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSuccess", reflect.TypeOf((*MockloginThrottleForAuth)(nil).RegisterSuccess), ctx, login)
+}
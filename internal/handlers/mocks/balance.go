@@ -92,8 +92,23 @@ func (mr *MockwithdrawalServiceForBalanceMockRecorder) GetWithdrawalsHistory(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalsHistory", reflect.TypeOf((*MockwithdrawalServiceForBalance)(nil).GetWithdrawalsHistory), ctx, userID)
 }
 
+// GetWithdrawalsHistoryPage mocks base method.
+func (m *MockwithdrawalServiceForBalance) GetWithdrawalsHistoryPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.BalanceActionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalsHistoryPage", ctx, userID, filter)
+	ret0, _ := ret[0].(*domain.BalanceActionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalsHistoryPage indicates an expected call of GetWithdrawalsHistoryPage.
+func (mr *MockwithdrawalServiceForBalanceMockRecorder) GetWithdrawalsHistoryPage(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalsHistoryPage", reflect.TypeOf((*MockwithdrawalServiceForBalance)(nil).GetWithdrawalsHistoryPage), ctx, userID, filter)
+}
+
 // WithdrawBalance mocks base method.
-func (m *MockwithdrawalServiceForBalance) WithdrawBalance(ctx context.Context, userID int, orderID string, amount float64) error {
+func (m *MockwithdrawalServiceForBalance) WithdrawBalance(ctx context.Context, userID int, orderID string, amount domain.Money) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WithdrawBalance", ctx, userID, orderID, amount)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: campaigns.go
+//
+// Generated by this command:
+//
+//	mockgen -source=campaigns.go -destination=./mocks/campaigns.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcampaignsService is a mock of campaignsService interface.
+type MockcampaignsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcampaignsServiceMockRecorder
+}
+
+// MockcampaignsServiceMockRecorder is the mock recorder for MockcampaignsService.
+type MockcampaignsServiceMockRecorder struct {
+	mock *MockcampaignsService
+}
+
+// NewMockcampaignsService creates a new mock instance.
+func NewMockcampaignsService(ctrl *gomock.Controller) *MockcampaignsService {
+	mock := &MockcampaignsService{ctrl: ctrl}
+	mock.recorder = &MockcampaignsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcampaignsService) EXPECT() *MockcampaignsServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCampaign mocks base method.
+func (m *MockcampaignsService) CreateCampaign(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockcampaignsServiceMockRecorder) CreateCampaign(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockcampaignsService)(nil).CreateCampaign), ctx, campaign)
+}
+
+// GetCampaign mocks base method.
+func (m *MockcampaignsService) GetCampaign(ctx context.Context, merchantID, id int) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, merchantID, id)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockcampaignsServiceMockRecorder) GetCampaign(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockcampaignsService)(nil).GetCampaign), ctx, merchantID, id)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockcampaignsService) GetCampaigns(ctx context.Context, merchantID int) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx, merchantID)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockcampaignsServiceMockRecorder) GetCampaigns(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockcampaignsService)(nil).GetCampaigns), ctx, merchantID)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockcampaignsService) UpdateCampaign(ctx context.Context, merchantID, id int, update domain.CampaignUpdate) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, merchantID, id, update)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockcampaignsServiceMockRecorder) UpdateCampaign(ctx, merchantID, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockcampaignsService)(nil).UpdateCampaign), ctx, merchantID, id, update)
+}
@@ -39,17 +39,136 @@ func (m *MockgoodRewardsService) EXPECT() *MockgoodRewardsServiceMockRecorder {
 	return m.recorder
 }
 
+// DeleteGoodReward mocks base method.
+func (m *MockgoodRewardsService) DeleteGoodReward(ctx context.Context, merchantID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodReward", ctx, merchantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoodReward indicates an expected call of DeleteGoodReward.
+func (mr *MockgoodRewardsServiceMockRecorder) DeleteGoodReward(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodReward", reflect.TypeOf((*MockgoodRewardsService)(nil).DeleteGoodReward), ctx, merchantID, id)
+}
+
+// DiffGoodRewardVersions mocks base method.
+func (m *MockgoodRewardsService) DiffGoodRewardVersions(ctx context.Context, merchantID, id, from, to int) (*domain.GoodRewardDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffGoodRewardVersions", ctx, merchantID, id, from, to)
+	ret0, _ := ret[0].(*domain.GoodRewardDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffGoodRewardVersions indicates an expected call of DiffGoodRewardVersions.
+func (mr *MockgoodRewardsServiceMockRecorder) DiffGoodRewardVersions(ctx, merchantID, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffGoodRewardVersions", reflect.TypeOf((*MockgoodRewardsService)(nil).DiffGoodRewardVersions), ctx, merchantID, id, from, to)
+}
+
+// ExportGoodRewards mocks base method.
+func (m *MockgoodRewardsService) ExportGoodRewards(ctx context.Context, merchantID int) ([]domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportGoodRewards", ctx, merchantID)
+	ret0, _ := ret[0].([]domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGoodRewards indicates an expected call of ExportGoodRewards.
+func (mr *MockgoodRewardsServiceMockRecorder) ExportGoodRewards(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGoodRewards", reflect.TypeOf((*MockgoodRewardsService)(nil).ExportGoodRewards), ctx, merchantID)
+}
+
+// GetGoodReward mocks base method.
+func (m *MockgoodRewardsService) GetGoodReward(ctx context.Context, merchantID, id int) (*domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodReward", ctx, merchantID, id)
+	ret0, _ := ret[0].(*domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodReward indicates an expected call of GetGoodReward.
+func (mr *MockgoodRewardsServiceMockRecorder) GetGoodReward(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodReward", reflect.TypeOf((*MockgoodRewardsService)(nil).GetGoodReward), ctx, merchantID, id)
+}
+
+// GetGoodRewardVersions mocks base method.
+func (m *MockgoodRewardsService) GetGoodRewardVersions(ctx context.Context, merchantID, id int) ([]domain.GoodRewardVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodRewardVersions", ctx, merchantID, id)
+	ret0, _ := ret[0].([]domain.GoodRewardVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodRewardVersions indicates an expected call of GetGoodRewardVersions.
+func (mr *MockgoodRewardsServiceMockRecorder) GetGoodRewardVersions(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodRewardVersions", reflect.TypeOf((*MockgoodRewardsService)(nil).GetGoodRewardVersions), ctx, merchantID, id)
+}
+
+// GetGoodRewardsPage mocks base method.
+func (m *MockgoodRewardsService) GetGoodRewardsPage(ctx context.Context, merchantID int, filter domain.GoodRewardsFilter) (*domain.GoodRewardsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodRewardsPage", ctx, merchantID, filter)
+	ret0, _ := ret[0].(*domain.GoodRewardsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodRewardsPage indicates an expected call of GetGoodRewardsPage.
+func (mr *MockgoodRewardsServiceMockRecorder) GetGoodRewardsPage(ctx, merchantID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodRewardsPage", reflect.TypeOf((*MockgoodRewardsService)(nil).GetGoodRewardsPage), ctx, merchantID, filter)
+}
+
+// ImportGoodRewards mocks base method.
+func (m *MockgoodRewardsService) ImportGoodRewards(ctx context.Context, merchantID int, rows []domain.ImportedGoodReward, dryRun bool) (*domain.GoodRewardImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGoodRewards", ctx, merchantID, rows, dryRun)
+	ret0, _ := ret[0].(*domain.GoodRewardImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGoodRewards indicates an expected call of ImportGoodRewards.
+func (mr *MockgoodRewardsServiceMockRecorder) ImportGoodRewards(ctx, merchantID, rows, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGoodRewards", reflect.TypeOf((*MockgoodRewardsService)(nil).ImportGoodRewards), ctx, merchantID, rows, dryRun)
+}
+
 // SaveNewGoodReward mocks base method.
-func (m *MockgoodRewardsService) SaveNewGoodReward(ctx context.Context, match string, reward float64, rewardType string) (*domain.GoodReward, error) {
+func (m *MockgoodRewardsService) SaveNewGoodReward(ctx context.Context, reward domain.GoodReward) (*domain.GoodReward, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveNewGoodReward", ctx, match, reward, rewardType)
+	ret := m.ctrl.Call(m, "SaveNewGoodReward", ctx, reward)
 	ret0, _ := ret[0].(*domain.GoodReward)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveNewGoodReward indicates an expected call of SaveNewGoodReward.
-func (mr *MockgoodRewardsServiceMockRecorder) SaveNewGoodReward(ctx, match, reward, rewardType any) *gomock.Call {
+func (mr *MockgoodRewardsServiceMockRecorder) SaveNewGoodReward(ctx, reward any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNewGoodReward", reflect.TypeOf((*MockgoodRewardsService)(nil).SaveNewGoodReward), ctx, reward)
+}
+
+// UpdateGoodReward mocks base method.
+func (m *MockgoodRewardsService) UpdateGoodReward(ctx context.Context, merchantID, id int, update domain.GoodRewardUpdate) (*domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoodReward", ctx, merchantID, id, update)
+	ret0, _ := ret[0].(*domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoodReward indicates an expected call of UpdateGoodReward.
+func (mr *MockgoodRewardsServiceMockRecorder) UpdateGoodReward(ctx, merchantID, id, update any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNewGoodReward", reflect.TypeOf((*MockgoodRewardsService)(nil).SaveNewGoodReward), ctx, match, reward, rewardType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoodReward", reflect.TypeOf((*MockgoodRewardsService)(nil).UpdateGoodReward), ctx, merchantID, id, update)
 }
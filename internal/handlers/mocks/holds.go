@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: holds.go
+//
+// Generated by this command:
+//
+//	mockgen -source=holds.go -destination=./mocks/holds.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockholdsService is a mock of holdsService interface.
+type MockholdsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockholdsServiceMockRecorder
+}
+
+// MockholdsServiceMockRecorder is the mock recorder for MockholdsService.
+type MockholdsServiceMockRecorder struct {
+	mock *MockholdsService
+}
+
+// NewMockholdsService creates a new mock instance.
+func NewMockholdsService(ctrl *gomock.Controller) *MockholdsService {
+	mock := &MockholdsService{ctrl: ctrl}
+	mock.recorder = &MockholdsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockholdsService) EXPECT() *MockholdsServiceMockRecorder {
+	return m.recorder
+}
+
+// CaptureHold mocks base method.
+func (m *MockholdsService) CaptureHold(ctx context.Context, userID, holdID int) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, userID, holdID)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockholdsServiceMockRecorder) CaptureHold(ctx, userID, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockholdsService)(nil).CaptureHold), ctx, userID, holdID)
+}
+
+// HoldBalance mocks base method.
+func (m *MockholdsService) HoldBalance(ctx context.Context, userID int, orderID string, amount domain.Money) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldBalance", ctx, userID, orderID, amount)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldBalance indicates an expected call of HoldBalance.
+func (mr *MockholdsServiceMockRecorder) HoldBalance(ctx, userID, orderID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldBalance", reflect.TypeOf((*MockholdsService)(nil).HoldBalance), ctx, userID, orderID, amount)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockholdsService) ReleaseHold(ctx context.Context, userID, holdID int) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, userID, holdID)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockholdsServiceMockRecorder) ReleaseHold(ctx, userID, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockholdsService)(nil).ReleaseHold), ctx, userID, holdID)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=jwks.go -destination=./mocks/jwks.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	reflect "reflect"
+
+	jwt "github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockjwksProvider is a mock of jwksProvider interface.
+type MockjwksProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockjwksProviderMockRecorder
+}
+
+// MockjwksProviderMockRecorder is the mock recorder for MockjwksProvider.
+type MockjwksProviderMockRecorder struct {
+	mock *MockjwksProvider
+}
+
+// NewMockjwksProvider creates a new mock instance.
+func NewMockjwksProvider(ctrl *gomock.Controller) *MockjwksProvider {
+	mock := &MockjwksProvider{ctrl: ctrl}
+	mock.recorder = &MockjwksProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockjwksProvider) EXPECT() *MockjwksProviderMockRecorder {
+	return m.recorder
+}
+
+// JWKS mocks base method.
+func (m *MockjwksProvider) JWKS() jwt.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(jwt.JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockjwksProviderMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockjwksProvider)(nil).JWKS))
+}
@@ -39,6 +39,21 @@ func (m *MockordersService) EXPECT() *MockordersServiceMockRecorder {
 	return m.recorder
 }
 
+// GetUserOrder mocks base method.
+func (m *MockordersService) GetUserOrder(ctx context.Context, userID int, orderID string) (*domain.UserOrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrder", ctx, userID, orderID)
+	ret0, _ := ret[0].(*domain.UserOrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrder indicates an expected call of GetUserOrder.
+func (mr *MockordersServiceMockRecorder) GetUserOrder(ctx, userID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrder", reflect.TypeOf((*MockordersService)(nil).GetUserOrder), ctx, userID, orderID)
+}
+
 // GetUserOrders mocks base method.
 func (m *MockordersService) GetUserOrders(ctx context.Context, userID int) ([]domain.UserOrder, error) {
 	m.ctrl.T.Helper()
@@ -54,17 +69,32 @@ func (mr *MockordersServiceMockRecorder) GetUserOrders(ctx, userID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*MockordersService)(nil).GetUserOrders), ctx, userID)
 }
 
+// GetUserOrdersPage mocks base method.
+func (m *MockordersService) GetUserOrdersPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.UserOrdersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrdersPage", ctx, userID, filter)
+	ret0, _ := ret[0].(*domain.UserOrdersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrdersPage indicates an expected call of GetUserOrdersPage.
+func (mr *MockordersServiceMockRecorder) GetUserOrdersPage(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrdersPage", reflect.TypeOf((*MockordersService)(nil).GetUserOrdersPage), ctx, userID, filter)
+}
+
 // RegisterOrder mocks base method.
-func (m *MockordersService) RegisterOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error) {
+func (m *MockordersService) RegisterOrder(ctx context.Context, orderID, merchant string, userID int) (*domain.UserOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterOrder", ctx, orderID, userID)
+	ret := m.ctrl.Call(m, "RegisterOrder", ctx, orderID, merchant, userID)
 	ret0, _ := ret[0].(*domain.UserOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterOrder indicates an expected call of RegisterOrder.
-func (mr *MockordersServiceMockRecorder) RegisterOrder(ctx, orderID, userID any) *gomock.Call {
+func (mr *MockordersServiceMockRecorder) RegisterOrder(ctx, orderID, merchant, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockordersService)(nil).RegisterOrder), ctx, orderID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockordersService)(nil).RegisterOrder), ctx, orderID, merchant, userID)
 }
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: passwords.go
+//
+// Generated by this command:
+//
+//	mockgen -source=passwords.go -destination=./mocks/passwords.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpasswordService is a mock of passwordService interface.
+type MockpasswordService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordServiceMockRecorder
+}
+
+// MockpasswordServiceMockRecorder is the mock recorder for MockpasswordService.
+type MockpasswordServiceMockRecorder struct {
+	mock *MockpasswordService
+}
+
+// NewMockpasswordService creates a new mock instance.
+func NewMockpasswordService(ctrl *gomock.Controller) *MockpasswordService {
+	mock := &MockpasswordService{ctrl: ctrl}
+	mock.recorder = &MockpasswordServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordService) EXPECT() *MockpasswordServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockpasswordService) ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentPassword, newPassword)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockpasswordServiceMockRecorder) ChangePassword(ctx, userID, currentPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockpasswordService)(nil).ChangePassword), ctx, userID, currentPassword, newPassword)
+}
+
+// IssueResetToken mocks base method.
+func (m *MockpasswordService) IssueResetToken(ctx context.Context, actorID, userID int) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueResetToken", ctx, actorID, userID)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueResetToken indicates an expected call of IssueResetToken.
+func (mr *MockpasswordServiceMockRecorder) IssueResetToken(ctx, actorID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueResetToken", reflect.TypeOf((*MockpasswordService)(nil).IssueResetToken), ctx, actorID, userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockpasswordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockpasswordServiceMockRecorder) ResetPassword(ctx, token, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockpasswordService)(nil).ResetPassword), ctx, token, newPassword)
+}
+
+// MocksessionStarter is a mock of sessionStarter interface.
+type MocksessionStarter struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionStarterMockRecorder
+}
+
+// MocksessionStarterMockRecorder is the mock recorder for MocksessionStarter.
+type MocksessionStarterMockRecorder struct {
+	mock *MocksessionStarter
+}
+
+// NewMocksessionStarter creates a new mock instance.
+func NewMocksessionStarter(ctrl *gomock.Controller) *MocksessionStarter {
+	mock := &MocksessionStarter{ctrl: ctrl}
+	mock.recorder = &MocksessionStarterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionStarter) EXPECT() *MocksessionStarterMockRecorder {
+	return m.recorder
+}
+
+// StartSession mocks base method.
+func (m *MocksessionStarter) StartSession(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, user)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MocksessionStarterMockRecorder) StartSession(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MocksessionStarter)(nil).StartSession), ctx, user)
+}
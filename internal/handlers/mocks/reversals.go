@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reversals.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reversals.go -destination=./mocks/reversals.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockreversalsService is a mock of reversalsService interface.
+type MockreversalsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockreversalsServiceMockRecorder
+}
+
+// MockreversalsServiceMockRecorder is the mock recorder for MockreversalsService.
+type MockreversalsServiceMockRecorder struct {
+	mock *MockreversalsService
+}
+
+// NewMockreversalsService creates a new mock instance.
+func NewMockreversalsService(ctrl *gomock.Controller) *MockreversalsService {
+	mock := &MockreversalsService{ctrl: ctrl}
+	mock.recorder = &MockreversalsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreversalsService) EXPECT() *MockreversalsServiceMockRecorder {
+	return m.recorder
+}
+
+// ClawbackOrderAccrual mocks base method.
+func (m *MockreversalsService) ClawbackOrderAccrual(ctx context.Context, orderID, reason string) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClawbackOrderAccrual", ctx, orderID, reason)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClawbackOrderAccrual indicates an expected call of ClawbackOrderAccrual.
+func (mr *MockreversalsServiceMockRecorder) ClawbackOrderAccrual(ctx, orderID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClawbackOrderAccrual", reflect.TypeOf((*MockreversalsService)(nil).ClawbackOrderAccrual), ctx, orderID, reason)
+}
+
+// RefundWithdrawal mocks base method.
+func (m *MockreversalsService) RefundWithdrawal(ctx context.Context, withdrawalID int, reason string) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundWithdrawal", ctx, withdrawalID, reason)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundWithdrawal indicates an expected call of RefundWithdrawal.
+func (mr *MockreversalsServiceMockRecorder) RefundWithdrawal(ctx, withdrawalID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundWithdrawal", reflect.TypeOf((*MockreversalsService)(nil).RefundWithdrawal), ctx, withdrawalID, reason)
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=statement.go -destination=./mocks/statement.go -package=servicemock
+//
+// Package servicemock is a generated GoMock package.
+package servicemock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockstatementService is a mock of statementService interface.
+type MockstatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatementServiceMockRecorder
+}
+
+// MockstatementServiceMockRecorder is the mock recorder for MockstatementService.
+type MockstatementServiceMockRecorder struct {
+	mock *MockstatementService
+}
+
+// NewMockstatementService creates a new mock instance.
+func NewMockstatementService(ctrl *gomock.Controller) *MockstatementService {
+	mock := &MockstatementService{ctrl: ctrl}
+	mock.recorder = &MockstatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatementService) EXPECT() *MockstatementServiceMockRecorder {
+	return m.recorder
+}
+
+// StreamStatement mocks base method.
+func (m *MockstatementService) StreamStatement(ctx context.Context, userID int, from, to *time.Time, fn func(domain.StatementLine) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStatement", ctx, userID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamStatement indicates an expected call of StreamStatement.
+func (mr *MockstatementServiceMockRecorder) StreamStatement(ctx, userID, from, to, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStatement", reflect.TypeOf((*MockstatementService)(nil).StreamStatement), ctx, userID, from, to, fn)
+}
+
+// MockstatementWriter is a mock of statementWriter interface.
+type MockstatementWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatementWriterMockRecorder
+}
+
+// MockstatementWriterMockRecorder is the mock recorder for MockstatementWriter.
+type MockstatementWriterMockRecorder struct {
+	mock *MockstatementWriter
+}
+
+// NewMockstatementWriter creates a new mock instance.
+func NewMockstatementWriter(ctrl *gomock.Controller) *MockstatementWriter {
+	mock := &MockstatementWriter{ctrl: ctrl}
+	mock.recorder = &MockstatementWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatementWriter) EXPECT() *MockstatementWriterMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockstatementWriter) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockstatementWriterMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockstatementWriter)(nil).Close))
+}
+
+// Started mocks base method.
+func (m *MockstatementWriter) Started() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Started")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Started indicates an expected call of Started.
+func (mr *MockstatementWriterMockRecorder) Started() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Started", reflect.TypeOf((*MockstatementWriter)(nil).Started))
+}
+
+// WriteLine mocks base method.
+func (m *MockstatementWriter) WriteLine(line domain.StatementLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteLine", line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteLine indicates an expected call of WriteLine.
+func (mr *MockstatementWriterMockRecorder) WriteLine(line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteLine", reflect.TypeOf((*MockstatementWriter)(nil).WriteLine), line)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"io"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/utils"
@@ -16,8 +19,10 @@ import (
 )
 
 type ordersService interface {
-	RegisterOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error)
+	RegisterOrder(ctx context.Context, orderID string, merchant string, userID int) (*domain.UserOrder, error)
 	GetUserOrders(ctx context.Context, userID int) ([]domain.UserOrder, error)
+	GetUserOrdersPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.UserOrdersPage, error)
+	GetUserOrder(ctx context.Context, userID int, orderID string) (*domain.UserOrderDetails, error)
 }
 
 type OrdersHandler struct {
@@ -32,6 +37,9 @@ func NewOrdersHandler(ordersService ordersService) *OrdersHandler {
 
 type registerOrderBody struct {
 	OrderID string `json:"order_id"`
+	// Merchant is the code of the merchant the order was made at, the default
+	// merchant when empty.
+	Merchant string `json:"merchant,omitempty"`
 }
 
 // RegisterOrder godoc
@@ -40,6 +48,7 @@ type registerOrderBody struct {
 // @Accept json
 // @Produce json
 // @Param dto body registerOrderBody true "Register order in system"
+// @Param merchant query string false "Merchant code for plain text bodies, the default merchant when empty"
 // @Security BearerAuth
 // @Success 200
 // @Success 201
@@ -48,9 +57,10 @@ type registerOrderBody struct {
 // @Failure 409 {object} httputils.HTTPError
 // @Failure 422 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /orders [post]
+// @Router /user/orders [post]
 func (h *OrdersHandler) RegisterOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := ""
+	merchant := r.URL.Query().Get("merchant")
 
 	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
 		var body registerOrderBody
@@ -61,6 +71,10 @@ func (h *OrdersHandler) RegisterOrder(w http.ResponseWriter, r *http.Request) {
 		}
 
 		orderID = body.OrderID
+
+		if body.Merchant != "" {
+			merchant = body.Merchant
+		}
 	} else if strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
 		body, err := io.ReadAll(r.Body)
 
@@ -84,7 +98,7 @@ func (h *OrdersHandler) RegisterOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.service.RegisterOrder(r.Context(), orderID, userID)
+	_, err = h.service.RegisterOrder(r.Context(), orderID, merchant, userID)
 
 	if err != nil {
 		if errors.Is(err, domain.ErrOrderRegisteredByYou) {
@@ -93,6 +107,9 @@ func (h *OrdersHandler) RegisterOrder(w http.ResponseWriter, r *http.Request) {
 		} else if errors.Is(err, domain.ErrOrderRegisteredByOther) {
 			httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
 			return
+		} else if errors.Is(err, domain.ErrInvalidMerchantCode) {
+			httputils.SendJSONErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
 		}
 
 		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
@@ -103,22 +120,57 @@ func (h *OrdersHandler) RegisterOrder(w http.ResponseWriter, r *http.Request) {
 }
 
 type orderForResponse struct {
-	Number     string    `json:"number"`
-	Status     string    `json:"status"`
-	Accrual    *float64  `json:"accrual,omitempty"`
-	UploadedAt time.Time `json:"uploaded_at"`
+	Number         string        `json:"number"`
+	Status         string        `json:"status"`
+	Accrual        *domain.Money `json:"accrual,omitempty" swaggertype:"number"`
+	Merchant       string        `json:"merchant"`
+	UploadedAt     time.Time     `json:"uploaded_at"`
+	ReversalReason *string       `json:"reversal_reason,omitempty"`
+	ReversedAt     *time.Time    `json:"reversed_at,omitempty"`
+}
+
+func newOrderForResponse(order domain.UserOrder) orderForResponse {
+	return orderForResponse{
+		Number:         order.OrderID,
+		Status:         order.Status,
+		Accrual:        order.Accrual,
+		Merchant:       order.Merchant,
+		UploadedAt:     order.UploadedAt,
+		ReversalReason: order.ReversalReason,
+		ReversedAt:     order.ReversedAt,
+	}
+}
+
+type orderDetailsForResponse struct {
+	orderForResponse
+	Breakdown *domain.AccrualBreakdown `json:"breakdown,omitempty"`
+}
+
+type ordersPageForResponse struct {
+	Items      []orderForResponse `json:"items"`
+	NextCursor *string            `json:"next_cursor"`
 }
 
 // GetOrders godoc
 // @Summary Get user registered orders
+// @Description Without query parameters the whole history is returned as a list.
+// @Description With any of them one page is returned as {"items": [...], "next_cursor": "..."},
+// @Description next_cursor is null on the last page.
 // @Tags orders
 // @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, 50 by default, 500 at most"
+// @Param status query string false "Order status" Enums(NEW, PROCESSING, INVALID, PROCESSED)
+// @Param from query string false "Uploaded at or after, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Uploaded before, RFC 3339 or YYYY-MM-DD (the whole day)"
+// @Param sort query string false "Sort direction by upload time, asc by default" Enums(asc, desc)
 // @Security BearerAuth
 // @Success 200 {array} orderForResponse
 // @Success 204
+// @Failure 400 {object} httputils.HTTPError
 // @Failure 401 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
-// @Router /orders [get]
+// @Router /user/orders [get]
 func (h *OrdersHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextutil.GetUserIDFromContext(r.Context())
 
@@ -127,6 +179,18 @@ func (h *OrdersHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filter, paged, err := parseHistoryFilter(r)
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if paged {
+		h.getOrdersPage(w, r, userID, filter)
+		return
+	}
+
 	orders, err := h.service.GetUserOrders(r.Context(), userID)
 
 	if err != nil {
@@ -142,13 +206,80 @@ func (h *OrdersHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 	responseOrders := make([]orderForResponse, 0)
 
 	for _, order := range orders {
-		responseOrders = append(responseOrders, orderForResponse{
-			Number:     order.OrderID,
-			Status:     order.Status,
-			Accrual:    order.Accrual,
-			UploadedAt: order.UploadedAt,
-		})
+		responseOrders = append(responseOrders, newOrderForResponse(order))
 	}
 
 	httputils.SendJSONResponse(w, http.StatusOK, responseOrders)
 }
+
+func (h *OrdersHandler) getOrdersPage(w http.ResponseWriter, r *http.Request, userID int, filter domain.HistoryFilter) {
+	page, err := h.service.GetUserOrdersPage(r.Context(), userID, filter)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHistoryFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[GetOrders]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	if len(page.Items) == 0 {
+		httputils.SendStatusCode(w, http.StatusNoContent)
+		return
+	}
+
+	response := ordersPageForResponse{
+		Items:      make([]orderForResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+
+	for _, order := range page.Items {
+		response.Items = append(response.Items, newOrderForResponse(order))
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// GetOrder godoc
+// @Summary Get user registered order
+// @Description breakdown explains the accrual of a processed order: what every reward rule,
+// @Description by id and version, gave for every good and for the whole order.
+// @Description It is omitted for orders the accrual system has not explained.
+// @Tags orders
+// @Produce json
+// @Param orderID path string true "Order number"
+// @Security BearerAuth
+// @Success 200 {object} orderDetailsForResponse
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/orders/{orderID} [get]
+func (h *OrdersHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	details, err := h.service.GetUserOrder(r.Context(), userID, chi.URLParam(r, "orderID"))
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, "order not found")
+			return
+		}
+
+		log.Println("[GetOrder]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusOK, orderDetailsForResponse{
+		orderForResponse: newOrderForResponse(details.UserOrder),
+		Breakdown:        details.Breakdown,
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -40,7 +41,7 @@ func TestOrdersHandler_RegisterOrder(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, body *registerOrderBody, userID int) {
 				service.
 					EXPECT().
-					RegisterOrder(ctx, body.OrderID, userID).
+					RegisterOrder(ctx, body.OrderID, body.Merchant, userID).
 					Return(&domain.UserOrder{OrderID: body.OrderID, UserID: userID}, nil)
 			},
 			ExpectedStatusCode: http.StatusAccepted,
@@ -68,7 +69,7 @@ func TestOrdersHandler_RegisterOrder(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, body *registerOrderBody, userID int) {
 				service.
 					EXPECT().
-					RegisterOrder(ctx, body.OrderID, userID).
+					RegisterOrder(ctx, body.OrderID, body.Merchant, userID).
 					Return(nil, domain.ErrOrderRegisteredByOther)
 			},
 			ExpectedStatusCode: http.StatusConflict,
@@ -82,11 +83,26 @@ func TestOrdersHandler_RegisterOrder(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, body *registerOrderBody, userID int) {
 				service.
 					EXPECT().
-					RegisterOrder(ctx, body.OrderID, userID).
+					RegisterOrder(ctx, body.OrderID, body.Merchant, userID).
 					Return(nil, domain.ErrOrderRegisteredByYou)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
+		{
+			Name: "invalid (merchant code)",
+			Body: &registerOrderBody{
+				OrderID:  "12344",
+				Merchant: "Shop!",
+			},
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, body *registerOrderBody, userID int) {
+				service.
+					EXPECT().
+					RegisterOrder(ctx, body.OrderID, body.Merchant, userID).
+					Return(nil, domain.ErrInvalidMerchantCode)
+			},
+			ExpectedStatusCode: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -126,6 +142,7 @@ func TestOrdersHandler_GetOrders(t *testing.T) {
 
 	type TestCase struct {
 		Name               string
+		Query              string
 		UserID             int
 		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockordersService, userID int)
 		ExpectedStatusCode int
@@ -154,11 +171,67 @@ func TestOrdersHandler_GetOrders(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusNoContent,
 		},
+		{
+			Name:   "valid (page)",
+			Query:  "?limit=1&status=PROCESSED&from=2026-10-01&sort=desc",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, userID int) {
+				nextCursor := "next"
+
+				service.
+					EXPECT().
+					GetUserOrdersPage(ctx, userID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.UserOrdersPage, error) {
+						assert.Equal(t, 1, filter.Limit)
+						assert.Equal(t, domain.ProcessedOrderStatus, filter.Status)
+						assert.Equal(t, "desc", filter.Sort)
+						assert.NotNil(t, filter.From)
+						return &domain.UserOrdersPage{Items: []domain.UserOrder{{UserID: userID}}, NextCursor: &nextCursor}, nil
+					})
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:   "valid (empty page)",
+			Query:  "?limit=10",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, userID int) {
+				service.
+					EXPECT().
+					GetUserOrdersPage(ctx, userID, gomock.Any()).
+					Return(&domain.UserOrdersPage{Items: []domain.UserOrder{}}, nil)
+			},
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "invalid (bad cursor)",
+			Query:              "?cursor=%25%25",
+			UserID:             1,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (bad limit)",
+			Query:              "?limit=-5",
+			UserID:             1,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "invalid (unknown status)",
+			Query:  "?status=LOST",
+			UserID: 1,
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, userID int) {
+				service.
+					EXPECT().
+					GetUserOrdersPage(ctx, userID, gomock.Any()).
+					Return(nil, domain.ErrInvalidHistoryFilter)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
-			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r := httptest.NewRequest(http.MethodGet, "/"+testCase.Query, nil)
 			r.Header.Set("Content-Type", "application/json")
 			ctx := contextutil.SetUserIDToContext(r.Context(), testCase.UserID)
 			r = r.WithContext(ctx)
@@ -177,3 +250,91 @@ func TestOrdersHandler_GetOrders(t *testing.T) {
 		})
 	}
 }
+
+func TestOrdersHandler_GetOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderServiceMock := servicemock.NewMockordersService(ctrl)
+	orderHandler := NewOrdersHandler(orderServiceMock)
+
+	type TestCase struct {
+		Name               string
+		OrderID            string
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockordersService, orderID string)
+		ExpectedStatusCode int
+		ExpectedBreakdown  bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name:    "valid",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, orderID string) {
+				service.
+					EXPECT().
+					GetUserOrder(ctx, 1, orderID).
+					Return(&domain.UserOrderDetails{
+						UserOrder: domain.UserOrder{OrderID: orderID, UserID: 1},
+						Breakdown: &domain.AccrualBreakdown{Accrual: domain.MoneyFromInt(10)},
+					}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedBreakdown:  true,
+		},
+		{
+			Name:    "valid (not explained yet)",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, orderID string) {
+				service.
+					EXPECT().
+					GetUserOrder(ctx, 1, orderID).
+					Return(&domain.UserOrderDetails{UserOrder: domain.UserOrder{OrderID: orderID, UserID: 1}}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:    "invalid (not found)",
+			OrderID: "1234",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockordersService, orderID string) {
+				service.
+					EXPECT().
+					GetUserOrder(ctx, 1, orderID).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("orderID", testCase.OrderID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(contextutil.SetUserIDToContext(r.Context(), 1))
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), orderServiceMock, testCase.OrderID)
+			}
+
+			orderHandler.GetOrder(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode != http.StatusOK {
+				return
+			}
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, testCase.OrderID, body["number"])
+
+			_, hasBreakdown := body["breakdown"]
+			assert.Equal(t, testCase.ExpectedBreakdown, hasBreakdown)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+var historyQueryParams = []string{"cursor", "limit", "status", "from", "to", "sort"}
+
+// parseHistoryFilter reads the pagination query parameters. paged is false when
+// none of them is present, so the handler can keep answering with the plain
+// list older clients expect.
+func parseHistoryFilter(r *http.Request) (filter domain.HistoryFilter, paged bool, err error) {
+	query := r.URL.Query()
+
+	for _, param := range historyQueryParams {
+		if query.Has(param) {
+			paged = true
+			break
+		}
+	}
+
+	if !paged {
+		return filter, false, nil
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		filter.Cursor, err = domain.DecodePageCursor(cursor)
+		if err != nil {
+			return filter, true, err
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit <= 0 {
+			return filter, true, domain.ErrInvalidHistoryFilter
+		}
+	}
+
+	if from := query.Get("from"); from != "" {
+		filter.From, err = parseHistoryTime(from, false)
+		if err != nil {
+			return filter, true, err
+		}
+	}
+
+	if to := query.Get("to"); to != "" {
+		filter.To, err = parseHistoryTime(to, true)
+		if err != nil {
+			return filter, true, err
+		}
+	}
+
+	filter.Status = query.Get("status")
+	filter.Sort = query.Get("sort")
+
+	return filter, true, nil
+}
+
+// parseHistoryTime accepts RFC 3339 timestamps and plain dates. A plain date
+// used as the end of a range covers that whole day.
+func parseHistoryTime(value string, endOfRange bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, domain.ErrInvalidHistoryFilter
+	}
+
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
+)
+
+type passwordService interface {
+	ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) (*domain.User, error)
+	IssueResetToken(ctx context.Context, actorID int, userID int) (*domain.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+}
+
+type sessionStarter interface {
+	StartSession(ctx context.Context, user *domain.User) (*domain.TokenPair, error)
+}
+
+type PasswordHandler struct {
+	service        passwordService
+	sessionStarter sessionStarter
+}
+
+func NewPasswordHandler(service passwordService, sessionStarter sessionStarter) *PasswordHandler {
+	return &PasswordHandler{
+		service:        service,
+		sessionStarter: sessionStarter,
+	}
+}
+
+type changePasswordBody struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func (b *changePasswordBody) Valid() bool {
+	return len(b.CurrentPassword) != 0 && len(b.NewPassword) != 0
+}
+
+// ChangePassword godoc
+// @Summary Change the password of the current user
+// @Description Every session of the user is revoked, the current one included. The response carries a fresh token pair.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body changePasswordBody true "Current and new password"
+// @Security BearerAuth
+// @Success 200 {object} loginResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/password [post]
+func (h *PasswordHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body changePasswordBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	user, err := h.service.ChangePassword(r.Context(), userID, body.CurrentPassword, body.NewPassword)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrWrongPassword):
+			httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, domain.ErrWeakPassword), errors.Is(err, domain.ErrPasswordNotChanged):
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Println("[ChangePassword]", err)
+			httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		}
+
+		return
+	}
+
+	tokens, err := h.sessionStarter.StartSession(r.Context(), user)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrUserLocked) {
+			httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+			return
+		}
+
+		log.Println("[ChangePassword]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, "can not generate token")
+		return
+	}
+
+	w.Header().Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	httputils.SendJSONResponse(w, http.StatusOK, loginResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+type resetPasswordBody struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (b *resetPasswordBody) Valid() bool {
+	return len(b.Token) != 0 && len(b.NewPassword) != 0
+}
+
+// ResetPassword godoc
+// @Summary Set a new password with a reset token
+// @Description The token works once and only until it expires. Every session of the user is revoked.
+// @Tags auth
+// @Accept json
+// @Param dto body resetPasswordBody true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/password/reset [post]
+func (h *PasswordHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var body resetPasswordBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	err := h.service.ResetPassword(r.Context(), body.Token, body.NewPassword)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidResetToken):
+			httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, domain.ErrWeakPassword):
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Println("[ResetPassword]", err)
+			httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		}
+
+		return
+	}
+
+	httputils.SendStatusCode(w, http.StatusNoContent)
+}
+
+// IssuePasswordReset godoc
+// @Summary Issue a password reset token for a user
+// @Description The token is shown only in this response, pass it on to the user.
+// @Tags admin
+// @Produce json
+// @Param userID path int true "User ID"
+// @Security BearerAuth
+// @Success 201 {object} domain.PasswordResetToken
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/users/{userID}/password-reset [post]
+func (h *PasswordHandler) IssuePasswordReset(w http.ResponseWriter, r *http.Request) {
+	actorID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.service.IssueResetToken(r.Context(), actorID, userID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httputils.SendJSONErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		log.Println("[IssuePasswordReset]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	httputils.SendJSONResponse(w, http.StatusCreated, token)
+}
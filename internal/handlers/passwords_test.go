@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func TestPasswordHandler_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	passwordService := servicemock.NewMockpasswordService(ctrl)
+	sessionStarter := servicemock.NewMocksessionStarter(ctrl)
+	passwordHandler := NewPasswordHandler(passwordService, sessionStarter)
+
+	type TestCase struct {
+		Name               string
+		Body               *changePasswordBody
+		PrepareServiceFunc func(ctx context.Context, body *changePasswordBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &changePasswordBody{CurrentPassword: "old-pass1", NewPassword: "new-pass2"},
+			PrepareServiceFunc: func(ctx context.Context, body *changePasswordBody) {
+				user := &domain.User{ID: 1}
+
+				passwordService.
+					EXPECT().
+					ChangePassword(ctx, 1, body.CurrentPassword, body.NewPassword).
+					Return(user, nil)
+				sessionStarter.
+					EXPECT().
+					StartSession(ctx, user).
+					Return(&domain.TokenPair{AccessToken: "access", RefreshToken: "sid.secret"}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			Body:               &changePasswordBody{CurrentPassword: "old-pass1"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (wrong current password)",
+			Body: &changePasswordBody{CurrentPassword: "guess", NewPassword: "new-pass2"},
+			PrepareServiceFunc: func(ctx context.Context, body *changePasswordBody) {
+				passwordService.
+					EXPECT().
+					ChangePassword(ctx, 1, body.CurrentPassword, body.NewPassword).
+					Return(nil, domain.ErrWrongPassword)
+			},
+			ExpectedStatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "invalid (weak password)",
+			Body: &changePasswordBody{CurrentPassword: "old-pass1", NewPassword: "short"},
+			PrepareServiceFunc: func(ctx context.Context, body *changePasswordBody) {
+				passwordService.
+					EXPECT().
+					ChangePassword(ctx, 1, body.CurrentPassword, body.NewPassword).
+					Return(nil, domain.ErrWeakPassword)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			r = r.WithContext(contextutil.SetUserIDToContext(r.Context(), 1))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), testCase.Body)
+			}
+
+			passwordHandler.ChangePassword(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestPasswordHandler_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	passwordService := servicemock.NewMockpasswordService(ctrl)
+	passwordHandler := NewPasswordHandler(passwordService, servicemock.NewMocksessionStarter(ctrl))
+
+	type TestCase struct {
+		Name               string
+		Body               *resetPasswordBody
+		PrepareServiceFunc func(ctx context.Context, body *resetPasswordBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &resetPasswordBody{Token: "token", NewPassword: "new-pass2"},
+			PrepareServiceFunc: func(ctx context.Context, body *resetPasswordBody) {
+				passwordService.EXPECT().ResetPassword(ctx, body.Token, body.NewPassword).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			Body:               &resetPasswordBody{NewPassword: "new-pass2"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid (expired token)",
+			Body: &resetPasswordBody{Token: "stale", NewPassword: "new-pass2"},
+			PrepareServiceFunc: func(ctx context.Context, body *resetPasswordBody) {
+				passwordService.EXPECT().ResetPassword(ctx, body.Token, body.NewPassword).Return(domain.ErrInvalidResetToken)
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), testCase.Body)
+			}
+
+			passwordHandler.ResetPassword(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestPasswordHandler_IssuePasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	passwordService := servicemock.NewMockpasswordService(ctrl)
+	passwordHandler := NewPasswordHandler(passwordService, servicemock.NewMocksessionStarter(ctrl))
+
+	t.Run("valid", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, nil, map[string]string{"userID": "7"})
+		w := httptest.NewRecorder()
+
+		passwordService.
+			EXPECT().
+			IssueResetToken(r.Context(), 1, 7).
+			Return(&domain.PasswordResetToken{UserID: 7, Token: "token", ExpiresAt: time.Now().UTC()}, nil)
+
+		passwordHandler.IssuePasswordReset(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+
+		var token domain.PasswordResetToken
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&token))
+		assert.Equal(t, "token", token.Token)
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		r := newAdminRequest(t, http.MethodPost, nil, map[string]string{"userID": "8"})
+		w := httptest.NewRecorder()
+
+		passwordService.EXPECT().IssueResetToken(r.Context(), 1, 8).Return(nil, domain.ErrNotFound)
+
+		passwordHandler.IssuePasswordReset(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/jsonutil"
+)
+
+type reversalsService interface {
+	RefundWithdrawal(ctx context.Context, withdrawalID int, reason string) (*domain.BalanceAction, error)
+	ClawbackOrderAccrual(ctx context.Context, orderID string, reason string) (*domain.BalanceAction, error)
+}
+
+type ReversalsHandler struct {
+	service reversalsService
+}
+
+func NewReversalsHandler(service reversalsService) *ReversalsHandler {
+	return &ReversalsHandler{
+		service: service,
+	}
+}
+
+type reversalBody struct {
+	Reason string `json:"reason"`
+}
+
+func (b *reversalBody) Valid() bool {
+	return len(b.Reason) != 0
+}
+
+type reversalForResponse struct {
+	ID          int          `json:"id"`
+	Kind        string       `json:"kind"`
+	Order       string       `json:"order"`
+	Sum         domain.Money `json:"sum" swaggertype:"number"`
+	ReversesID  *int         `json:"reverses_id,omitempty"`
+	Reason      *string      `json:"reason,omitempty"`
+	ProcessedAt *time.Time   `json:"processed_at,omitempty"`
+}
+
+func newReversalForResponse(action *domain.BalanceAction) reversalForResponse {
+	return reversalForResponse{
+		ID:          action.ID,
+		Kind:        action.Kind,
+		Order:       action.OrderID,
+		Sum:         action.Amount,
+		ReversesID:  action.ReversesID,
+		Reason:      action.Reason,
+		ProcessedAt: action.ProcessedAt,
+	}
+}
+
+// RefundWithdrawal godoc
+// @Summary Refund a processed withdrawal back to the user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param withdrawalID path int true "Withdrawal ID"
+// @Param dto body reversalBody true "Reason code"
+// @Security BearerAuth
+// @Success 201 {object} reversalForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 422 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/withdrawals/{withdrawalID}/refund [post]
+func (h *ReversalsHandler) RefundWithdrawal(w http.ResponseWriter, r *http.Request) {
+	withdrawalID, err := strconv.Atoi(chi.URLParam(r, "withdrawalID"))
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid withdrawal id")
+		return
+	}
+
+	var body reversalBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	refund, err := h.service.RefundWithdrawal(r.Context(), withdrawalID, body.Reason)
+
+	if err != nil {
+		h.sendReversalError(w, "[RefundWithdrawal]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, newReversalForResponse(refund))
+}
+
+// ClawbackOrderAccrual godoc
+// @Summary Claw back points accrued for a returned order
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param orderID path string true "Order number"
+// @Param dto body reversalBody true "Reason code"
+// @Security BearerAuth
+// @Success 201 {object} reversalForResponse
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 422 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /admin/orders/{orderID}/clawback [post]
+func (h *ReversalsHandler) ClawbackOrderAccrual(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderID")
+
+	if len(orderID) == 0 {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	var body reversalBody
+
+	if status, err := jsonutil.Unmarshal(w, r, &body); err != nil {
+		httputils.SendJSONErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !body.Valid() {
+		httputils.SendJSONErrorResponse(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	clawback, err := h.service.ClawbackOrderAccrual(r.Context(), orderID, body.Reason)
+
+	if err != nil {
+		h.sendReversalError(w, "[ClawbackOrderAccrual]", err)
+		return
+	}
+
+	httputils.SendJSONResponse(w, http.StatusCreated, newReversalForResponse(clawback))
+}
+
+func (h *ReversalsHandler) sendReversalError(w http.ResponseWriter, logPrefix string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidReversalReason):
+		httputils.SendJSONErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		httputils.SendJSONErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrNotReversible), errors.Is(err, domain.ErrAlreadyReversed):
+		httputils.SendJSONErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		log.Println(logPrefix, err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func TestReversalsHandler_RefundWithdrawal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reversalsServiceMock := servicemock.NewMockreversalsService(ctrl)
+	reversalsHandler := NewReversalsHandler(reversalsServiceMock)
+
+	type TestCase struct {
+		Name               string
+		WithdrawalID       string
+		Body               *reversalBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:         "valid",
+			WithdrawalID: "10",
+			Body:         &reversalBody{Reason: domain.CustomerRequestReversalReason},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					RefundWithdrawal(ctx, 10, body.Reason).
+					Return(&domain.BalanceAction{ID: 11, Amount: domain.MoneyFromInt(50), Kind: domain.RefundBalanceActionKind}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "invalid (bad withdrawal id)",
+			WithdrawalID:       "abc",
+			Body:               &reversalBody{Reason: domain.CustomerRequestReversalReason},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			WithdrawalID:       "10",
+			Body:               &reversalBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid (unknown reason)",
+			WithdrawalID: "10",
+			Body:         &reversalBody{Reason: "because"},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					RefundWithdrawal(ctx, 10, body.Reason).
+					Return(nil, domain.ErrInvalidReversalReason)
+			},
+			ExpectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			Name:         "invalid (not found)",
+			WithdrawalID: "12",
+			Body:         &reversalBody{Reason: domain.CustomerRequestReversalReason},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					RefundWithdrawal(ctx, 12, body.Reason).
+					Return(nil, domain.ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:         "invalid (already refunded)",
+			WithdrawalID: "13",
+			Body:         &reversalBody{Reason: domain.CustomerRequestReversalReason},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					RefundWithdrawal(ctx, 13, body.Reason).
+					Return(nil, domain.ErrAlreadyReversed)
+			},
+			ExpectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("withdrawalID", testCase.WithdrawalID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), reversalsServiceMock, testCase.Body)
+			}
+
+			reversalsHandler.RefundWithdrawal(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestReversalsHandler_ClawbackOrderAccrual(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reversalsServiceMock := servicemock.NewMockreversalsService(ctrl)
+	reversalsHandler := NewReversalsHandler(reversalsServiceMock)
+
+	type TestCase struct {
+		Name               string
+		OrderID            string
+		Body               *reversalBody
+		PrepareServiceFunc func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody)
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:    "valid",
+			OrderID: "12345678903",
+			Body:    &reversalBody{Reason: domain.OrderReturnedReversalReason},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					ClawbackOrderAccrual(ctx, "12345678903", body.Reason).
+					Return(&domain.BalanceAction{ID: 5, Amount: domain.MoneyFromInt(-30), Kind: domain.ClawbackBalanceActionKind}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "invalid (invalid body)",
+			OrderID:            "12345678903",
+			Body:               &reversalBody{},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:    "invalid (not processed)",
+			OrderID: "12345678903",
+			Body:    &reversalBody{Reason: domain.OrderReturnedReversalReason},
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockreversalsService, body *reversalBody) {
+				service.
+					EXPECT().
+					ClawbackOrderAccrual(ctx, "12345678903", body.Reason).
+					Return(nil, domain.ErrNotReversible)
+			},
+			ExpectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rawBody, err := json.Marshal(testCase.Body)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawBody))
+			r.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("orderID", testCase.OrderID)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), reversalsServiceMock, testCase.Body)
+			}
+
+			reversalsHandler.ClawbackOrderAccrual(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
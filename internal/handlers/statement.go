@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+)
+
+type statementService interface {
+	StreamStatement(
+		ctx context.Context, userID int, from *time.Time, to *time.Time, fn func(line domain.StatementLine) error,
+	) error
+}
+
+type StatementHandler struct {
+	service statementService
+}
+
+func NewStatementHandler(service statementService) *StatementHandler {
+	return &StatementHandler{
+		service: service,
+	}
+}
+
+// GetStatement godoc
+// @Summary Get user account statement
+// @Description Every movement of the spendable points, oldest first, with the balance after it.
+// @Description The format follows the Accept header: application/json (default), text/csv or text/plain.
+// @Tags balance
+// @Produce json
+// @Produce text/csv
+// @Produce plain
+// @Param from query string false "Lines at or after, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Lines before, RFC 3339 or YYYY-MM-DD (the whole day)"
+// @Security BearerAuth
+// @Success 200 {array} domain.StatementLine
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 401 {object} httputils.HTTPError
+// @Failure 406 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Router /user/statement [get]
+func (h *StatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextutil.GetUserIDFromContext(r.Context())
+
+	if err != nil {
+		httputils.SendJSONErrorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	writer := newStatementWriter(w, r.Header.Get("Accept"))
+
+	if writer == nil {
+		httputils.SendJSONErrorResponse(w, http.StatusNotAcceptable, "supported formats are application/json, text/csv and text/plain")
+		return
+	}
+
+	var from, to *time.Time
+
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = parseHistoryTime(value, false); err != nil {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = parseHistoryTime(value, true); err != nil {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err = h.service.StreamStatement(r.Context(), userID, from, to, writer.WriteLine)
+
+	if err != nil {
+		if writer.Started() {
+			// The status line is already sent, all we can do is cut the body short.
+			log.Println("[GetStatement]", err)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidHistoryFilter) {
+			httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		log.Println("[GetStatement]", err)
+		httputils.SendJSONErrorResponse(w, http.StatusInternalServerError, domain.ErrInternalServer.Error())
+		return
+	}
+
+	if !writer.Started() {
+		httputils.SendStatusCode(w, http.StatusNoContent)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Println("[GetStatement]", err)
+	}
+}
+
+// statementWriter streams statement lines in one format. Nothing is written
+// until the first line arrives, so an empty statement can still be answered
+// with 204.
+type statementWriter interface {
+	WriteLine(line domain.StatementLine) error
+	Started() bool
+	Close() error
+}
+
+func newStatementWriter(w http.ResponseWriter, accept string) statementWriter {
+	if accept == "" {
+		return &jsonStatementWriter{w: w}
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			return &jsonStatementWriter{w: w}
+		case "text/csv":
+			return &csvStatementWriter{w: w}
+		case "text/plain", "text/*":
+			return &textStatementWriter{w: w}
+		}
+	}
+
+	return nil
+}
+
+type jsonStatementWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *jsonStatementWriter) WriteLine(line domain.StatementLine) error {
+	separator := ","
+
+	if !s.started {
+		s.w.Header().Set("content-type", "application/json")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+		separator = "["
+	}
+
+	raw, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(s.w, separator); err != nil {
+		return err
+	}
+
+	_, err = s.w.Write(raw)
+
+	return err
+}
+
+func (s *jsonStatementWriter) Started() bool {
+	return s.started
+}
+
+func (s *jsonStatementWriter) Close() error {
+	_, err := io.WriteString(s.w, "]")
+	return err
+}
+
+type csvStatementWriter struct {
+	w       http.ResponseWriter
+	csv     *csv.Writer
+	started bool
+}
+
+func (s *csvStatementWriter) WriteLine(line domain.StatementLine) error {
+	if !s.started {
+		s.w.Header().Set("content-type", "text/csv")
+		s.w.Header().Set("Content-Disposition", `attachment; filename="statement.csv"`)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+		s.csv = csv.NewWriter(s.w)
+
+		if err := s.csv.Write([]string{"entry_id", "created_at", "kind", "order", "amount", "balance"}); err != nil {
+			return err
+		}
+	}
+
+	return s.csv.Write([]string{
+		strconv.Itoa(line.EntryID),
+		line.CreatedAt.UTC().Format(time.RFC3339),
+		line.Kind,
+		line.OrderID,
+		line.Amount.String(),
+		line.Balance.String(),
+	})
+}
+
+func (s *csvStatementWriter) Started() bool {
+	return s.started
+}
+
+func (s *csvStatementWriter) Close() error {
+	s.csv.Flush()
+	return s.csv.Error()
+}
+
+type textStatementWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+const textStatementRow = "%-20s  %-10s  %-20s  %14s  %14s\n"
+
+func (s *textStatementWriter) WriteLine(line domain.StatementLine) error {
+	if !s.started {
+		s.w.Header().Set("content-type", "text/plain")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+
+		if _, err := fmt.Fprintf(s.w, textStatementRow, "DATE", "KIND", "ORDER", "AMOUNT", "BALANCE"); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		s.w,
+		textStatementRow,
+		line.CreatedAt.UTC().Format(time.DateTime),
+		line.Kind,
+		line.OrderID,
+		line.Amount.String(),
+		line.Balance.String(),
+	)
+
+	return err
+}
+
+func (s *textStatementWriter) Started() bool {
+	return s.started
+}
+
+func (s *textStatementWriter) Close() error {
+	return nil
+}
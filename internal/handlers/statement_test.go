@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	servicemock "github.com/MowlCoder/accumulative-loyalty-system/internal/handlers/mocks"
+)
+
+func TestStatementHandler_GetStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	statementServiceMock := servicemock.NewMockstatementService(ctrl)
+	statementHandler := NewStatementHandler(statementServiceMock)
+
+	createdAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	lines := []domain.StatementLine{
+		{EntryID: 1, Kind: domain.AccrualLedgerEntry, OrderID: "100", Amount: domain.MoneyFromInt(10), Balance: domain.MoneyFromInt(10), CreatedAt: createdAt},
+		{EntryID: 2, Kind: domain.WithdrawalLedgerEntry, OrderID: "200", Amount: domain.MustParseMoney("-2.5"), Balance: domain.MustParseMoney("7.5"), CreatedAt: createdAt},
+	}
+
+	streamLines := func(ctx context.Context, userID int, from *time.Time, to *time.Time, fn func(line domain.StatementLine) error) error {
+		for _, line := range lines {
+			if err := fn(line); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	type TestCase struct {
+		Name                string
+		Accept              string
+		Query               string
+		PrepareServiceFunc  func(ctx context.Context, service *servicemock.MockstatementService)
+		ExpectedStatusCode  int
+		ExpectedContentType string
+		ExpectedBody        string
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid (json)",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockstatementService) {
+				service.EXPECT().StreamStatement(ctx, 1, nil, nil, gomock.Any()).DoAndReturn(streamLines)
+			},
+			ExpectedStatusCode:  http.StatusOK,
+			ExpectedContentType: "application/json",
+			ExpectedBody: `[{"entry_id":1,"kind":"ACCRUAL","order":"100","amount":10.00,"balance":10.00,"created_at":"2026-10-17T09:00:00Z"},` +
+				`{"entry_id":2,"kind":"WITHDRAWAL","order":"200","amount":-2.50,"balance":7.50,"created_at":"2026-10-17T09:00:00Z"}]`,
+		},
+		{
+			Name:   "valid (csv)",
+			Accept: "text/csv",
+			Query:  "?from=2026-10-01&to=2026-10-31",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockstatementService) {
+				service.EXPECT().StreamStatement(ctx, 1, gomock.Not(gomock.Nil()), gomock.Not(gomock.Nil()), gomock.Any()).DoAndReturn(streamLines)
+			},
+			ExpectedStatusCode:  http.StatusOK,
+			ExpectedContentType: "text/csv",
+			ExpectedBody: "entry_id,created_at,kind,order,amount,balance\n" +
+				"1,2026-10-17T09:00:00Z,ACCRUAL,100,10.00,10.00\n" +
+				"2,2026-10-17T09:00:00Z,WITHDRAWAL,200,-2.50,7.50\n",
+		},
+		{
+			Name:   "valid (plain text)",
+			Accept: "text/plain",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockstatementService) {
+				service.EXPECT().StreamStatement(ctx, 1, nil, nil, gomock.Any()).DoAndReturn(streamLines)
+			},
+			ExpectedStatusCode:  http.StatusOK,
+			ExpectedContentType: "text/plain",
+		},
+		{
+			Name: "valid (empty)",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockstatementService) {
+				service.EXPECT().StreamStatement(ctx, 1, nil, nil, gomock.Any()).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "invalid (unsupported format)",
+			Accept:             "application/pdf",
+			ExpectedStatusCode: http.StatusNotAcceptable,
+		},
+		{
+			Name:               "invalid (bad date)",
+			Query:              "?from=last-week",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:  "invalid (empty range)",
+			Query: "?from=2026-10-10&to=2026-10-01",
+			PrepareServiceFunc: func(ctx context.Context, service *servicemock.MockstatementService) {
+				service.EXPECT().StreamStatement(ctx, 1, gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrInvalidHistoryFilter)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+testCase.Query, nil)
+			r.Header.Set("Accept", testCase.Accept)
+			ctx := contextutil.SetUserIDToContext(r.Context(), 1)
+			r = r.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), statementServiceMock)
+			}
+
+			statementHandler.GetStatement(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if testCase.ExpectedContentType != "" {
+				assert.Equal(t, testCase.ExpectedContentType, res.Header.Get("Content-Type"))
+			}
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			if testCase.ExpectedBody != "" {
+				assert.Equal(t, testCase.ExpectedBody, string(body))
+			}
+
+			if testCase.ExpectedContentType == "text/plain" {
+				assert.Len(t, strings.Split(strings.TrimSpace(string(body)), "\n"), 3)
+			}
+		})
+	}
+}
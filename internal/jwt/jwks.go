@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+// JWK is the public half of a verification key as described in RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// newJWK describes the key and derives its kid from the RFC 7638 thumbprint,
+// so the same key file always gets the same kid on every instance.
+func newJWK(public crypto.PublicKey) (JWK, error) {
+	var jwk JWK
+	var thumbprintInput any
+
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		jwk = JWK{
+			KeyType:   "RSA",
+			Algorithm: signingMethodFor(key).Alg(),
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+
+		thumbprintInput = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case ed25519.PublicKey:
+		jwk = JWK{
+			KeyType:   "OKP",
+			Algorithm: signingMethodFor(key).Alg(),
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(key),
+		}
+
+		thumbprintInput = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		return JWK{}, ErrUnsupportedKey
+	}
+
+	raw, err := json.Marshal(thumbprintInput)
+	if err != nil {
+		return JWK{}, err
+	}
+
+	thumbprint := sha256.Sum256(raw)
+
+	jwk.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint[:])
+	jwk.Use = "sig"
+
+	return jwk, nil
+}
@@ -0,0 +1,33 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJWK(t *testing.T) {
+	t.Run("RFC 7638 thumbprint", func(t *testing.T) {
+		n, err := base64.RawURLEncoding.DecodeString(
+			"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		)
+		require.NoError(t, err)
+
+		jwk, err := newJWK(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+		require.NoError(t, err)
+
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwk.KeyID)
+		assert.Equal(t, "AQAB", jwk.E)
+		assert.Equal(t, "RS256", jwk.Algorithm)
+		assert.Equal(t, "sig", jwk.Use)
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		_, err := newJWK("not a key")
+		require.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
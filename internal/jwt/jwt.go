@@ -1,26 +1,133 @@
 package jwt
 
 import (
-	"os"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// InsecureDefaultSecret is the HS256 secret the service used to fall back to.
+// It is only accepted in dev mode.
+const InsecureDefaultSecret = "secret"
+
+var (
+	ErrUnknownKey              = errors.New("token is signed with an unknown key")
+	ErrUnexpectedSigningMethod = errors.New("token signing method does not match its key")
+	ErrUnsupportedKey          = errors.New("unsupported key, only RSA and Ed25519 keys are supported")
+	ErrInvalidToken            = errors.New("invalid token")
+)
+
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID int
+	UserID    int
+	SessionID string `json:"sid,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+type signingKey struct {
+	id     string
+	method jwt.SigningMethod
+	key    any
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    any
+}
+
+// Manager signs access tokens with one key and verifies them against every
+// key it knows by kid, so a retired key keeps verifying tokens it signed until
+// they expire.
+type Manager struct {
+	signing      signingKey
+	verification map[string]verificationKey
+	jwks         JWKS
+}
+
+// NewHMACManager signs and verifies with a shared HS256 secret. Such tokens
+// carry no kid and the key is never published in the JWKS.
+func NewHMACManager(secret string) *Manager {
+	key := []byte(secret)
+
+	return &Manager{
+		signing: signingKey{method: jwt.SigningMethodHS256, key: key},
+		verification: map[string]verificationKey{
+			"": {method: jwt.SigningMethodHS256, key: key},
+		},
+		jwks: JWKS{Keys: []JWK{}},
+	}
 }
 
-func GenerateToken(userID int) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+// NewManager signs with the PEM encoded private key and verifies with its
+// public half plus every extra PEM encoded key, public or private.
+func NewManager(signingKeyPEM []byte, verificationKeysPEM ...[]byte) (*Manager, error) {
+	private, err := parsePrivateKey(signingKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	signingJWK, err := newJWK(private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	manager := &Manager{
+		signing: signingKey{
+			id:     signingJWK.KeyID,
+			method: signingMethodFor(private.Public()),
+			key:    private,
+		},
+		verification: make(map[string]verificationKey),
+		jwks:         JWKS{Keys: []JWK{}},
+	}
+
+	manager.addVerificationKey(private.Public(), signingJWK)
+
+	for i, raw := range verificationKeysPEM {
+		public, err := parsePublicKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %d: %w", i+1, err)
+		}
+
+		jwk, err := newJWK(public)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %d: %w", i+1, err)
+		}
+
+		manager.addVerificationKey(public, jwk)
+	}
+
+	return manager, nil
+}
+
+func (m *Manager) addVerificationKey(public any, jwk JWK) {
+	if _, ok := m.verification[jwk.KeyID]; ok {
+		return
+	}
+
+	m.verification[jwk.KeyID] = verificationKey{method: signingMethodFor(public), key: public}
+	m.jwks.Keys = append(m.jwks.Keys, jwk)
+}
+
+// GenerateToken issues an access token for the session that expires after ttl.
+// The role is a snapshot, a changed role takes effect with the next refresh.
+func (m *Manager) GenerateToken(userID int, sessionID string, role string, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(m.signing.method, Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Hour * 24)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(ttl)),
 		},
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Role:      role,
 	})
 
-	tokenString, err := token.SignedString([]byte(getJWTSecretKey()))
+	if m.signing.id != "" {
+		token.Header["kid"] = m.signing.id
+	}
+
+	tokenString, err := token.SignedString(m.signing.key)
 
 	if err != nil {
 		return "", err
@@ -29,10 +136,23 @@ func GenerateToken(userID int) (string, error) {
 	return tokenString, nil
 }
 
-func ParseToken(tokenString string) (*Claims, error) {
+func (m *Manager) ParseToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(getJWTSecretKey()), nil
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.verification[kid]
+
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		// Checking the algorithm against the key, not just the header, keeps a
+		// public key from being used as an HMAC secret.
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, ErrUnexpectedSigningMethod
+		}
+
+		return key.key, nil
 	})
 
 	if err != nil {
@@ -40,18 +160,13 @@ func ParseToken(tokenString string) (*Claims, error) {
 	}
 
 	if !token.Valid {
-		return nil, err
+		return nil, ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
-func getJWTSecretKey() string {
-	key, ok := os.LookupEnv("JWT_SECRET")
-
-	if !ok {
-		return "secret"
-	}
-
-	return key
+// JWKS returns the public verification keys. It is empty for HS256 managers.
+func (m *Manager) JWKS() JWKS {
+	return m.jwks
 }
@@ -1,15 +1,39 @@
 package jwt
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newRSAKeyPEM(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func newEd25519KeyPEM(t *testing.T) []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	raw, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw})
+}
+
 func TestGenerateToken(t *testing.T) {
 	t.Run("generate token", func(t *testing.T) {
-		token, err := GenerateToken(123)
+		token, err := NewHMACManager("test-secret").GenerateToken(123, "session", "customer", time.Minute)
 		require.NoError(t, err)
 
 		assert.NotEmpty(t, token)
@@ -17,16 +41,98 @@ func TestGenerateToken(t *testing.T) {
 }
 
 func TestParseToken(t *testing.T) {
-	t.Run("parse token", func(t *testing.T) {
-		userID := 123
+	rsaManager, err := NewManager(newRSAKeyPEM(t))
+	require.NoError(t, err)
+
+	ed25519Manager, err := NewManager(newEd25519KeyPEM(t))
+	require.NoError(t, err)
 
-		token, err := GenerateToken(userID)
+	managers := map[string]*Manager{
+		"HS256": NewHMACManager("test-secret"),
+		"RS256": rsaManager,
+		"EdDSA": ed25519Manager,
+	}
+
+	for alg, manager := range managers {
+		t.Run("parse token "+alg, func(t *testing.T) {
+			userID := 123
+
+			token, err := manager.GenerateToken(userID, "session", "admin", time.Minute)
+			require.NoError(t, err)
+			assert.NotEmpty(t, token)
+
+			claims, err := manager.ParseToken(token)
+			require.NoError(t, err)
+
+			assert.Equal(t, claims.UserID, userID)
+			assert.Equal(t, "session", claims.SessionID)
+			assert.Equal(t, "admin", claims.Role)
+		})
+
+		t.Run("expired token "+alg, func(t *testing.T) {
+			token, err := manager.GenerateToken(123, "session", "customer", -time.Minute)
+			require.NoError(t, err)
+
+			claims, err := manager.ParseToken(token)
+			require.Error(t, err)
+			assert.Nil(t, claims)
+		})
+	}
+
+	t.Run("token of another key", func(t *testing.T) {
+		token, err := rsaManager.GenerateToken(123, "session", "customer", time.Minute)
 		require.NoError(t, err)
-		assert.NotEmpty(t, token)
 
-		claims, err := ParseToken(token)
+		_, err = ed25519Manager.ParseToken(token)
+		require.ErrorIs(t, err, ErrUnknownKey)
+
+		_, err = NewHMACManager("test-secret").ParseToken(token)
+		require.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("HMAC token with a public key kid", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: 123})
+		token.Header["kid"] = rsaManager.signing.id
+
+		tokenString, err := token.SignedString([]byte("guessed"))
 		require.NoError(t, err)
 
-		assert.Equal(t, claims.UserID, userID)
+		_, err = rsaManager.ParseToken(tokenString)
+		require.ErrorIs(t, err, ErrUnexpectedSigningMethod)
 	})
 }
+
+func TestManager_rotation(t *testing.T) {
+	oldKey := newRSAKeyPEM(t)
+	newKey := newEd25519KeyPEM(t)
+
+	oldManager, err := NewManager(oldKey)
+	require.NoError(t, err)
+
+	newManager, err := NewManager(newKey, oldKey)
+	require.NoError(t, err)
+
+	oldToken, err := oldManager.GenerateToken(123, "session", "customer", time.Minute)
+	require.NoError(t, err)
+
+	claims, err := newManager.ParseToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, 123, claims.UserID)
+
+	newToken, err := newManager.GenerateToken(123, "session", "customer", time.Minute)
+	require.NoError(t, err)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, newManager.signing.id, parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	jwks := newManager.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, newManager.signing.id, jwks.Keys[0].KeyID)
+	assert.Equal(t, oldManager.signing.id, jwks.Keys[1].KeyID)
+}
+
+func TestNewHMACManager_JWKS(t *testing.T) {
+	assert.Empty(t, NewHMACManager("test-secret").JWKS().Keys)
+}
@@ -0,0 +1,123 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrInvalidPEM = errors.New("no PEM block found")
+
+// LoadManager reads the signing key and the extra verification keys from PEM
+// files.
+func LoadManager(signingKeyPath string, verificationKeyPaths []string) (*Manager, error) {
+	signingKeyPEM, err := os.ReadFile(signingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeysPEM := make([][]byte, 0, len(verificationKeyPaths))
+
+	for _, path := range verificationKeyPaths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		verificationKeysPEM = append(verificationKeysPEM, raw)
+	}
+
+	return NewManager(signingKeyPEM, verificationKeysPEM...)
+}
+
+type privateKey interface {
+	Public() crypto.PublicKey
+}
+
+func parsePrivateKey(raw []byte) (privateKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	var key any
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// parsePublicKey accepts a public key or a private key, in which case only its
+// public half is kept.
+func parsePublicKey(raw []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	var key any
+	var err error
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "RSA PRIVATE KEY", "PRIVATE KEY":
+		private, err := parsePrivateKey(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		return private.Public(), nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return key, nil
+	case ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+func signingMethodFor(public crypto.PublicKey) jwt.SigningMethod {
+	if _, ok := public.(ed25519.PublicKey); ok {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+)
+
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, presented string) (*domain.APIKey, error)
+}
+
+// NewAPIKeyMiddleware lets through only requests that carry an active API key
+// in the X-API-Key header and puts the key into the context.
+func NewAPIKeyMiddleware(authenticator apiKeyAuthenticator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented := r.Header.Get(domain.APIKeyHeader)
+
+			if presented == "" {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
+
+			key, err := authenticator.Authenticate(r.Context(), presented)
+
+			if err != nil {
+				if errors.Is(err, domain.ErrInvalidAPIKey) {
+					httputils.SendStatusCode(w, http.StatusUnauthorized)
+					return
+				}
+
+				log.Println("[APIKeyMiddleware]", err)
+				httputils.SendStatusCode(w, http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(contextutil.SetAPIKeyToContext(r.Context(), key)))
+		})
+	}
+}
+
+// RequireScope lets through only requests whose API key was granted scope. It
+// must run after the API key middleware.
+func RequireScope(scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := contextutil.GetAPIKeyFromContext(r.Context())
+
+			if err != nil {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
+
+			if !key.HasScope(scope) {
+				httputils.SendStatusCode(w, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type stubAPIKeyAuthenticator map[string]*domain.APIKey
+
+func (s stubAPIKeyAuthenticator) Authenticate(ctx context.Context, presented string) (*domain.APIKey, error) {
+	key, ok := s[presented]
+
+	if !ok {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	authenticator := stubAPIKeyAuthenticator{
+		"reader.secret": {ID: "reader", Scopes: []string{domain.OrdersReadScope}},
+		"writer.secret": {ID: "writer", Scopes: []string{domain.OrdersWriteScope}},
+	}
+
+	handler := NewAPIKeyMiddleware(authenticator)(
+		RequireScope(domain.OrdersReadScope)(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+			writer.WriteHeader(http.StatusOK)
+		})),
+	)
+
+	testCases := []struct {
+		Name               string
+		Key                string
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "valid",
+			Key:                "reader.secret",
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (missing scope)",
+			Key:                "writer.secret",
+			ExpectedStatusCode: http.StatusForbidden,
+		},
+		{
+			Name:               "invalid (unknown key)",
+			Key:                "reader.guess",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "invalid (no key)",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if testCase.Key != "" {
+				request.Header.Set(domain.APIKeyHeader, testCase.Key)
+			}
+
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, request)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
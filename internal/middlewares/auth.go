@@ -1,11 +1,14 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
 	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
 )
@@ -14,26 +17,60 @@ var (
 	ErrInvalidAuthorizationHeader = errors.New("invalid authorization header")
 )
 
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token, err := getTokenFromHeader(r)
+type accessTokenParser interface {
+	ParseToken(tokenString string) (*jwt.Claims, error)
+}
+
+type sessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// NewAuthMiddleware accepts a bearer access token only while the session it
+// was issued for is still active, so logout takes effect before the token
+// expires.
+func NewAuthMiddleware(parser accessTokenParser, checker sessionChecker) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := getTokenFromHeader(r)
+
+			if err != nil {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
 
-		if err != nil {
-			httputils.SendStatusCode(w, http.StatusUnauthorized)
-			return
-		}
+			jwtClaim, err := parser.ParseToken(token)
 
-		jwtClaim, err := jwt.ParseToken(token)
+			if err != nil || jwtClaim.SessionID == "" {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
 
-		if err != nil {
-			httputils.SendStatusCode(w, http.StatusUnauthorized)
-			return
-		}
+			active, err := checker.IsSessionActive(r.Context(), jwtClaim.SessionID)
 
-		ctx := contextutil.SetUserIDToContext(r.Context(), jwtClaim.UserID)
+			if err != nil {
+				log.Println("[AuthMiddleware]", err)
+				httputils.SendStatusCode(w, http.StatusInternalServerError)
+				return
+			}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			if !active {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
+
+			role := jwtClaim.Role
+
+			if role == "" {
+				role = domain.CustomerRole
+			}
+
+			ctx := contextutil.SetUserIDToContext(r.Context(), jwtClaim.UserID)
+			ctx = contextutil.SetSessionIDToContext(ctx, jwtClaim.SessionID)
+			ctx = contextutil.SetRoleToContext(ctx, role)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
 
 func getTokenFromHeader(r *http.Request) (string, error) {
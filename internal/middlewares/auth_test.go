@@ -1,30 +1,90 @@
 package middlewares
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
 )
 
+type stubSessionChecker map[string]bool
+
+func (s stubSessionChecker) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	return s[sessionID], nil
+}
+
 func TestAuthMiddleware(t *testing.T) {
-	t.Run("invalid", func(t *testing.T) {
-		request := httptest.NewRequest(http.MethodPost, "/", nil)
-		w := httptest.NewRecorder()
+	checker := stubSessionChecker{"active": true, "revoked": false}
+	tokenManager := jwt.NewHMACManager("test-secret")
 
-		handler := AuthMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
-			writer.WriteHeader(http.StatusOK)
-		}))
+	handler := NewAuthMiddleware(tokenManager, checker)(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		sessionID, err := contextutil.GetSessionIDFromContext(r.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "active", sessionID)
 
-		handler.ServeHTTP(w, request)
+		role, err := contextutil.GetRoleFromContext(r.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "customer", role)
 
-		res := w.Result()
-		defer res.Body.Close()
+		writer.WriteHeader(http.StatusOK)
+	}))
 
-		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
-	})
+	newToken := func(sessionID string) string {
+		token, err := tokenManager.GenerateToken(1, sessionID, "", time.Minute)
+		require.NoError(t, err)
+
+		return token
+	}
+
+	testCases := []struct {
+		Name               string
+		Token              string
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "valid",
+			Token:              newToken("active"),
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (no token)",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "invalid (no session in token)",
+			Token:              newToken(""),
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "invalid (revoked session)",
+			Token:              newToken("revoked"),
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/", nil)
+			if testCase.Token != "" {
+				request.Header.Set("Authorization", "Bearer "+testCase.Token)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, request)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
 }
 
 func TestAuthMiddleware_getTokenFromHeader(t *testing.T) {
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+)
+
+type merchantResolver interface {
+	Resolve(ctx context.Context, key *domain.APIKey, requested string) (*domain.Merchant, error)
+}
+
+// NewMerchantMiddleware puts the id of the merchant the request acts for into
+// the context. The merchant comes from the API key when the key is bound to
+// one, otherwise from the X-Merchant header, falling back to the default
+// merchant. It must run after the API key middleware, if there is one.
+func NewMerchantMiddleware(resolver merchantResolver) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Without API keys there is no key in the context, which is fine.
+			key, _ := contextutil.GetAPIKeyFromContext(r.Context())
+
+			merchant, err := resolver.Resolve(r.Context(), key, r.Header.Get(domain.MerchantHeader))
+
+			if err != nil {
+				if errors.Is(err, domain.ErrForeignMerchant) {
+					httputils.SendJSONErrorResponse(w, http.StatusForbidden, err.Error())
+					return
+				}
+
+				if errors.Is(err, domain.ErrUnknownMerchant) {
+					httputils.SendJSONErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+
+				log.Println("[MerchantMiddleware]", err)
+				httputils.SendStatusCode(w, http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(contextutil.SetMerchantIDToContext(r.Context(), merchant.ID)))
+		})
+	}
+}
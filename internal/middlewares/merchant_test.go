@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type stubMerchantResolver map[string]*domain.Merchant
+
+func (s stubMerchantResolver) Resolve(ctx context.Context, key *domain.APIKey, requested string) (*domain.Merchant, error) {
+	if key != nil && key.MerchantID != nil {
+		if requested != "" && requested != "shop" {
+			return nil, domain.ErrForeignMerchant
+		}
+
+		return s["shop"], nil
+	}
+
+	if requested == "" {
+		requested = domain.DefaultMerchantCode
+	}
+
+	merchant, ok := s[requested]
+
+	if !ok {
+		return nil, domain.ErrUnknownMerchant
+	}
+
+	return merchant, nil
+}
+
+func TestMerchantMiddleware(t *testing.T) {
+	resolver := stubMerchantResolver{
+		domain.DefaultMerchantCode: {ID: 1, Code: domain.DefaultMerchantCode},
+		"shop":                     {ID: 2, Code: "shop"},
+	}
+
+	shopID := 2
+	boundKey := &domain.APIKey{ID: "bound", MerchantID: &shopID}
+
+	handler := NewMerchantMiddleware(resolver)(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		merchantID, err := contextutil.GetMerchantIDFromContext(r.Context())
+
+		if err != nil {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(strconv.Itoa(merchantID)))
+	}))
+
+	testCases := []struct {
+		Name               string
+		Key                *domain.APIKey
+		Merchant           string
+		ExpectedStatusCode int
+		ExpectedMerchantID string
+	}{
+		{
+			Name:               "valid (default merchant)",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedMerchantID: "1",
+		},
+		{
+			Name:               "valid (merchant from header)",
+			Merchant:           "shop",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedMerchantID: "2",
+		},
+		{
+			Name:               "valid (merchant from key)",
+			Key:                boundKey,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedMerchantID: "2",
+		},
+		{
+			Name:               "invalid (key of another merchant)",
+			Key:                boundKey,
+			Merchant:           domain.DefaultMerchantCode,
+			ExpectedStatusCode: http.StatusForbidden,
+		},
+		{
+			Name:               "invalid (unknown merchant)",
+			Merchant:           "nope",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if testCase.Merchant != "" {
+				request.Header.Set(domain.MerchantHeader, testCase.Merchant)
+			}
+
+			if testCase.Key != nil {
+				request = request.WithContext(contextutil.SetAPIKeyToContext(request.Context(), testCase.Key))
+			}
+
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, request)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if testCase.ExpectedMerchantID != "" {
+				body, _ := io.ReadAll(res.Body)
+				assert.Equal(t, testCase.ExpectedMerchantID, string(body))
+			}
+		})
+	}
+}
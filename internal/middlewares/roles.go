@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+	"github.com/MowlCoder/accumulative-loyalty-system/pkg/httputils"
+)
+
+// RequireRoles lets through only users whose role is one of roles. It must
+// run after the auth middleware, which puts the role into the context.
+func RequireRoles(roles ...string) func(next http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(roles))
+
+	for _, role := range roles {
+		allowed[role] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, err := contextutil.GetRoleFromContext(r.Context())
+
+			if err != nil {
+				httputils.SendStatusCode(w, http.StatusUnauthorized)
+				return
+			}
+
+			if _, ok := allowed[role]; !ok {
+				httputils.SendStatusCode(w, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
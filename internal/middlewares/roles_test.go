@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/contextutil"
+)
+
+func TestRequireRoles(t *testing.T) {
+	handler := RequireRoles("support", "admin")(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		Name               string
+		Role               string
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "valid (support)",
+			Role:               "support",
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "valid (admin)",
+			Role:               "admin",
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid (customer)",
+			Role:               "customer",
+			ExpectedStatusCode: http.StatusForbidden,
+		},
+		{
+			Name:               "invalid (no role)",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if testCase.Role != "" {
+				ctx = contextutil.SetRoleToContext(ctx, testCase.Role)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, request)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// createAccrualLot records freshly issued points as a lot. It must run after
+// the accrual ledger entry is posted: if the user was in debt, the debt is
+// paid off first and only what is left of amount ends up in the lot.
+func createAccrualLot(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int,
+	balanceActionID int,
+	orderID string,
+	amount domain.Money,
+	expiresAt *time.Time,
+) error {
+	var balance domain.Money
+
+	query := `
+		SELECT balance
+		FROM ledger_accounts
+		WHERE type = $1 AND user_id = $2
+	`
+
+	err := tx.QueryRow(
+		ctx,
+		query,
+		domain.UserPointsLedgerAccount, userID,
+	).Scan(&balance)
+
+	if err != nil {
+		return err
+	}
+
+	remaining := amount
+
+	if balance.Cmp(remaining) < 0 {
+		remaining = balance
+	}
+
+	if remaining.IsNegative() {
+		remaining = domain.Money{}
+	}
+
+	query = `
+		INSERT INTO accrual_lots (user_id, balance_action_id, order_id, amount, remaining, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		userID, balanceActionID, orderID, amount, remaining, expiresAt,
+	)
+
+	return err
+}
+
+// consumeAccrualLots takes amount points out of the user's lots, the ones that
+// expire first going first. Expired lots are never touched. When strict is set
+// and the lots do not cover amount, ErrInsufficientFunds is returned; otherwise
+// the shortfall is left as debt on the ledger.
+func consumeAccrualLots(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int,
+	balanceActionID int,
+	amount domain.Money,
+	strict bool,
+) error {
+	query := `
+		SELECT id, remaining
+		FROM accrual_lots
+		WHERE user_id = $1 AND remaining > 0 AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY expires_at NULLS LAST, id
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(
+		ctx,
+		query,
+		userID, time.Now().UTC(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	lots := make([]domain.AccrualLot, 0)
+
+	for rows.Next() {
+		var lot domain.AccrualLot
+
+		if err := rows.Scan(&lot.ID, &lot.Remaining); err != nil {
+			rows.Close()
+			return err
+		}
+
+		lots = append(lots, lot)
+	}
+
+	rows.Close()
+
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	left := amount
+
+	for _, lot := range lots {
+		if !left.IsPositive() {
+			break
+		}
+
+		take := lot.Remaining
+
+		if take.Cmp(left) > 0 {
+			take = left
+		}
+
+		query = `
+			UPDATE accrual_lots
+			SET remaining = remaining - $1
+			WHERE id = $2
+		`
+
+		if _, err := tx.Exec(ctx, query, take, lot.ID); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO lot_consumptions (lot_id, balance_action_id, amount)
+			VALUES ($1, $2, $3)
+		`
+
+		if _, err := tx.Exec(ctx, query, lot.ID, balanceActionID, take); err != nil {
+			return err
+		}
+
+		left = left.Sub(take)
+	}
+
+	if strict && left.IsPositive() {
+		return domain.ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// restoreLotConsumptions puts the points consumed by a balance action back into
+// the lots they came from. What goes back into a lot that expired in the
+// meantime expires right away, so refunded points never outlive their lot.
+func restoreLotConsumptions(ctx context.Context, tx pgx.Tx, balanceActionID int) error {
+	query := `
+		UPDATE accrual_lots l
+		SET remaining = l.remaining + c.amount
+		FROM lot_consumptions c
+		WHERE c.lot_id = l.id AND c.balance_action_id = $1 AND c.restored_at IS NULL
+		RETURNING l.id, l.user_id, l.order_id, l.remaining, l.expires_at
+	`
+
+	rows, err := tx.Query(ctx, query, balanceActionID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	expired := make([]domain.AccrualLot, 0)
+
+	for rows.Next() {
+		var lot domain.AccrualLot
+
+		if err := rows.Scan(&lot.ID, &lot.UserID, &lot.OrderID, &lot.Remaining, &lot.ExpiresAt); err != nil {
+			rows.Close()
+			return err
+		}
+
+		if lot.ExpiresAt != nil && !lot.ExpiresAt.After(now) && lot.Remaining.IsPositive() {
+			expired = append(expired, lot)
+		}
+	}
+
+	rows.Close()
+
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	query = `
+		UPDATE lot_consumptions
+		SET restored_at = NOW()
+		WHERE balance_action_id = $1 AND restored_at IS NULL
+	`
+
+	if _, err := tx.Exec(ctx, query, balanceActionID); err != nil {
+		return err
+	}
+
+	for _, lot := range expired {
+		if err := postLotExpiry(ctx, tx, lot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postLotExpiry posts an EXPIRY action for what is left in the lot and empties
+// it. The lot must be locked by tx.
+func postLotExpiry(ctx context.Context, tx pgx.Tx, lot domain.AccrualLot) error {
+	orderID := ""
+
+	if lot.OrderID != nil {
+		orderID = *lot.OrderID
+	}
+
+	query := `
+		INSERT INTO balance_actions (user_id, amount, order_id, kind, processed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var actionID int
+
+	err := tx.QueryRow(
+		ctx,
+		query,
+		lot.UserID, lot.Remaining.Neg(), orderID, domain.ExpiryBalanceActionKind, time.Now().UTC(),
+	).Scan(&actionID)
+
+	if err != nil {
+		return err
+	}
+
+	entry := domain.NewExpiryLedgerEntry(lot.UserID, orderID, lot.Remaining)
+	entry.BalanceActionID = &actionID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE accrual_lots
+		SET remaining = 0
+		WHERE id = $1
+	`
+
+	if _, err := tx.Exec(ctx, query, lot.ID); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO lot_consumptions (lot_id, balance_action_id, amount)
+		VALUES ($1, $2, $3)
+	`
+
+	_, err = tx.Exec(ctx, query, lot.ID, actionID, lot.Remaining)
+
+	return err
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	repo := APIKeyRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, merchant_id, secret_hash, scopes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	return r.pool.QueryRow(
+		ctx,
+		query,
+		key.ID, key.Name, key.MerchantID, key.SecretHash, key.Scopes,
+	).Scan(&key.CreatedAt)
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	var key domain.APIKey
+
+	query := `
+		SELECT id, name, merchant_id, secret_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE id = $1
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(&key.ID, &key.Name, &key.MerchantID, &key.SecretHash, &key.Scopes, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	query := `
+		SELECT id, name, merchant_id, secret_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+
+	for rows.Next() {
+		var key domain.APIKey
+
+		err := rows.Scan(&key.ID, &key.Name, &key.MerchantID, &key.SecretHash, &key.Scopes, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id string) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that the key was used. It writes at most once a
+// minute per key, so busy keys do not turn every request into an update.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+
+	_, err := r.pool.Exec(ctx, query, id)
+
+	return err
+}
@@ -2,12 +2,16 @@ package repositories
 
 import (
 	"context"
-	"math"
+	"errors"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
 )
 
 type BalanceActionsRepository struct {
@@ -22,7 +26,7 @@ func NewBalanceActionsRepository(pool *pgxpool.Pool) *BalanceActionsRepository {
 	return &repo
 }
 
-func (r *BalanceActionsRepository) Save(ctx context.Context, userID int, orderID string, amount float64) error {
+func (r *BalanceActionsRepository) Save(ctx context.Context, userID int, orderID string, amount domain.Money) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
@@ -30,52 +34,52 @@ func (r *BalanceActionsRepository) Save(ctx context.Context, userID int, orderID
 
 	defer tx.Rollback(ctx)
 
+	kind := domain.AccrualBalanceActionKind
+
+	if amount.IsNegative() {
+		kind = domain.WithdrawalBalanceActionKind
+	}
+
 	query := `
-		SELECT id
-		FROM users
-		WHERE id = $1
-		FOR UPDATE
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, processed_at)
+	   VALUES ($1, $2, $3, $4, $5)
+	   RETURNING id
 	`
 
-	_, err = tx.Exec(
+	var actionID int
+
+	err = tx.QueryRow(
 		ctx,
 		query,
-		userID,
-	)
+		userID, amount, orderID, kind, time.Now().UTC(),
+	).Scan(&actionID)
+
 	if err != nil {
 		return err
 	}
 
-	query = `
-	   INSERT INTO balance_actions (user_id, amount, order_id, processed_at)
-	   VALUES ($1, $2, $3, $4)
-	`
+	var entry domain.LedgerEntry
 
-	_, err = tx.Exec(
-		ctx,
-		query,
-		userID, amount, orderID, time.Now().UTC(),
-	)
+	if amount.IsNegative() {
+		entry = domain.NewWithdrawalLedgerEntry(userID, orderID, amount.Neg())
+	} else {
+		entry = domain.NewAccrualLedgerEntry(userID, orderID, amount)
+	}
 
-	if err != nil {
+	entry.BalanceActionID = &actionID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
 		return err
 	}
 
-	if amount < 0 {
-		query = `
-			SELECT SUM(amount)
-			FROM balance_actions
-			WHERE user_id = $1
-		`
-
-		var currentBalance float64
-		if err := tx.QueryRow(ctx, query, userID).Scan(&currentBalance); err != nil {
-			return err
-		}
+	if amount.IsNegative() {
+		err = consumeAccrualLots(ctx, tx, userID, actionID, amount.Neg(), true)
+	} else {
+		err = createAccrualLot(ctx, tx, userID, actionID, orderID, amount, nil)
+	}
 
-		if currentBalance < 0 {
-			return domain.ErrInsufficientFunds
-		}
+	if err != nil {
+		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -85,85 +89,779 @@ func (r *BalanceActionsRepository) Save(ctx context.Context, userID int, orderID
 	return nil
 }
 
-func (r *BalanceActionsRepository) GetCurrentBalance(ctx context.Context, userID int) float64 {
-	var amount float64
+func (r *BalanceActionsRepository) GetCurrentBalance(ctx context.Context, userID int) domain.Money {
+	var amount domain.Money
 
 	query := `
-        SELECT SUM(amount)
-        FROM balance_actions
-        WHERE user_id = $1
+        SELECT balance
+        FROM ledger_accounts
+        WHERE type = $1 AND user_id = $2
     `
 
 	err := r.pool.QueryRow(
 		ctx,
 		query,
-		userID,
+		domain.UserPointsLedgerAccount, userID,
 	).Scan(&amount)
 
 	if err != nil {
-		return 0
+		return domain.Money{}
 	}
 
 	return amount
 }
 
-func (r *BalanceActionsRepository) GetWithdrawalAmount(ctx context.Context, userID int) float64 {
-	var amount float64
+func (r *BalanceActionsRepository) GetWithdrawalAmount(ctx context.Context, userID int) domain.Money {
+	var amount domain.Money
 
 	query := `
-        SELECT SUM(amount)
+        SELECT COALESCE(SUM(amount), 0)
         FROM balance_actions
-        WHERE user_id = $1 AND amount < 0
+        WHERE user_id = $1 AND kind IN ($2, $3) AND status = $4
     `
 
 	err := r.pool.QueryRow(
 		ctx,
 		query,
-		userID,
+		userID, domain.WithdrawalBalanceActionKind, domain.RefundBalanceActionKind, domain.ProcessedBalanceActionStatus,
 	).Scan(&amount)
 
 	if err != nil {
-		return 0
+		return domain.Money{}
 	}
 
-	return math.Abs(amount)
+	return amount.Neg()
 }
 
 func (r *BalanceActionsRepository) GetUserWithdrawals(ctx context.Context, userID int) ([]domain.BalanceAction, error) {
 	query := `
-        SELECT id, order_id, user_id, amount, created_at, processed_at
-        FROM balance_actions
-        WHERE user_id = $1 AND amount < 0
-        ORDER BY created_at DESC
+        SELECT
+            ba.id, ba.order_id, ba.user_id, ba.amount, ba.kind, ba.status, ba.created_at, ba.processed_at, ba.expires_at,
+            refund.id, refund.amount, refund.reason, refund.created_at
+        FROM balance_actions ba
+        LEFT JOIN balance_actions refund ON refund.reverses_id = ba.id AND refund.kind = $3
+        WHERE ba.user_id = $1 AND ba.kind = $2 AND ba.status = $4
+        ORDER BY ba.created_at DESC
     `
 
 	rows, err := r.pool.Query(
 		ctx,
 		query,
-		userID,
+		userID, domain.WithdrawalBalanceActionKind, domain.RefundBalanceActionKind, domain.ProcessedBalanceActionStatus,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanWithdrawals(rows)
+}
+
+func (r *BalanceActionsRepository) GetHeldAmount(ctx context.Context, userID int) domain.Money {
+	var amount domain.Money
+
+	query := `
+        SELECT balance
+        FROM ledger_accounts
+        WHERE type = $1 AND user_id = $2
+    `
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		domain.UserHeldLedgerAccount, userID,
+	).Scan(&amount)
+
+	if err != nil {
+		return domain.Money{}
+	}
+
+	return amount
+}
+
+func (r *BalanceActionsRepository) Hold(
+	ctx context.Context, userID int, orderID string, amount domain.Money, expiresAt time.Time,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	hold := domain.BalanceAction{
+		UserID:    userID,
+		Amount:    amount.Neg(),
+		OrderID:   orderID,
+		Kind:      domain.WithdrawalBalanceActionKind,
+		Status:    domain.HeldBalanceActionStatus,
+		ExpiresAt: &expiresAt,
+	}
+
+	query := `
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, status, expires_at)
+	   VALUES ($1, $2, $3, $4, $5, $6)
+	   RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		hold.UserID, hold.Amount, hold.OrderID, hold.Kind, hold.Status, hold.ExpiresAt,
+	).Scan(&hold.ID, &hold.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewHoldLedgerEntry(userID, orderID, amount)
+	entry.BalanceActionID = &hold.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := consumeAccrualLots(ctx, tx, userID, hold.ID, amount, true); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// CaptureHold turns an active hold of the user into a regular withdrawal.
+func (r *BalanceActionsRepository) CaptureHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error) {
+	return r.finishHold(ctx, &userID, holdID, domain.ProcessedBalanceActionStatus)
+}
+
+// ReleaseHold returns the points of an active hold to the user.
+func (r *BalanceActionsRepository) ReleaseHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error) {
+	return r.finishHold(ctx, &userID, holdID, domain.ReleasedBalanceActionStatus)
+}
+
+// ReleaseExpiredHolds releases up to limit holds whose expiration time has
+// passed and returns how many were released.
+func (r *BalanceActionsRepository) ReleaseExpiredHolds(ctx context.Context, limit int) (int, error) {
+	query := `
+		SELECT id
+		FROM balance_actions
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(
+		ctx,
+		query,
+		domain.HeldBalanceActionStatus, time.Now().UTC(), limit,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int, 0)
+
+	for rows.Next() {
+		var id int
+
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+
+	if rows.Err() != nil {
+		return 0, rows.Err()
+	}
+
+	released := 0
+
+	for _, id := range ids {
+		_, err := r.finishHold(ctx, nil, id, domain.ReleasedBalanceActionStatus)
+
+		if err != nil {
+			if errors.Is(err, domain.ErrHoldNotActive) {
+				continue
+			}
+
+			return released, err
+		}
+
+		released++
+	}
+
+	return released, nil
+}
+
+// finishHold captures or releases a hold. When userID is nil the owner of the
+// hold is not checked, which is what the expiration worker needs.
+func (r *BalanceActionsRepository) finishHold(
+	ctx context.Context, userID *int, holdID int, status string,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var hold domain.BalanceAction
+
+	query := `
+		SELECT id, order_id, user_id, amount, kind, status, created_at, processed_at, expires_at
+		FROM balance_actions
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		holdID,
+	).Scan(&hold.ID, &hold.OrderID, &hold.UserID, &hold.Amount, &hold.Kind, &hold.Status, &hold.CreatedAt, &hold.ProcessedAt, &hold.ExpiresAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if userID != nil && hold.UserID != *userID {
+		return nil, domain.ErrNotFound
+	}
+
+	if hold.Status != domain.HeldBalanceActionStatus {
+		return nil, domain.ErrHoldNotActive
+	}
+
+	now := time.Now().UTC()
+
+	if status == domain.ProcessedBalanceActionStatus && hold.ExpiresAt != nil && !hold.ExpiresAt.After(now) {
+		return nil, domain.ErrHoldExpired
+	}
+
+	var entry domain.LedgerEntry
+
+	if status == domain.ProcessedBalanceActionStatus {
+		entry = domain.NewCaptureLedgerEntry(hold.UserID, hold.OrderID, hold.Amount.Abs())
+	} else {
+		entry = domain.NewReleaseLedgerEntry(hold.UserID, hold.OrderID, hold.Amount.Abs())
+	}
+
+	entry.BalanceActionID = &hold.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if status == domain.ReleasedBalanceActionStatus {
+		if err := restoreLotConsumptions(ctx, tx, hold.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	query = `
+		UPDATE balance_actions
+		SET status = $1, processed_at = $2
+		WHERE id = $3
+	`
+
+	if _, err := tx.Exec(ctx, query, status, now, hold.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	hold.Status = status
+	hold.ProcessedAt = &now
+
+	return &hold, nil
+}
+
+// RefundWithdrawal gives the points of a processed withdrawal back to the user
+// by posting a compensating REFUND action linked to the original one.
+func (r *BalanceActionsRepository) RefundWithdrawal(
+	ctx context.Context, withdrawalID int, reason string,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var withdrawal domain.BalanceAction
+
+	query := `
+		SELECT id, order_id, user_id, amount, kind, status
+		FROM balance_actions
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		withdrawalID,
+	).Scan(&withdrawal.ID, &withdrawal.OrderID, &withdrawal.UserID, &withdrawal.Amount, &withdrawal.Kind, &withdrawal.Status)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if withdrawal.Kind != domain.WithdrawalBalanceActionKind || withdrawal.Status != domain.ProcessedBalanceActionStatus {
+		return nil, domain.ErrNotReversible
+	}
+
+	refund := domain.BalanceAction{
+		UserID:     withdrawal.UserID,
+		Amount:     withdrawal.Amount.Abs(),
+		OrderID:    withdrawal.OrderID,
+		Kind:       domain.RefundBalanceActionKind,
+		Status:     domain.ProcessedBalanceActionStatus,
+		ReversesID: &withdrawal.ID,
+		Reason:     &reason,
+	}
+
+	query = `
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, status, reverses_id, reason, processed_at)
+	   VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	   RETURNING id, created_at, processed_at
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		refund.UserID, refund.Amount, refund.OrderID, refund.Kind, refund.Status, refund.ReversesID, refund.Reason,
+	).Scan(&refund.ID, &refund.CreatedAt, &refund.ProcessedAt)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+			return nil, domain.ErrAlreadyReversed
+		}
+
+		return nil, err
+	}
+
+	entry := domain.NewRefundLedgerEntry(refund.UserID, refund.OrderID, refund.Amount)
+	entry.BalanceActionID = &refund.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := restoreLotConsumptions(ctx, tx, withdrawal.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// AdjustBalance credits (positive amount) or debits (negative amount) the user
+// by hand. Credited points become a lot expiring at expiresAt, debits take the
+// oldest lots first and may leave the user in debt.
+func (r *BalanceActionsRepository) AdjustBalance(
+	ctx context.Context, userID int, actorID int, amount domain.Money, reason string, expiresAt time.Time,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id
+		FROM users
+		WHERE id = $1
+		FOR SHARE
+	`
+
+	if err := tx.QueryRow(ctx, query, userID).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	adjustment := domain.BalanceAction{
+		UserID:  userID,
+		Amount:  amount,
+		Kind:    domain.AdjustmentBalanceActionKind,
+		Status:  domain.ProcessedBalanceActionStatus,
+		Reason:  &reason,
+		ActorID: &actorID,
+	}
+
+	query = `
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, status, reason, actor_id, processed_at)
+	   VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	   RETURNING id, created_at, processed_at
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		adjustment.UserID, adjustment.Amount, adjustment.OrderID, adjustment.Kind, adjustment.Status, adjustment.Reason, adjustment.ActorID,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt, &adjustment.ProcessedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewAdjustmentLedgerEntry(userID, amount)
+	entry.BalanceActionID = &adjustment.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if amount.IsNegative() {
+		err = consumeAccrualLots(ctx, tx, userID, adjustment.ID, amount.Neg(), false)
+	} else {
+		err = createAccrualLot(ctx, tx, userID, adjustment.ID, adjustment.OrderID, amount, &expiresAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
+
+// GetExpiringPoints returns the points of the user that expire before the given
+// time, grouped by day.
+func (r *BalanceActionsRepository) GetExpiringPoints(
+	ctx context.Context, userID int, before time.Time,
+) ([]domain.ExpiringPoints, error) {
+	query := `
+		SELECT date_trunc('day', expires_at) AS expires_on, SUM(remaining)
+		FROM accrual_lots
+		WHERE user_id = $1 AND remaining > 0 AND expires_at > $2 AND expires_at <= $3
+		GROUP BY expires_on
+		ORDER BY expires_on
+	`
+
+	rows, err := r.pool.Query(
+		ctx,
+		query,
+		userID, time.Now().UTC(), before,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
+	result := make([]domain.ExpiringPoints, 0)
+
+	for rows.Next() {
+		var points domain.ExpiringPoints
+
+		if err := rows.Scan(&points.ExpiresAt, &points.Amount); err != nil {
+			return nil, err
+		}
+
+		result = append(result, points)
+	}
+
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
 
-	result := make([]domain.BalanceAction, 0)
+	return result, nil
+}
+
+// ExpireAccrualLots writes off up to limit lots whose expiration time has
+// passed and returns how many were written off.
+func (r *BalanceActionsRepository) ExpireAccrualLots(ctx context.Context, limit int) (int, error) {
+	query := `
+		SELECT id
+		FROM accrual_lots
+		WHERE remaining > 0 AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(
+		ctx,
+		query,
+		time.Now().UTC(), limit,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int, 0)
 
 	for rows.Next() {
-		var bw domain.BalanceAction
+		var id int
 
-		if err := rows.Scan(&bw.ID, &bw.OrderID, &bw.UserID, &bw.Amount, &bw.CreatedAt, &bw.ProcessedAt); err != nil {
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+
+	if rows.Err() != nil {
+		return 0, rows.Err()
+	}
+
+	expired := 0
+
+	for _, id := range ids {
+		ok, err := r.expireAccrualLot(ctx, id)
+
+		if err != nil {
+			return expired, err
+		}
+
+		if ok {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// expireAccrualLot posts an EXPIRY action for whatever is left in the lot. It
+// reports false when the lot was emptied concurrently.
+func (r *BalanceActionsRepository) expireAccrualLot(ctx context.Context, lotID int) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var lot domain.AccrualLot
+
+	query := `
+		SELECT id, user_id, order_id, remaining
+		FROM accrual_lots
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		lotID,
+	).Scan(&lot.ID, &lot.UserID, &lot.OrderID, &lot.Remaining)
+
+	if err != nil {
+		return false, err
+	}
+
+	if !lot.Remaining.IsPositive() {
+		return false, nil
+	}
+
+	if err := postLotExpiry(ctx, tx, lot); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetUserWithdrawalsPage returns one keyset page of the user processed
+// withdrawals. The status filter is either PROCESSED (not refunded) or
+// REFUNDED.
+func (r *BalanceActionsRepository) GetUserWithdrawalsPage(
+	ctx context.Context, userID int, filter domain.HistoryFilter,
+) (*domain.BalanceActionsPage, error) {
+	historyQuery := newHistoryQuery(domain.RefundBalanceActionKind)
+	historyQuery.where("ba.user_id = %s", userID)
+	historyQuery.where("ba.kind = %s", domain.WithdrawalBalanceActionKind)
+	historyQuery.where("ba.status = %s", domain.ProcessedBalanceActionStatus)
+
+	switch filter.Status {
+	case domain.ProcessedBalanceActionStatus:
+		historyQuery.where("refund.id IS NULL")
+	case domain.RefundedWithdrawalStatus:
+		historyQuery.where("refund.id IS NOT NULL")
+	}
+
+	var cursorKey int
+
+	if filter.Cursor != nil {
+		var err error
+
+		cursorKey, err = strconv.Atoi(filter.Cursor.Key)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	query, args := historyQuery.build(`
+		SELECT
+		    ba.id, ba.order_id, ba.user_id, ba.amount, ba.kind, ba.status, ba.created_at, ba.processed_at, ba.expires_at,
+		    refund.id, refund.amount, refund.reason, refund.created_at
+		FROM balance_actions ba
+		LEFT JOIN balance_actions refund ON refund.reverses_id = ba.id AND refund.kind = $1`,
+		"ba.created_at", "ba.id", cursorKey, filter,
+	)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	withdrawals, err := scanWithdrawals(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.BalanceActionsPage{Items: withdrawals}
+
+	if len(withdrawals) > filter.Limit {
+		page.Items = withdrawals[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		nextCursor := domain.PageCursor{Time: last.CreatedAt, Key: strconv.Itoa(last.ID)}.Encode()
+		page.NextCursor = &nextCursor
+	}
+
+	return &page, nil
+}
+
+// scanWithdrawals reads withdrawal rows joined with their optional refund.
+func scanWithdrawals(rows pgx.Rows) ([]domain.BalanceAction, error) {
+	result := make([]domain.BalanceAction, 0)
+	for rows.Next() {
+		var bw domain.BalanceAction
+		var refundID *int
+		var refundAmount *domain.Money
+		var refundReason *string
+		var refundCreatedAt *time.Time
+
+		err := rows.Scan(
+			&bw.ID, &bw.OrderID, &bw.UserID, &bw.Amount, &bw.Kind, &bw.Status, &bw.CreatedAt, &bw.ProcessedAt, &bw.ExpiresAt,
+			&refundID, &refundAmount, &refundReason, &refundCreatedAt,
+		)
+		if err != nil {
 			return nil, err
 		}
 
-		bw.Amount = math.Abs(bw.Amount)
+		bw.Amount = bw.Amount.Abs()
+
+		if refundID != nil {
+			bw.ReversedBy = &domain.BalanceAction{
+				ID:          *refundID,
+				UserID:      bw.UserID,
+				Amount:      *refundAmount,
+				OrderID:     bw.OrderID,
+				Kind:        domain.RefundBalanceActionKind,
+				Status:      domain.ProcessedBalanceActionStatus,
+				ReversesID:  &bw.ID,
+				Reason:      refundReason,
+				CreatedAt:   *refundCreatedAt,
+				ProcessedAt: refundCreatedAt,
+			}
+		}
 
 		result = append(result, bw)
 	}
 
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
 	return result, nil
 }
+
+// StreamStatement walks the postings of the user's spendable points account in
+// chronological order and calls fn for every line between from (inclusive) and
+// to (exclusive). The running balance covers the whole history, so the first
+// line already carries the correct balance. Rows are read one by one and never
+// collected in memory.
+func (r *BalanceActionsRepository) StreamStatement(
+	ctx context.Context,
+	userID int,
+	from *time.Time,
+	to *time.Time,
+	fn func(line domain.StatementLine) error,
+) error {
+	query := `
+		SELECT entry_id, kind, order_id, amount, balance, created_at
+		FROM (
+		    SELECT
+		        e.id AS entry_id, e.kind, e.order_id, p.amount, p.created_at,
+		        SUM(p.amount) OVER (ORDER BY p.id) AS balance,
+		        p.id AS posting_id
+		    FROM ledger_postings p
+		    JOIN ledger_entries e ON e.id = p.entry_id
+		    JOIN ledger_accounts a ON a.id = p.account_id
+		    WHERE a.type = $1 AND a.user_id = $2
+		) statement
+		WHERE ($3::timestamp IS NULL OR created_at >= $3) AND ($4::timestamp IS NULL OR created_at < $4)
+		ORDER BY posting_id
+	`
+
+	rows, err := r.pool.Query(
+		ctx,
+		query,
+		domain.UserPointsLedgerAccount, userID, from, to,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var line domain.StatementLine
+
+		if err := rows.Scan(&line.EntryID, &line.Kind, &line.OrderID, &line.Amount, &line.Balance, &line.CreatedAt); err != nil {
+			return err
+		}
+
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
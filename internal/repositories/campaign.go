@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+const campaignColumns = `
+	id, merchant_id, name, valid_from, valid_to, disabled, created_at, updated_at
+`
+
+type CampaignRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCampaignRepository(pool *pgxpool.Pool) *CampaignRepository {
+	repo := CampaignRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *CampaignRepository) Create(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error) {
+	var created domain.Campaign
+
+	query := `
+		INSERT INTO campaigns (merchant_id, name, valid_from, valid_to, disabled)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + campaignColumns
+
+	err := scanCampaign(r.pool.QueryRow(
+		ctx,
+		query,
+		campaign.MerchantID, campaign.Name, utcTime(campaign.ValidFrom), utcTime(campaign.ValidTo), campaign.Disabled,
+	), &created)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrCampaignNameTaken
+		}
+
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (r *CampaignRepository) GetByID(ctx context.Context, merchantID int, id int) (*domain.Campaign, error) {
+	var campaign domain.Campaign
+
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		WHERE merchant_id = $1 AND id = $2
+	`
+
+	err := scanCampaign(r.pool.QueryRow(
+		ctx,
+		query,
+		merchantID, id,
+	), &campaign)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+func (r *CampaignRepository) List(ctx context.Context, merchantID int) ([]domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		WHERE merchant_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.pool.Query(ctx, query, merchantID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	campaigns := make([]domain.Campaign, 0)
+
+	for rows.Next() {
+		var campaign domain.Campaign
+
+		if err := scanCampaign(rows, &campaign); err != nil {
+			return nil, err
+		}
+
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// Update applies the non-nil fields of update. Zero times are stored as NULL.
+func (r *CampaignRepository) Update(
+	ctx context.Context, merchantID int, id int, update domain.CampaignUpdate,
+) (*domain.Campaign, error) {
+	var campaign domain.Campaign
+
+	query := `
+		UPDATE campaigns
+		SET name = COALESCE($3, name),
+			valid_from = CASE WHEN $4::boolean THEN $5::timestamp ELSE valid_from END,
+			valid_to = CASE WHEN $6::boolean THEN $7::timestamp ELSE valid_to END,
+			disabled = COALESCE($8, disabled),
+			updated_at = NOW()
+		WHERE merchant_id = $1 AND id = $2
+		RETURNING ` + campaignColumns
+
+	err := scanCampaign(r.pool.QueryRow(
+		ctx,
+		query,
+		merchantID, id, update.Name,
+		update.ValidFrom != nil, utcTime(update.ValidFrom),
+		update.ValidTo != nil, utcTime(update.ValidTo),
+		update.Disabled,
+	), &campaign)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		if isUniqueViolation(err) {
+			return nil, domain.ErrCampaignNameTaken
+		}
+
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+func scanCampaign(row pgx.Row, campaign *domain.Campaign) error {
+	return row.Scan(
+		&campaign.ID,
+		&campaign.MerchantID,
+		&campaign.Name,
+		&campaign.ValidFrom,
+		&campaign.ValidTo,
+		&campaign.Disabled,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+	)
+}
@@ -3,8 +3,10 @@ package repositories
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -12,6 +14,16 @@ import (
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
 )
 
+// goodRewardStateColumns are the columns of a rule that good_reward_versions
+// keeps a copy of for every version.
+const goodRewardStateColumns = `
+	merchant_id, match, match_type, match_field, ignore_case, reward, reward_type, min_basket, tiers, priority, stacking,
+	max_per_good, max_per_order, campaign_id, valid_from, valid_to, schedule, disabled, version, created_at, updated_at,
+	deleted_at
+`
+
+const goodRewardColumns = `id, ` + goodRewardStateColumns
+
 type GoodRewardRepository struct {
 	pool *pgxpool.Pool
 }
@@ -24,78 +36,800 @@ func NewGoodRewardRepository(pool *pgxpool.Pool) *GoodRewardRepository {
 	return &repo
 }
 
-func (r *GoodRewardRepository) GetRewardsWithMatches(ctx context.Context, descriptions []string) ([]domain.GoodReward, error) {
+// GetRewardsWithMatches returns the rules that may apply to at least one of
+// the goods of a purchase made at, compared by each rule's match field. Every
+// rule is taken as its latest version effective at asOf, which is at for the
+// purchase itself, so edits made after it do not change what it earns.
+// Disabled and deleted rules never apply, nor do rules outside their validity
+// window or whose campaign is not active at that moment. Substring, exact and
+// prefix rules are matched here the same way domain.NewMatcher does it, rules
+// that ignore case against their match folded by domain.FoldCase; word
+// rules are only checked as substrings, and regex rules and order rules are
+// all returned, so the caller must still run each rule's domain.Matcher.
+// Schedules are left to the caller as well.
+func (r *GoodRewardRepository) GetRewardsWithMatches(
+	ctx context.Context, merchantID int, goods []domain.OrderGood, at time.Time, asOf time.Time,
+) ([]domain.GoodReward, error) {
+	descriptions := make([]string, len(goods))
+	skus := make([]string, len(goods))
+	categories := make([]string, len(goods))
+	foldedDescriptions := make([]string, len(goods))
+	foldedSKUs := make([]string, len(goods))
+	foldedCategories := make([]string, len(goods))
+
+	for i := range goods {
+		descriptions[i] = goods[i].Description
+		skus[i] = goods[i].SKU
+		categories[i] = goods[i].Category
+		foldedDescriptions[i] = domain.FoldCase(goods[i].Description)
+		foldedSKUs[i] = domain.FoldCase(goods[i].SKU)
+		foldedCategories[i] = domain.FoldCase(goods[i].Category)
+	}
+
 	query := `
-        SELECT id, match, reward, reward_type, created_at
-        FROM good_rewards
-        WHERE EXISTS (
-            SELECT 1
-            FROM unnest($1::text[]) AS element
-            WHERE element LIKE '%' || good_rewards.match || '%'
+        WITH rules AS (
+            SELECT DISTINCT ON (rule_id) rule_id AS id, ` + goodRewardStateColumns + `, match_folded
+            FROM good_reward_versions
+            WHERE merchant_id = $1 AND effective_at <= $15
+            ORDER BY rule_id, version DESC
         )
+        SELECT ` + goodRewardColumns + `
+        FROM rules
+        WHERE deleted_at IS NULL AND NOT disabled
+            AND (valid_from IS NULL OR valid_from <= $6) AND (valid_to IS NULL OR valid_to > $6)
+            AND (campaign_id IS NULL OR EXISTS (
+                SELECT 1
+                FROM campaigns
+                WHERE campaigns.id = rules.campaign_id AND NOT campaigns.disabled
+                    AND (campaigns.valid_from IS NULL OR campaigns.valid_from <= $6)
+                    AND (campaigns.valid_to IS NULL OR campaigns.valid_to > $6)
+            ))
+            AND (match_type = $3 OR reward_type <> ALL($7::text[])
+                OR (ignore_case AND match_folded IS NULL) OR EXISTS (
+                SELECT 1
+                FROM (
+                    SELECT
+                        CASE WHEN rules.ignore_case THEN folded ELSE element END AS d,
+                        CASE WHEN rules.ignore_case THEN rules.match_folded ELSE rules.match END AS m
+                    FROM unnest(
+                        CASE rules.match_field
+                            WHEN $8 THEN $9::text[]
+                            WHEN $10 THEN $11::text[]
+                            ELSE $2::text[]
+                        END,
+                        CASE rules.match_field
+                            WHEN $8 THEN $13::text[]
+                            WHEN $10 THEN $14::text[]
+                            ELSE $12::text[]
+                        END
+                    ) AS goods (element, folded)
+                ) AS descriptions
+                WHERE CASE rules.match_type
+                    WHEN $4 THEN d = m
+                    WHEN $5 THEN left(d, length(m)) = m
+                    ELSE strpos(d, m) > 0
+                END
+            ))
     `
 
 	rows, err := r.pool.Query(
 		ctx,
 		query,
-		descriptions,
+		merchantID, descriptions, domain.RegexMatchType, domain.ExactMatchType, domain.PrefixMatchType, at.UTC(),
+		[]string{domain.PercentRewardType, domain.PointRewardType},
+		domain.SKUMatchField, skus, domain.CategoryMatchField, categories,
+		foldedDescriptions, foldedSKUs, foldedCategories, asOf.UTC(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rewards := make([]domain.GoodReward, 0)
+
+	for rows.Next() {
+		var reward domain.GoodReward
+
+		if err := scanGoodReward(rows, &reward); err != nil {
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return rewards, nil
+}
+
+// SaveReward creates a rule and keeps it as its first version.
+func (r *GoodRewardRepository) SaveReward(ctx context.Context, reward domain.GoodReward) (*domain.GoodReward, error) {
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	goodReward, err := insertGoodReward(ctx, tx, reward)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return goodReward, nil
+}
+
+func (r *GoodRewardRepository) GetByID(ctx context.Context, merchantID int, id int) (*domain.GoodReward, error) {
+	var reward domain.GoodReward
+
+	query := `
+        SELECT ` + goodRewardColumns + `
+        FROM good_rewards
+        WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL
+    `
+
+	err := scanGoodReward(r.pool.QueryRow(
+		ctx,
+		query,
+		merchantID, id,
+	), &reward)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+func (r *GoodRewardRepository) GetPage(
+	ctx context.Context, merchantID int, filter domain.GoodRewardsFilter,
+) (*domain.GoodRewardsPage, error) {
+	historyQuery := newHistoryQuery()
+	historyQuery.where("merchant_id = %s", merchantID)
+	historyQuery.where("deleted_at IS NULL")
+
+	switch filter.Status {
+	case domain.ActiveGoodRewardStatus:
+		historyQuery.where("NOT disabled")
+	case domain.DisabledGoodRewardStatus:
+		historyQuery.where("disabled")
+	}
+
+	if filter.Search != "" {
+		historyQuery.where("strpos(lower(match), lower(%s)) > 0", filter.Search)
+	}
+
+	if filter.CampaignID != nil {
+		historyQuery.where("campaign_id = %s", *filter.CampaignID)
+	}
+
+	var cursorKey int
+
+	if filter.Cursor != nil {
+		var err error
+
+		cursorKey, err = strconv.Atoi(filter.Cursor.Key)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	query, args := historyQuery.build(`
+		SELECT `+goodRewardColumns+`
+		FROM good_rewards`,
+		"created_at", "id", cursorKey, filter.HistoryFilter,
 	)
 
+	rows, err := r.pool.Query(ctx, query, args...)
+
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
+	rewards := make([]domain.GoodReward, 0)
+
+	for rows.Next() {
+		var reward domain.GoodReward
+
+		if err := scanGoodReward(rows, &reward); err != nil {
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
 
+	page := domain.GoodRewardsPage{Items: rewards}
+
+	if len(rewards) > filter.Limit {
+		page.Items = rewards[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		nextCursor := domain.PageCursor{Time: last.CreatedAt, Key: strconv.Itoa(last.ID)}.Encode()
+		page.NextCursor = &nextCursor
+	}
+
+	return &page, nil
+}
+
+// GetAll returns every rule of the merchant that is not deleted, by id.
+func (r *GoodRewardRepository) GetAll(ctx context.Context, merchantID int) ([]domain.GoodReward, error) {
+	query := `
+        SELECT ` + goodRewardColumns + `
+        FROM good_rewards
+        WHERE merchant_id = $1 AND deleted_at IS NULL
+        ORDER BY id
+    `
+
+	rows, err := r.pool.Query(ctx, query, merchantID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
 	rewards := make([]domain.GoodReward, 0)
 
 	for rows.Next() {
 		var reward domain.GoodReward
 
-		if err := rows.Scan(&reward.ID, &reward.Match, &reward.Reward, &reward.RewardType, &reward.CreatedAt); err != nil {
+		if err := scanGoodReward(rows, &reward); err != nil {
 			return nil, err
 		}
 
 		rewards = append(rewards, reward)
 	}
 
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
 	return rewards, nil
 }
 
-func (r *GoodRewardRepository) SaveReward(
-	ctx context.Context, match string, reward float64, rewardType string,
+// Update applies the non-nil fields of update to a rule that is not deleted,
+// and keeps the result as the next version of the rule.
+// Zero caps, minimum baskets, campaign ids, times and schedules, and empty
+// tiers, are stored as NULL.
+func (r *GoodRewardRepository) Update(
+	ctx context.Context, merchantID int, id int, update domain.GoodRewardUpdate,
 ) (*domain.GoodReward, error) {
-	var insertedID int64
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	reward, err := updateGoodReward(ctx, tx, merchantID, id, update)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return reward, nil
+}
+
+// Delete marks a rule as deleted, which is kept as its last version. The row
+// itself stays for the calculations that used it.
+func (r *GoodRewardRepository) Delete(ctx context.Context, merchantID int, id int) error {
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE good_rewards
+        SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
+        WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL
+    `
+
+	tag, err := tx.Exec(
+		ctx,
+		query,
+		merchantID, id,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := saveGoodRewardVersion(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ImportRewards creates or replaces the rules in one transaction, each in a
+// savepoint of its own so a failed rule does not stop the others from being
+// tried. A rule with an id replaces that rule. One without is matched with
+// the rule that has the same match field, match, match type and ignore case,
+// among per-good rules or order rules depending on its type; it is created
+// when there is none. Rules that would not change keep their version.
+// Nothing is saved for dry runs or when a rule failed, and the results are
+// what would have been done. Failures that are about a rule are reported in
+// its result, the error is for the others.
+func (r *GoodRewardRepository) ImportRewards(
+	ctx context.Context, merchantID int, rewards []domain.GoodReward, dryRun bool,
+) ([]domain.GoodRewardImportResult, error) {
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	results := make([]domain.GoodRewardImportResult, len(rewards))
+	failed := false
+
+	for i, reward := range rewards {
+		reward.MerchantID = merchantID
+
+		savepoint, err := tx.Begin(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		results[i], err = importGoodReward(ctx, savepoint, reward)
+
+		if err == nil {
+			err = savepoint.Commit(ctx)
+		}
+
+		if err != nil {
+			if rollbackErr := savepoint.Rollback(ctx); rollbackErr != nil {
+				return nil, rollbackErr
+			}
+
+			if !errors.Is(err, domain.ErrNotFound) && !errors.Is(err, domain.ErrMatchKeyAlreadyExists) &&
+				!errors.Is(err, domain.ErrUnknownCampaign) && !errors.Is(err, domain.ErrAmbiguousMatch) {
+				return nil, err
+			}
+
+			if errors.Is(err, domain.ErrNotFound) {
+				err = errors.New("good reward not found")
+			}
+
+			failed = true
+			results[i] = domain.GoodRewardImportResult{Action: domain.FailedImportAction, Error: err.Error()}
+		}
+	}
+
+	if dryRun || failed {
+		return results, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetVersions returns every version of a rule, deleted ones included, oldest
+// first.
+func (r *GoodRewardRepository) GetVersions(
+	ctx context.Context, merchantID int, id int,
+) ([]domain.GoodRewardVersion, error) {
+	query := `
+        SELECT rule_id, ` + goodRewardStateColumns + `, effective_at
+        FROM good_reward_versions
+        WHERE merchant_id = $1 AND rule_id = $2
+        ORDER BY version
+    `
+
+	rows, err := r.pool.Query(ctx, query, merchantID, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := make([]domain.GoodRewardVersion, 0)
+
+	for rows.Next() {
+		var version domain.GoodRewardVersion
+
+		if err := scanGoodReward(rows, &version.GoodReward, &version.EffectiveAt); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if len(versions) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return versions, nil
+}
+
+// GetVersion returns one version of a rule, deleted or not.
+func (r *GoodRewardRepository) GetVersion(
+	ctx context.Context, merchantID int, id int, version int,
+) (*domain.GoodRewardVersion, error) {
+	var rewardVersion domain.GoodRewardVersion
 
 	query := `
-        INSERT INTO good_rewards (match, reward, reward_type)
-        VALUES ($1, $2, $3)
-        RETURNING id
+        SELECT rule_id, ` + goodRewardStateColumns + `, effective_at
+        FROM good_reward_versions
+        WHERE merchant_id = $1 AND rule_id = $2 AND version = $3
     `
 
-	err := r.pool.QueryRow(
+	err := scanGoodReward(
+		r.pool.QueryRow(ctx, query, merchantID, id, version),
+		&rewardVersion.GoodReward, &rewardVersion.EffectiveAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &rewardVersion, nil
+}
+
+// insertGoodReward creates a rule in tx and keeps it as its first version.
+func insertGoodReward(ctx context.Context, tx pgx.Tx, reward domain.GoodReward) (*domain.GoodReward, error) {
+	var goodReward domain.GoodReward
+
+	query := `
+        INSERT INTO good_rewards (
+            merchant_id, match, match_type, match_field, ignore_case, reward, reward_type, min_basket, tiers,
+            priority, stacking, max_per_good, max_per_order, campaign_id, valid_from, valid_to, schedule, disabled,
+            match_folded
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+        RETURNING ` + goodRewardColumns
+
+	err := scanGoodReward(tx.QueryRow(
+		ctx,
+		query,
+		reward.MerchantID, reward.Match, reward.MatchType, reward.MatchField, reward.IgnoreCase, reward.Reward,
+		reward.RewardType,
+		reward.MinBasket, tiersValue(reward.Tiers), reward.Priority, reward.Stacking, reward.MaxPerGood, reward.MaxPerOrder,
+		reward.CampaignID, utcTime(reward.ValidFrom), utcTime(reward.ValidTo), reward.Schedule, reward.Disabled,
+		domain.FoldCase(reward.Match),
+	), &goodReward)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrMatchKeyAlreadyExists
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, domain.ErrUnknownCampaign
+		}
+
+		return nil, err
+	}
+
+	if err := saveGoodRewardVersion(ctx, tx, goodReward.ID); err != nil {
+		return nil, err
+	}
+
+	return &goodReward, nil
+}
+
+// updateGoodReward changes a rule in tx, see Update.
+func updateGoodReward(
+	ctx context.Context, tx pgx.Tx, merchantID int, id int, update domain.GoodRewardUpdate,
+) (*domain.GoodReward, error) {
+	var reward domain.GoodReward
+
+	var schedule *domain.Schedule
+
+	if update.Schedule != nil && !update.Schedule.IsZero() {
+		schedule = update.Schedule
+	}
+
+	var tiers any
+
+	if update.Tiers != nil {
+		tiers = tiersValue(*update.Tiers)
+	}
+
+	var foldedMatch *string
+
+	if update.Match != nil {
+		folded := domain.FoldCase(*update.Match)
+		foldedMatch = &folded
+	}
+
+	query := `
+        UPDATE good_rewards
+        SET match = COALESCE($3, match),
+            match_folded = COALESCE($24, match_folded),
+            match_type = COALESCE($4, match_type),
+            ignore_case = COALESCE($5, ignore_case),
+            reward = COALESCE($6, reward),
+            reward_type = COALESCE($7, reward_type),
+            priority = COALESCE($8, priority),
+            stacking = COALESCE($9, stacking),
+            max_per_good = CASE WHEN $10::numeric IS NULL THEN max_per_good ELSE NULLIF($10::numeric, 0) END,
+            max_per_order = CASE WHEN $11::numeric IS NULL THEN max_per_order ELSE NULLIF($11::numeric, 0) END,
+            disabled = COALESCE($12, disabled),
+            campaign_id = CASE WHEN $13::int IS NULL THEN campaign_id ELSE NULLIF($13::int, 0) END,
+            valid_from = CASE WHEN $14::boolean THEN $15::timestamp ELSE valid_from END,
+            valid_to = CASE WHEN $16::boolean THEN $17::timestamp ELSE valid_to END,
+            schedule = CASE WHEN $18::boolean THEN $19::jsonb ELSE schedule END,
+            min_basket = CASE WHEN $20::numeric IS NULL THEN min_basket ELSE NULLIF($20::numeric, 0) END,
+            tiers = CASE WHEN $21::boolean THEN $22::jsonb ELSE tiers END,
+            match_field = COALESCE($23, match_field),
+            version = version + 1,
+            updated_at = NOW()
+        WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL
+        RETURNING ` + goodRewardColumns
+
+	err := scanGoodReward(tx.QueryRow(
 		ctx,
 		query,
-		match, reward, rewardType,
-	).Scan(&insertedID)
+		merchantID, id, update.Match, update.MatchType, update.IgnoreCase, update.Reward, update.RewardType,
+		update.Priority, update.Stacking, update.MaxPerGood, update.MaxPerOrder, update.Disabled,
+		update.CampaignID,
+		update.ValidFrom != nil, utcTime(update.ValidFrom),
+		update.ValidTo != nil, utcTime(update.ValidTo),
+		update.Schedule != nil, schedule,
+		update.MinBasket,
+		update.Tiers != nil, tiers,
+		update.MatchField,
+		foldedMatch,
+	), &reward)
 
 	if err != nil {
-		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
 
-		if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+		if isUniqueViolation(err) {
 			return nil, domain.ErrMatchKeyAlreadyExists
 		}
 
+		if isForeignKeyViolation(err) {
+			return nil, domain.ErrUnknownCampaign
+		}
+
+		return nil, err
+	}
+
+	if err := saveGoodRewardVersion(ctx, tx, reward.ID); err != nil {
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+// importGoodReward creates or replaces reward in tx, see ImportRewards.
+func importGoodReward(
+	ctx context.Context, tx pgx.Tx, reward domain.GoodReward,
+) (domain.GoodRewardImportResult, error) {
+	var result domain.GoodRewardImportResult
+
+	existing, err := findImportedGoodReward(ctx, tx, reward)
+
+	if errors.Is(err, domain.ErrNotFound) && reward.ID == 0 {
+		created, err := insertGoodReward(ctx, tx, reward)
+
+		if err != nil {
+			return result, err
+		}
+
+		return domain.GoodRewardImportResult{Action: domain.CreatedImportAction, RuleID: created.ID}, nil
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	update := reward.Replacement()
+	replaced := *existing
+	update.Apply(&replaced)
+
+	diff, err := domain.NewGoodRewardDiff(
+		domain.GoodRewardVersion{GoodReward: *existing},
+		domain.GoodRewardVersion{GoodReward: replaced},
+	)
+
+	if err != nil {
+		return result, err
+	}
+
+	if len(diff.Changes) == 0 {
+		return domain.GoodRewardImportResult{Action: domain.UnchangedImportAction, RuleID: existing.ID}, nil
+	}
+
+	if _, err := updateGoodReward(ctx, tx, reward.MerchantID, existing.ID, update); err != nil {
+		return result, err
+	}
+
+	return domain.GoodRewardImportResult{Action: domain.UpdatedImportAction, RuleID: existing.ID}, nil
+}
+
+// findImportedGoodReward locks the rule an imported rule replaces, by its id
+// or by its match.
+func findImportedGoodReward(ctx context.Context, tx pgx.Tx, reward domain.GoodReward) (*domain.GoodReward, error) {
+	var rows pgx.Rows
+	var err error
+
+	if reward.ID != 0 {
+		query := `
+            SELECT ` + goodRewardColumns + `
+            FROM good_rewards
+            WHERE merchant_id = $1 AND id = $2 AND deleted_at IS NULL
+            FOR UPDATE
+        `
+
+		rows, err = tx.Query(ctx, query, reward.MerchantID, reward.ID)
+	} else {
+		query := `
+            SELECT ` + goodRewardColumns + `
+            FROM good_rewards
+            WHERE merchant_id = $1 AND deleted_at IS NULL
+                AND match_field = $2 AND match = $3 AND match_type = $4 AND ignore_case = $5
+                AND (reward_type = ANY($6::text[])) = $7
+            ORDER BY id
+            LIMIT 2
+            FOR UPDATE
+        `
+
+		rows, err = tx.Query(
+			ctx,
+			query,
+			reward.MerchantID, reward.MatchField, reward.Match, reward.MatchType, reward.IgnoreCase,
+			[]string{domain.PercentRewardType, domain.PointRewardType}, !domain.IsOrderRewardType(reward.RewardType),
+		)
+	}
+
+	if err != nil {
 		return nil, err
 	}
 
-	return &domain.GoodReward{
-		ID:         int(insertedID),
-		Match:      match,
-		Reward:     reward,
-		RewardType: rewardType,
-		CreatedAt:  time.Now().UTC(),
-	}, nil
+	defer rows.Close()
+
+	found := make([]domain.GoodReward, 0, 1)
+
+	for rows.Next() {
+		var existing domain.GoodReward
+
+		if err := scanGoodReward(rows, &existing); err != nil {
+			return nil, err
+		}
+
+		found = append(found, existing)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, domain.ErrNotFound
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, domain.ErrAmbiguousMatch
+	}
+}
+
+// saveGoodRewardVersion copies the rule as it is in tx to
+// good_reward_versions. It is effective from its last change.
+func saveGoodRewardVersion(ctx context.Context, tx pgx.Tx, id int) error {
+	query := `
+        INSERT INTO good_reward_versions (rule_id, effective_at, match_folded, ` + goodRewardStateColumns + `)
+        SELECT id, COALESCE(deleted_at, updated_at), match_folded, ` + goodRewardStateColumns + `
+        FROM good_rewards
+        WHERE id = $1
+    `
+
+	_, err := tx.Exec(ctx, query, id)
+
+	return err
+}
+
+// scanGoodReward scans the goodRewardColumns of row into reward, and the
+// columns that follow them into extra.
+func scanGoodReward(row pgx.Row, reward *domain.GoodReward, extra ...any) error {
+	dest := []any{
+		&reward.ID,
+		&reward.MerchantID,
+		&reward.Match,
+		&reward.MatchType,
+		&reward.MatchField,
+		&reward.IgnoreCase,
+		&reward.Reward,
+		&reward.RewardType,
+		&reward.MinBasket,
+		&reward.Tiers,
+		&reward.Priority,
+		&reward.Stacking,
+		&reward.MaxPerGood,
+		&reward.MaxPerOrder,
+		&reward.CampaignID,
+		&reward.ValidFrom,
+		&reward.ValidTo,
+		&reward.Schedule,
+		&reward.Disabled,
+		&reward.Version,
+		&reward.CreatedAt,
+		&reward.UpdatedAt,
+		&reward.DeletedAt,
+	}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == postgresql.PgForeignKeyErrorCode
+}
+
+// tiersValue stores no tiers as NULL rather than an empty JSON array.
+func tiersValue(tiers []domain.RewardTier) any {
+	if len(tiers) == 0 {
+		return nil
+	}
+
+	return tiers
+}
+
+// utcTime prepares t for a TIMESTAMP column, which keeps no time zone. A zero
+// time is stored as NULL.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	utc := t.UTC()
+
+	return &utc
 }
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// postLedgerEntry writes a balanced entry inside an existing transaction and
+// updates the running balance of every touched user account. Accounts are
+// locked in a stable order so concurrent entries can not deadlock each other.
+//
+// System accounts are shared by every user, so locking them would make every
+// entry wait for the others to commit. They keep no running balance and are
+// never locked: their balance is the sum of their postings.
+func postLedgerEntry(ctx context.Context, tx pgx.Tx, entry domain.LedgerEntry) (int, error) {
+	if !entry.IsBalanced() {
+		return 0, domain.ErrUnbalancedLedgerEntry
+	}
+
+	postings := make([]domain.LedgerPosting, len(entry.Postings))
+	copy(postings, entry.Postings)
+
+	sort.SliceStable(postings, func(i, j int) bool {
+		if postings[i].AccountType != postings[j].AccountType {
+			return postings[i].AccountType < postings[j].AccountType
+		}
+
+		return postings[i].UserID < postings[j].UserID
+	})
+
+	var entryID int
+
+	query := `
+		INSERT INTO ledger_entries (kind, order_id, balance_action_id)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	err := tx.QueryRow(
+		ctx,
+		query,
+		entry.Kind, entry.OrderID, entry.BalanceActionID,
+	).Scan(&entryID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, posting := range postings {
+		query = `
+			INSERT INTO ledger_accounts (type, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (type, user_id) DO NOTHING
+		`
+
+		if _, err := tx.Exec(ctx, query, posting.AccountType, posting.UserID); err != nil {
+			return 0, err
+		}
+
+		var accountID int
+
+		if posting.UserID == domain.SystemLedgerOwnerID {
+			query = `
+				SELECT id
+				FROM ledger_accounts
+				WHERE type = $1 AND user_id = $2
+			`
+
+			err := tx.QueryRow(ctx, query, posting.AccountType, posting.UserID).Scan(&accountID)
+
+			if err != nil {
+				return 0, err
+			}
+		} else {
+			query = `
+				UPDATE ledger_accounts
+				SET balance = balance + $1, updated_at = NOW()
+				WHERE type = $2 AND user_id = $3
+				RETURNING id, balance
+			`
+
+			var balance domain.Money
+
+			err := tx.QueryRow(
+				ctx,
+				query,
+				posting.Amount, posting.AccountType, posting.UserID,
+			).Scan(&accountID, &balance)
+
+			if err != nil {
+				return 0, err
+			}
+
+			if posting.NoOverdraft && balance.IsNegative() {
+				return 0, domain.ErrInsufficientFunds
+			}
+		}
+
+		query = `
+			INSERT INTO ledger_postings (entry_id, account_id, amount)
+			VALUES ($1, $2, $3)
+		`
+
+		if _, err := tx.Exec(ctx, query, entryID, accountID, posting.Amount); err != nil {
+			return 0, err
+		}
+	}
+
+	return entryID, nil
+}
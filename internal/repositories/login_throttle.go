@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type LoginThrottleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLoginThrottleRepository(pool *pgxpool.Pool) *LoginThrottleRepository {
+	repo := LoginThrottleRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *LoginThrottleRepository) Get(ctx context.Context, scope string, key string) (*domain.LoginThrottle, error) {
+	var throttle domain.LoginThrottle
+
+	query := `
+		SELECT scope, key, failures, last_failed_at, blocked_until
+		FROM login_throttles
+		WHERE scope = $1 AND key = $2
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		scope, key,
+	).Scan(&throttle.Scope, &throttle.Key, &throttle.Failures, &throttle.LastFailedAt, &throttle.BlockedUntil)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &throttle, nil
+}
+
+// RecordFailure counts one more failure and returns the new count. A counter
+// whose last failure is older than windowStart starts over from one.
+func (r *LoginThrottleRepository) RecordFailure(
+	ctx context.Context, scope string, key string, now time.Time, windowStart time.Time,
+) (int, error) {
+	var failures int
+
+	query := `
+		INSERT INTO login_throttles (scope, key, failures, last_failed_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET failures = CASE WHEN login_throttles.last_failed_at < $4 THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failed_at = $3
+		RETURNING failures
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		scope, key, now, windowStart,
+	).Scan(&failures)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+func (r *LoginThrottleRepository) Block(ctx context.Context, scope string, key string, until time.Time) error {
+	query := `
+		UPDATE login_throttles
+		SET blocked_until = $1
+		WHERE scope = $2 AND key = $3
+	`
+
+	_, err := r.pool.Exec(ctx, query, until, scope, key)
+
+	return err
+}
+
+// Clear forgets every failure of the scope, lifting any backoff or lockout.
+func (r *LoginThrottleRepository) Clear(ctx context.Context, scope string, key string) error {
+	query := `
+		DELETE FROM login_throttles
+		WHERE scope = $1 AND key = $2
+	`
+
+	_, err := r.pool.Exec(ctx, query, scope, key)
+
+	return err
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/storage/postgresql"
+)
+
+type MerchantRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMerchantRepository(pool *pgxpool.Pool) *MerchantRepository {
+	repo := MerchantRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *MerchantRepository) Create(ctx context.Context, code string, name string) (*domain.Merchant, error) {
+	merchant := domain.Merchant{
+		Code: code,
+		Name: name,
+	}
+
+	query := `
+		INSERT INTO merchants (code, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		code, name,
+	).Scan(&merchant.ID, &merchant.CreatedAt)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == postgresql.PgUniqueIndexErrorCode {
+			return nil, domain.ErrMerchantCodeTaken
+		}
+
+		return nil, err
+	}
+
+	return &merchant, nil
+}
+
+func (r *MerchantRepository) GetByID(ctx context.Context, id int) (*domain.Merchant, error) {
+	query := `
+		SELECT id, code, name, created_at
+		FROM merchants
+		WHERE id = $1
+	`
+
+	return r.get(ctx, query, id)
+}
+
+func (r *MerchantRepository) GetByCode(ctx context.Context, code string) (*domain.Merchant, error) {
+	query := `
+		SELECT id, code, name, created_at
+		FROM merchants
+		WHERE code = $1
+	`
+
+	return r.get(ctx, query, code)
+}
+
+func (r *MerchantRepository) List(ctx context.Context) ([]domain.Merchant, error) {
+	query := `
+		SELECT id, code, name, created_at
+		FROM merchants
+		ORDER BY id
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	merchants := make([]domain.Merchant, 0)
+
+	for rows.Next() {
+		var merchant domain.Merchant
+
+		if err := rows.Scan(&merchant.ID, &merchant.Code, &merchant.Name, &merchant.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants, rows.Err()
+}
+
+func (r *MerchantRepository) get(ctx context.Context, query string, arg any) (*domain.Merchant, error) {
+	var merchant domain.Merchant
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		arg,
+	).Scan(&merchant.ID, &merchant.Code, &merchant.Name, &merchant.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &merchant, nil
+}
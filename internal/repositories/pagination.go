@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// historyQuery assembles the WHERE, ORDER BY and LIMIT parts of a keyset
+// paginated history query. Rows are ordered by timeColumn and then keyColumn,
+// the same pair a domain.PageCursor points at.
+type historyQuery struct {
+	conditions []string
+	args       []any
+}
+
+// newHistoryQuery starts a query whose base part already uses args as $1, $2...
+func newHistoryQuery(args ...any) *historyQuery {
+	return &historyQuery{args: args}
+}
+
+// where adds a condition. Every %s in condition is replaced with the
+// placeholder of the matching argument.
+func (q *historyQuery) where(condition string, args ...any) {
+	placeholders := make([]any, 0, len(args))
+
+	for _, arg := range args {
+		q.args = append(q.args, arg)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(q.args)))
+	}
+
+	q.conditions = append(q.conditions, fmt.Sprintf(condition, placeholders...))
+}
+
+func (q *historyQuery) build(
+	base string, timeColumn string, keyColumn string, cursorKey any, filter domain.HistoryFilter,
+) (string, []any) {
+	if filter.From != nil {
+		q.where(timeColumn+" >= %s", *filter.From)
+	}
+
+	if filter.To != nil {
+		q.where(timeColumn+" < %s", *filter.To)
+	}
+
+	direction, operator := "DESC", "<"
+
+	if filter.IsAscending() {
+		direction, operator = "ASC", ">"
+	}
+
+	if filter.Cursor != nil {
+		q.where(fmt.Sprintf("(%s, %s) %s (%%s, %%s)", timeColumn, keyColumn, operator), filter.Cursor.Time, cursorKey)
+	}
+
+	q.args = append(q.args, filter.Limit+1)
+
+	query := base + "\n\t\tWHERE " + strings.Join(q.conditions, " AND ") +
+		fmt.Sprintf("\n\t\tORDER BY %s %s, %s %s\n\t\tLIMIT $%d", timeColumn, direction, keyColumn, direction, len(q.args))
+
+	return query, q.args
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type PasswordResetRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasswordResetRepository(pool *pgxpool.Pool) *PasswordResetRepository {
+	repo := PasswordResetRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *PasswordResetRepository) Create(
+	ctx context.Context,
+	userID int,
+	issuedBy int,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, issued_by, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.pool.Exec(
+		ctx,
+		query,
+		userID, issuedBy, tokenHash, expiresAt,
+	)
+
+	return err
+}
+
+// GetUserID returns the user an active reset token was issued for.
+func (r *PasswordResetRepository) GetUserID(ctx context.Context, tokenHash string, now time.Time) (int, error) {
+	var userID int
+
+	query := `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		tokenHash, now,
+	).Scan(&userID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// Redeem spends the reset token with the given hash and sets the password of
+// its user in one go. ErrNotFound means there is no such token, or it is used
+// up or expired.
+func (r *PasswordResetRepository) Redeem(
+	ctx context.Context,
+	tokenHash string,
+	hashedPassword string,
+	now time.Time,
+) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var userID int
+
+	query := `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		tokenHash, now,
+	).Scan(&userID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+
+		return 0, err
+	}
+
+	if err := setPassword(ctx, tx, userID, hashedPassword); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
@@ -23,20 +23,22 @@ func NewRegisteredOrdersRepository(pool *pgxpool.Pool) *RegisteredOrdersReposito
 	}
 }
 
-func (r *RegisteredOrdersRepository) GetByID(ctx context.Context, orderID string) (*domain.RegisteredOrder, error) {
+func (r *RegisteredOrdersRepository) GetByID(
+	ctx context.Context, merchantID int, orderID string,
+) (*domain.RegisteredOrder, error) {
 	var order domain.RegisteredOrder
 
 	query := `
-		SELECT order_id, status, accrual, created_at
+		SELECT merchant_id, order_id, status, accrual, purchased_at, created_at
 		FROM registered_orders
-		WHERE order_id = $1
+		WHERE merchant_id = $1 AND order_id = $2
 	`
 
 	err := r.pool.QueryRow(
 		ctx,
 		query,
-		orderID,
-	).Scan(&order.OrderID, &order.Status, &order.Accrual, &order.CreatedAt)
+		merchantID, orderID,
+	).Scan(&order.MerchantID, &order.OrderID, &order.Status, &order.Accrual, &order.PurchasedAt, &order.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -49,29 +51,320 @@ func (r *RegisteredOrdersRepository) GetByID(ctx context.Context, orderID string
 	return &order, nil
 }
 
-func (r *RegisteredOrdersRepository) SetCalculatedOrderAccrual(ctx context.Context, orderID string, accrual float64) error {
+// SetCalculatedOrderAccrual marks the order processed with the accrual of
+// breakdown, and keeps the breakdown as a calculation of the order.
+func (r *RegisteredOrdersRepository) SetCalculatedOrderAccrual(
+	ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown,
+) error {
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE registered_orders
-		SET status = $1, accrual = $2
-		WHERE order_id = $3
+		SET status = $1, accrual = $2, calculated_at = NOW()
+		WHERE merchant_id = $3 AND order_id = $4
 	`
 
-	_, err := r.pool.Exec(
+	_, err = tx.Exec(
 		ctx,
 		query,
-		domain.ProcessedRegisteredOrderStatus, accrual, orderID,
+		domain.ProcessedRegisteredOrderStatus, breakdown.Accrual, merchantID, orderID,
 	)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if _, err := saveCalculation(ctx, tx, merchantID, orderID, breakdown); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetBreakdown rebuilds the breakdown of the latest calculation of the order,
+// see saveCalculation.
+func (r *RegisteredOrdersRepository) GetBreakdown(
+	ctx context.Context, merchantID int, orderID string,
+) (*domain.AccrualBreakdown, error) {
+	var calculatedAt *time.Time
+
+	query := `
+		SELECT calculated_at
+		FROM registered_orders
+		WHERE merchant_id = $1 AND order_id = $2
+	`
+
+	err := r.pool.QueryRow(ctx, query, merchantID, orderID).Scan(&calculatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if calculatedAt == nil {
+		return nil, domain.ErrNoBreakdown
+	}
+
+	goods, err := r.GetOrderGoods(ctx, merchantID, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT line, rule_id, rule_version, reward_type, amount
+		FROM order_accrual_contributions
+		WHERE calculation_id = (
+			SELECT MAX(id)
+			FROM order_accrual_calculations
+			WHERE merchant_id = $1 AND order_id = $2
+		)
+		ORDER BY position
+	`
+
+	rows, err := r.pool.Query(ctx, query, merchantID, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	breakdown := domain.NewAccrualBreakdown(goods)
+
+	for rows.Next() {
+		var line *int
+		var contribution domain.RuleContribution
+
+		err := rows.Scan(
+			&line, &contribution.RuleID, &contribution.RuleVersion, &contribution.RewardType, &contribution.Amount,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if line == nil || *line >= len(goods) {
+			breakdown.Add(-1, contribution)
+		} else {
+			breakdown.Add(*line, contribution)
+		}
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return breakdown, nil
+}
+
+// GetRecalculatedOrders returns the processed orders recalculation picks, at
+// most limit of them.
+func (r *RegisteredOrdersRepository) GetRecalculatedOrders(
+	ctx context.Context, merchantID int, recalculation domain.Recalculation, limit int,
+) ([]domain.RegisteredOrder, error) {
+	query := `
+		SELECT merchant_id, order_id, status, accrual, purchased_at, created_at
+		FROM registered_orders
+		WHERE merchant_id = $1 AND status = $2 AND (
+			CASE WHEN $3::boolean
+				THEN order_id = ANY($4::text[])
+				ELSE COALESCE(purchased_at, created_at) >= $5 AND COALESCE(purchased_at, created_at) < $6
+			END
+		)
+		ORDER BY created_at, order_id
+		LIMIT $7
+	`
+
+	rows, err := r.pool.Query(
+		ctx,
+		query,
+		merchantID, domain.ProcessedRegisteredOrderStatus,
+		len(recalculation.OrderIDs) > 0, recalculation.OrderIDs,
+		utcTime(recalculation.From), utcTime(recalculation.To),
+		limit,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]domain.RegisteredOrder, 0)
+
+	for rows.Next() {
+		var order domain.RegisteredOrder
+
+		err := rows.Scan(
+			&order.MerchantID, &order.OrderID, &order.Status, &order.Accrual, &order.PurchasedAt, &order.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return orders, nil
+}
+
+// RecalculateOrderAccrual replaces the accrual of a processed order with the
+// one of breakdown, kept as a new calculation of the order; earlier
+// calculations stay to explain what was accrued before. When the accrual
+// changes, the change is kept as an adjustment and returned; otherwise the
+// adjustment is nil.
+func (r *RegisteredOrdersRepository) RecalculateOrderAccrual(
+	ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown, reason string,
+) (*domain.AccrualAdjustment, error) {
+	tx, err := r.pool.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var oldAccrual *domain.Money
+
+	query := `
+		SELECT accrual
+		FROM registered_orders
+		WHERE merchant_id = $1 AND order_id = $2 AND status = $3
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, merchantID, orderID, domain.ProcessedRegisteredOrderStatus).Scan(&oldAccrual)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	query = `
+		UPDATE registered_orders
+		SET accrual = $1, calculated_at = NOW()
+		WHERE merchant_id = $2 AND order_id = $3
+	`
+
+	if _, err := tx.Exec(ctx, query, breakdown.Accrual, merchantID, orderID); err != nil {
+		return nil, err
+	}
+
+	calculationID, err := saveCalculation(ctx, tx, merchantID, orderID, breakdown)
+
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment := &domain.AccrualAdjustment{
+		MerchantID: merchantID,
+		OrderID:    orderID,
+		NewAccrual: breakdown.Accrual,
+		Reason:     reason,
+	}
+
+	if oldAccrual != nil {
+		adjustment.OldAccrual = *oldAccrual
+	}
+
+	adjustment.Delta = adjustment.NewAccrual.Sub(adjustment.OldAccrual)
+
+	if adjustment.Delta.IsZero() {
+		return nil, tx.Commit(ctx)
+	}
+
+	query = `
+		INSERT INTO order_accrual_adjustments (
+			merchant_id, order_id, old_accrual, new_accrual, delta, reason, calculation_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, (SELECT code FROM merchants WHERE id = $1)
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		merchantID, orderID, adjustment.OldAccrual, adjustment.NewAccrual, adjustment.Delta, reason, calculationID,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt, &adjustment.Merchant)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// GetAdjustments returns at most limit adjustments with an id above after, in
+// id order. A nil merchantID returns those of every merchant.
+func (r *RegisteredOrdersRepository) GetAdjustments(
+	ctx context.Context, merchantID *int, after int, limit int,
+) ([]domain.AccrualAdjustment, error) {
+	query := `
+		SELECT a.id, a.merchant_id, m.code, a.order_id, a.old_accrual, a.new_accrual, a.delta, a.reason, a.created_at
+		FROM order_accrual_adjustments AS a
+		JOIN merchants AS m ON m.id = a.merchant_id
+		WHERE a.id > $1 AND ($2::int IS NULL OR a.merchant_id = $2)
+		ORDER BY a.id
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, after, merchantID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	adjustments := make([]domain.AccrualAdjustment, 0)
+
+	for rows.Next() {
+		var adjustment domain.AccrualAdjustment
+
+		err := rows.Scan(
+			&adjustment.ID, &adjustment.MerchantID, &adjustment.Merchant, &adjustment.OrderID,
+			&adjustment.OldAccrual, &adjustment.NewAccrual, &adjustment.Delta, &adjustment.Reason, &adjustment.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return adjustments, nil
 }
 
 func (r *RegisteredOrdersRepository) TakeOrdersForProcessing(ctx context.Context, limit int) ([]domain.RegisteredOrder, error) {
 	query := `
-		SELECT order_id, status, accrual, created_at
+		SELECT merchant_id, order_id, status, accrual, purchased_at, created_at
 		FROM registered_orders
 		WHERE status = $1 OR status = $2
 		LIMIT $3
@@ -96,7 +389,11 @@ func (r *RegisteredOrdersRepository) TakeOrdersForProcessing(ctx context.Context
 	for rows.Next() {
 		var order domain.RegisteredOrder
 
-		if err := rows.Scan(&order.OrderID, &order.Status, &order.Accrual, &order.CreatedAt); err != nil {
+		err := rows.Scan(
+			&order.MerchantID, &order.OrderID, &order.Status, &order.Accrual, &order.PurchasedAt, &order.CreatedAt,
+		)
+
+		if err != nil {
 			return nil, err
 		}
 
@@ -106,17 +403,27 @@ func (r *RegisteredOrdersRepository) TakeOrdersForProcessing(ctx context.Context
 	return orders, nil
 }
 
-func (r *RegisteredOrdersRepository) ChangeOrdersStatus(ctx context.Context, orderIDs []string, status string) error {
+func (r *RegisteredOrdersRepository) ChangeOrdersStatus(
+	ctx context.Context, orders []domain.RegisteredOrder, status string,
+) error {
+	merchantIDs := make([]int, len(orders))
+	orderIDs := make([]string, len(orders))
+
+	for i, order := range orders {
+		merchantIDs[i] = order.MerchantID
+		orderIDs[i] = order.OrderID
+	}
+
 	query := `
 		UPDATE registered_orders
 		SET status = $1
-		WHERE order_id = ANY($2)
+		WHERE (merchant_id, order_id) IN (SELECT * FROM unnest($2::int[], $3::text[]))
 	`
 
 	_, err := r.pool.Exec(
 		ctx,
 		query,
-		status, orderIDs,
+		status, merchantIDs, orderIDs,
 	)
 
 	if err != nil {
@@ -126,17 +433,20 @@ func (r *RegisteredOrdersRepository) ChangeOrdersStatus(ctx context.Context, ord
 	return nil
 }
 
-func (r *RegisteredOrdersRepository) GetOrderGoods(ctx context.Context, orderID string) ([]domain.OrderGood, error) {
+func (r *RegisteredOrdersRepository) GetOrderGoods(
+	ctx context.Context, merchantID int, orderID string,
+) ([]domain.OrderGood, error) {
 	query := `
-		SELECT description, price
+		SELECT description, sku, category, quantity, unit_price, price
 		FROM orders_goods
-		WHERE order_id = $1
+		WHERE merchant_id = $1 AND order_id = $2
+		ORDER BY line
 	`
 
 	rows, err := r.pool.Query(
 		ctx,
 		query,
-		orderID,
+		merchantID, orderID,
 	)
 
 	if err != nil {
@@ -152,7 +462,9 @@ func (r *RegisteredOrdersRepository) GetOrderGoods(ctx context.Context, orderID
 	for rows.Next() {
 		var good domain.OrderGood
 
-		if err := rows.Scan(&good.Description, &good.Price); err != nil {
+		err := rows.Scan(&good.Description, &good.SKU, &good.Category, &good.Quantity, &good.UnitPrice, &good.Price)
+
+		if err != nil {
 			return nil, err
 		}
 
@@ -163,8 +475,10 @@ func (r *RegisteredOrdersRepository) GetOrderGoods(ctx context.Context, orderID
 }
 
 func (r *RegisteredOrdersRepository) RegisterOrder(
-	ctx context.Context, orderID string, goods []domain.OrderGood,
+	ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood,
 ) (*domain.RegisteredOrder, error) {
+	purchasedAt = utcTime(purchasedAt)
+
 	tx, err := r.pool.Begin(ctx)
 
 	if err != nil {
@@ -176,15 +490,15 @@ func (r *RegisteredOrdersRepository) RegisterOrder(
 	var insertedID string
 
 	query := `
-		INSERT INTO registered_orders (order_id, status)
-		VALUES ($1, $2)
+		INSERT INTO registered_orders (merchant_id, order_id, status, purchased_at)
+		VALUES ($1, $2, $3, $4)
 		RETURNING order_id
 	`
 
 	err = tx.QueryRow(
 		ctx,
 		query,
-		orderID, domain.NewRegisteredOrderStatus,
+		merchantID, orderID, domain.NewRegisteredOrderStatus, purchasedAt,
 	).Scan(&insertedID)
 
 	if err != nil {
@@ -200,14 +514,14 @@ func (r *RegisteredOrdersRepository) RegisterOrder(
 	batch := &pgx.Batch{}
 
 	query = `
-		INSERT INTO orders_goods (order_id, description, price)
-		VALUES ($1, $2, $3)
+		INSERT INTO orders_goods (merchant_id, order_id, line, description, sku, category, quantity, unit_price, price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	for _, good := range goods {
+	for i, good := range goods {
 		batch.Queue(
 			query,
-			insertedID, good.Description, good.Price,
+			merchantID, insertedID, i, good.Description, good.SKU, good.Category, good.Units(), good.UnitPrice, good.Price,
 		)
 	}
 
@@ -222,9 +536,61 @@ func (r *RegisteredOrdersRepository) RegisterOrder(
 	}
 
 	return &domain.RegisteredOrder{
-		OrderID:   insertedID,
-		Status:    domain.NewRegisteredOrderStatus,
-		CreatedAt: time.Now().UTC(),
-		Goods:     goods,
+		MerchantID:  merchantID,
+		OrderID:     insertedID,
+		Status:      domain.NewRegisteredOrderStatus,
+		PurchasedAt: purchasedAt,
+		CreatedAt:   time.Now().UTC(),
+		Goods:       goods,
 	}, nil
 }
+
+// saveCalculation keeps breakdown as a new calculation of an order with its
+// contributions: the goods ones in line order, then the order ones. It
+// returns the id of the calculation.
+func saveCalculation(
+	ctx context.Context, tx pgx.Tx, merchantID int, orderID string, breakdown *domain.AccrualBreakdown,
+) (int, error) {
+	var calculationID int
+
+	query := `
+		INSERT INTO order_accrual_calculations (merchant_id, order_id, accrual)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	if err := tx.QueryRow(ctx, query, merchantID, orderID, breakdown.Accrual).Scan(&calculationID); err != nil {
+		return 0, err
+	}
+
+	batch := &pgx.Batch{}
+
+	query = `
+		INSERT INTO order_accrual_contributions (
+			calculation_id, merchant_id, order_id, position, line, rule_id, rule_version, reward_type, amount
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	queue := func(line *int, contribution domain.RuleContribution) {
+		batch.Queue(
+			query,
+			calculationID, merchantID, orderID, batch.Len(), line,
+			contribution.RuleID, contribution.RuleVersion, contribution.RewardType, contribution.Amount,
+		)
+	}
+
+	for i := range breakdown.Goods {
+		line := i
+
+		for _, contribution := range breakdown.Goods[i].Rewards {
+			queue(&line, contribution)
+		}
+	}
+
+	for _, contribution := range breakdown.Order {
+		queue(nil, contribution)
+	}
+
+	return calculationID, tx.SendBatch(ctx, batch).Close()
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type SessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSessionRepository(pool *pgxpool.Pool) *SessionRepository {
+	repo := SessionRepository{
+		pool: pool,
+	}
+
+	return &repo
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, refresh_token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+
+	return r.pool.QueryRow(
+		ctx,
+		query,
+		session.ID, session.UserID, session.RefreshTokenHash, session.ExpiresAt,
+	).Scan(&session.CreatedAt)
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	var session domain.Session
+
+	query := `
+		SELECT id, user_id, refresh_token_hash, prev_refresh_token_hash, expires_at, revoked_at, created_at, refreshed_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&session.ID, &session.UserID, &session.RefreshTokenHash, &session.PrevRefreshTokenHash,
+		&session.ExpiresAt, &session.RevokedAt, &session.CreatedAt, &session.RefreshedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Rotate swaps the refresh token hash of an active session, but only if the
+// current hash is still oldHash. It returns ErrSessionNotActive when another
+// request rotated or revoked the session first.
+func (r *SessionRepository) Rotate(
+	ctx context.Context, id string, oldHash string, newHash string, expiresAt time.Time,
+) error {
+	query := `
+		UPDATE sessions
+		SET refresh_token_hash = $1, prev_refresh_token_hash = refresh_token_hash, expires_at = $2, refreshed_at = NOW()
+		WHERE id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, newHash, expiresAt, id, oldHash)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionNotActive
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := r.pool.Exec(ctx, query, id)
+
+	return err
+}
+
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID int) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+
+	return err
+}
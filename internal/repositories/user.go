@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -28,7 +29,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, err
 	var user domain.User
 
 	query := `
-		SELECT id, login, password, created_at
+		SELECT id, login, password, role, locked_at, lock_reason, created_at
 		FROM users
 		WHERE id = $1
 	`
@@ -37,7 +38,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, err
 		ctx,
 		query,
 		id,
-	).Scan(&user.ID, &user.Login, &user.Password, &user.CreatedAt)
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.LockedAt, &user.LockReason, &user.CreatedAt)
 
 	if err != nil {
 		return nil, domain.ErrNotFound
@@ -75,6 +76,7 @@ func (r *UserRepository) SaveUser(ctx context.Context, login string, hashedPassw
 		ID:        int(insertedID),
 		Login:     login,
 		Password:  hashedPassword,
+		Role:      domain.CustomerRole,
 		CreatedAt: time.Now().UTC(),
 	}, nil
 }
@@ -83,7 +85,7 @@ func (r *UserRepository) GetByLogin(ctx context.Context, login string) (*domain.
 	var user domain.User
 
 	query := `
-		SELECT id, login, password, created_at
+		SELECT id, login, password, role, locked_at, lock_reason, created_at
 		FROM users
 		WHERE login = $1
 	`
@@ -92,11 +94,122 @@ func (r *UserRepository) GetByLogin(ctx context.Context, login string) (*domain.
 		ctx,
 		query,
 		login,
-	).Scan(&user.ID, &user.Login, &user.Password, &user.CreatedAt)
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.LockedAt, &user.LockReason, &user.CreatedAt)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
 		return nil, err
 	}
 
 	return &user, nil
 }
+
+func (r *UserRepository) SetRole(ctx context.Context, id int, role string) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET role = $1
+		WHERE id = $2
+	`
+
+	if err := r.exec(ctx, query, role, id); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// Lock keeps the user from logging in. Revoking the sessions they already
+// have is up to the caller.
+func (r *UserRepository) Lock(ctx context.Context, id int, reason string) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET locked_at = COALESCE(locked_at, NOW()), lock_reason = $1
+		WHERE id = $2
+	`
+
+	if err := r.exec(ctx, query, reason, id); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *UserRepository) Unlock(ctx context.Context, id int) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET locked_at = NULL, lock_reason = NULL
+		WHERE id = $1
+	`
+
+	if err := r.exec(ctx, query, id); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// UpdatePassword stores a new password hash. Reset tokens issued before the
+// change are spent along with it.
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int, hashedPassword string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
+	if err := setPassword(ctx, tx, id, hashedPassword); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// exec runs an update of a single user and reports ErrNotFound when there is
+// no such user.
+func (r *UserRepository) exec(ctx context.Context, query string, args ...any) error {
+	tag, err := r.pool.Exec(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// setPassword stores a new password hash and spends every outstanding reset
+// token of the user.
+func setPassword(ctx context.Context, tx pgx.Tx, id int, hashedPassword string) error {
+	query := `
+		UPDATE users
+		SET password = $1
+		WHERE id = $2
+	`
+
+	tag, err := tx.Exec(ctx, query, hashedPassword, id)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	query = `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err = tx.Exec(ctx, query, id)
+
+	return err
+}
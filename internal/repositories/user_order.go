@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
@@ -25,7 +27,7 @@ func (r *UserOrderRepository) GetByOrderID(ctx context.Context, orderID string)
 	var userOrder domain.UserOrder
 
 	query := `
-		SELECT order_id, user_id, status, accrual, uploaded_at
+		SELECT order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant
 		FROM user_orders
 		WHERE order_id = $1
 	`
@@ -34,7 +36,7 @@ func (r *UserOrderRepository) GetByOrderID(ctx context.Context, orderID string)
 		ctx,
 		query,
 		orderID,
-	).Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt)
+	).Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt, &userOrder.ReversalReason, &userOrder.ReversedAt, &userOrder.Merchant)
 
 	if err != nil {
 		return nil, err
@@ -47,7 +49,8 @@ func (r *UserOrderRepository) SetOrderCalculatingResult(
 	ctx context.Context,
 	orderID string,
 	status string,
-	accrual float64,
+	accrual domain.Money,
+	pointsExpireAt time.Time,
 ) error {
 	tx, err := r.pool.Begin(ctx)
 
@@ -77,20 +80,36 @@ func (r *UserOrderRepository) SetOrderCalculatingResult(
 	}
 
 	query = `
-	   INSERT INTO balance_actions (user_id, amount, order_id, processed_at)
-	   VALUES ($1, $2, $3, $4)
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, processed_at)
+	   VALUES ($1, $2, $3, $4, $5)
+	   RETURNING id
 	`
 
-	_, err = tx.Exec(
+	var actionID int
+
+	err = tx.QueryRow(
 		ctx,
 		query,
-		userID, accrual, orderID, time.Now().UTC(),
-	)
+		userID, accrual, orderID, domain.AccrualBalanceActionKind, time.Now().UTC(),
+	).Scan(&actionID)
 
 	if err != nil {
 		return err
 	}
 
+	if accrual.IsPositive() {
+		entry := domain.NewAccrualLedgerEntry(userID, orderID, accrual)
+		entry.BalanceActionID = &actionID
+
+		if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		if err := createAccrualLot(ctx, tx, userID, actionID, orderID, accrual, &pointsExpireAt); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
@@ -98,9 +117,300 @@ func (r *UserOrderRepository) SetOrderCalculatingResult(
 	return nil
 }
 
+// SetOrderBreakdown keeps the explanation of the order accrual the accrual
+// system gave, see GetDetailsByOrderID.
+func (r *UserOrderRepository) SetOrderBreakdown(
+	ctx context.Context, orderID string, breakdown *domain.AccrualBreakdown,
+) error {
+	query := `
+		UPDATE user_orders
+		SET accrual_breakdown = $1
+		WHERE order_id = $2
+	`
+
+	_, err := r.pool.Exec(ctx, query, breakdown, orderID)
+
+	return err
+}
+
+func (r *UserOrderRepository) GetDetailsByOrderID(ctx context.Context, orderID string) (*domain.UserOrderDetails, error) {
+	var details domain.UserOrderDetails
+	order := &details.UserOrder
+
+	query := `
+		SELECT order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant, accrual_breakdown
+		FROM user_orders
+		WHERE order_id = $1
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		orderID,
+	).Scan(&order.OrderID, &order.UserID, &order.Status, &order.Accrual, &order.UploadedAt, &order.ReversalReason, &order.ReversedAt, &order.Merchant, &details.Breakdown)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// GetAccrualAdjustmentsCursor returns the id after which the adjustment feed
+// is read again: the latest adjustment ApplyAccrualAdjustment saw more than
+// lookback ago, 0 when there is none. Adjustment ids may commit out of order
+// in the accrual system, so the adjustments seen within lookback are read
+// again, and those that were late in between are applied then.
+func (r *UserOrderRepository) GetAccrualAdjustmentsCursor(ctx context.Context, lookback time.Duration) (int, error) {
+	var id int
+
+	query := `
+		SELECT COALESCE(MAX(adjustment_id), 0)
+		FROM applied_accrual_adjustments
+		WHERE seen_at <= NOW() - make_interval(secs => $1)
+	`
+
+	if err := r.pool.QueryRow(ctx, query, lookback.Seconds()).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetPendingAccrualAdjustments returns the adjustments after the id after
+// that ApplyAccrualAdjustment keeps until the accrual of their order is
+// final, in id order.
+func (r *UserOrderRepository) GetPendingAccrualAdjustments(
+	ctx context.Context, after int, limit int,
+) ([]domain.AccrualAdjustment, error) {
+	query := `
+		SELECT adjustment_id, order_id, merchant, old_accrual, new_accrual, delta, reason
+		FROM applied_accrual_adjustments
+		WHERE applied_at IS NULL AND adjustment_id > $1
+		ORDER BY adjustment_id
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, after, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	adjustments := make([]domain.AccrualAdjustment, 0)
+
+	for rows.Next() {
+		var adjustment domain.AccrualAdjustment
+
+		err := rows.Scan(
+			&adjustment.ID, &adjustment.OrderID, &adjustment.Merchant, &adjustment.OldAccrual,
+			&adjustment.NewAccrual, &adjustment.Delta, &adjustment.Reason,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return adjustments, nil
+}
+
+// ApplyAccrualAdjustment posts the change of an order accrual made by a
+// recalculation in the accrual system as an ADJUSTMENT of the balance of
+// whoever uploaded the order. Credited points become a lot expiring at
+// pointsExpireAt, debits take the oldest lots first and may leave the user in
+// debt. Every adjustment is applied once. While the order is not processed or
+// invalid yet, or an earlier adjustment of the order waits, the adjustment
+// is kept pending, see GetPendingAccrualAdjustments: the accrual the order
+// gets may be the one adjusted. nil is returned for adjustments that change
+// no balance: applied before, pending, of orders no user uploaded, invalid or
+// reversed, or whose accrual is not the one adjusted.
+func (r *UserOrderRepository) ApplyAccrualAdjustment(
+	ctx context.Context, adjustment domain.AccrualAdjustment, pointsExpireAt time.Time,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO applied_accrual_adjustments (
+			adjustment_id, order_id, merchant, old_accrual, new_accrual, delta, reason
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		adjustment.ID, adjustment.OrderID, adjustment.Merchant, adjustment.OldAccrual, adjustment.NewAccrual,
+		adjustment.Delta, adjustment.Reason,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var appliedAt *time.Time
+
+	query = `
+		SELECT applied_at
+		FROM applied_accrual_adjustments
+		WHERE adjustment_id = $1
+		FOR UPDATE
+	`
+
+	if err := tx.QueryRow(ctx, query, adjustment.ID).Scan(&appliedAt); err != nil {
+		return nil, err
+	}
+
+	if appliedAt != nil {
+		return nil, nil
+	}
+
+	var userOrder domain.UserOrder
+
+	query = `
+		SELECT user_id, status, accrual, reversed_at, merchant
+		FROM user_orders
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		adjustment.OrderID,
+	).Scan(&userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.ReversedAt, &userOrder.Merchant)
+
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if err != nil || userOrder.Merchant != adjustment.Merchant || userOrder.ReversedAt != nil {
+		return nil, markAccrualAdjustmentApplied(ctx, tx, adjustment.ID, nil)
+	}
+
+	if userOrder.Status != domain.ProcessedOrderStatus && userOrder.Status != domain.InvalidOrderStatus {
+		return nil, tx.Commit(ctx)
+	}
+
+	var earlierPending bool
+
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM applied_accrual_adjustments
+			WHERE order_id = $1 AND adjustment_id < $2 AND applied_at IS NULL
+		)
+	`
+
+	if err := tx.QueryRow(ctx, query, adjustment.OrderID, adjustment.ID).Scan(&earlierPending); err != nil {
+		return nil, err
+	}
+
+	if earlierPending {
+		return nil, tx.Commit(ctx)
+	}
+
+	if userOrder.Status != domain.ProcessedOrderStatus || userOrder.Accrual == nil ||
+		userOrder.Accrual.Cmp(adjustment.OldAccrual) != 0 {
+		return nil, markAccrualAdjustmentApplied(ctx, tx, adjustment.ID, nil)
+	}
+
+	query = `
+		UPDATE user_orders
+		SET accrual = $1
+		WHERE order_id = $2
+	`
+
+	if _, err := tx.Exec(ctx, query, adjustment.NewAccrual, adjustment.OrderID); err != nil {
+		return nil, err
+	}
+
+	action := domain.BalanceAction{
+		UserID:  userOrder.UserID,
+		Amount:  adjustment.Delta,
+		OrderID: adjustment.OrderID,
+		Kind:    domain.AdjustmentBalanceActionKind,
+		Status:  domain.ProcessedBalanceActionStatus,
+		Reason:  &adjustment.Reason,
+	}
+
+	query = `
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, status, reason, processed_at)
+	   VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	   RETURNING id, created_at, processed_at
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		action.UserID, action.Amount, action.OrderID, action.Kind, action.Status, action.Reason,
+	).Scan(&action.ID, &action.CreatedAt, &action.ProcessedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewAdjustmentLedgerEntry(action.UserID, action.Amount)
+	entry.BalanceActionID = &action.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if action.Amount.IsNegative() {
+		err = consumeAccrualLots(ctx, tx, action.UserID, action.ID, action.Amount.Neg(), false)
+	} else {
+		err = createAccrualLot(ctx, tx, action.UserID, action.ID, action.OrderID, action.Amount, &pointsExpireAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := markAccrualAdjustmentApplied(ctx, tx, adjustment.ID, &action.ID); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// markAccrualAdjustmentApplied records in tx that an adjustment is done with,
+// by the balance action if any, and commits tx.
+func markAccrualAdjustmentApplied(ctx context.Context, tx pgx.Tx, adjustmentID int, balanceActionID *int) error {
+	query := `
+		UPDATE applied_accrual_adjustments
+		SET applied_at = NOW(), balance_action_id = $1
+		WHERE adjustment_id = $2
+	`
+
+	if _, err := tx.Exec(ctx, query, balanceActionID, adjustmentID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (r *UserOrderRepository) GetByUserID(ctx context.Context, userID int) ([]domain.UserOrder, error) {
 	query := `
-		SELECT order_id, user_id, status, accrual, uploaded_at
+		SELECT order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant
 		FROM user_orders
 		WHERE user_id = $1
 		ORDER BY uploaded_at
@@ -125,7 +435,7 @@ func (r *UserOrderRepository) GetByUserID(ctx context.Context, userID int) ([]do
 	for rows.Next() {
 		var userOrder domain.UserOrder
 
-		if err := rows.Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt); err != nil {
+		if err := rows.Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt, &userOrder.ReversalReason, &userOrder.ReversedAt, &userOrder.Merchant); err != nil {
 			return nil, err
 		}
 
@@ -135,16 +445,18 @@ func (r *UserOrderRepository) GetByUserID(ctx context.Context, userID int) ([]do
 	return orders, nil
 }
 
-func (r *UserOrderRepository) SaveOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error) {
+func (r *UserOrderRepository) SaveOrder(
+	ctx context.Context, orderID string, merchant string, userID int,
+) (*domain.UserOrder, error) {
 	query := `
-		INSERT INTO user_orders (order_id, user_id, status)
-		VALUES ($1, $2, $3)
+		INSERT INTO user_orders (order_id, merchant, user_id, status)
+		VALUES ($1, $2, $3, $4)
 	`
 
 	_, err := r.pool.Exec(
 		ctx,
 		query,
-		orderID, userID, domain.NewOrderStatus,
+		orderID, merchant, userID, domain.NewOrderStatus,
 	)
 
 	if err != nil {
@@ -154,6 +466,7 @@ func (r *UserOrderRepository) SaveOrder(ctx context.Context, orderID string, use
 	return &domain.UserOrder{
 		OrderID:    orderID,
 		UserID:     userID,
+		Merchant:   merchant,
 		Status:     domain.NewOrderStatus,
 		Accrual:    nil,
 		UploadedAt: time.Now().UTC(),
@@ -164,7 +477,7 @@ func (r *UserOrderRepository) TakeOrdersForProcessing(ctx context.Context) ([]do
 	query := `
 		UPDATE user_orders SET status = $1
 		WHERE status = $2 OR status = $3
-		RETURNING order_id, user_id, status, accrual, uploaded_at
+		RETURNING order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant
 	`
 
 	rows, err := r.pool.Query(
@@ -186,7 +499,7 @@ func (r *UserOrderRepository) TakeOrdersForProcessing(ctx context.Context) ([]do
 	for rows.Next() {
 		var userOrder domain.UserOrder
 
-		if err := rows.Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt); err != nil {
+		if err := rows.Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt, &userOrder.ReversalReason, &userOrder.ReversedAt, &userOrder.Merchant); err != nil {
 			return nil, err
 		}
 
@@ -195,3 +508,228 @@ func (r *UserOrderRepository) TakeOrdersForProcessing(ctx context.Context) ([]do
 
 	return orders, nil
 }
+
+// RequeueOrder hands an order that came back INVALID to the accrual worker
+// again. Orders that are still queued are returned as they are; processed ones
+// can not be requeued, their points are already issued.
+func (r *UserOrderRepository) RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error) {
+	var userOrder domain.UserOrder
+
+	query := `
+		UPDATE user_orders
+		SET status = $1, accrual = NULL
+		WHERE order_id = $2 AND status = $3
+		RETURNING order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant
+	`
+
+	err := r.pool.QueryRow(
+		ctx,
+		query,
+		domain.NewOrderStatus, orderID, domain.InvalidOrderStatus,
+	).Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt, &userOrder.ReversalReason, &userOrder.ReversedAt, &userOrder.Merchant)
+
+	if err == nil {
+		return &userOrder, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	order, err := r.GetByOrderID(ctx, orderID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if order.Status == domain.ProcessedOrderStatus {
+		return nil, domain.ErrOrderNotRequeueable
+	}
+
+	return order, nil
+}
+
+// ClawbackOrderAccrual takes back the points accrued for a processed order by
+// posting a compensating CLAWBACK action. The user balance may go negative.
+func (r *UserOrderRepository) ClawbackOrderAccrual(
+	ctx context.Context, orderID string, reason string,
+) (*domain.BalanceAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	var userOrder domain.UserOrder
+
+	query := `
+		SELECT order_id, user_id, status, accrual, reversed_at
+		FROM user_orders
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		orderID,
+	).Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.ReversedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if userOrder.ReversedAt != nil {
+		return nil, domain.ErrAlreadyReversed
+	}
+
+	if userOrder.Status != domain.ProcessedOrderStatus || userOrder.Accrual == nil || !userOrder.Accrual.IsPositive() {
+		return nil, domain.ErrNotReversible
+	}
+
+	var accrualActionID int
+
+	query = `
+		SELECT id
+		FROM balance_actions
+		WHERE order_id = $1 AND kind = $2
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		orderID, domain.AccrualBalanceActionKind,
+	).Scan(&accrualActionID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotReversible
+		}
+
+		return nil, err
+	}
+
+	clawback := domain.BalanceAction{
+		UserID:     userOrder.UserID,
+		Amount:     userOrder.Accrual.Neg(),
+		OrderID:    orderID,
+		Kind:       domain.ClawbackBalanceActionKind,
+		Status:     domain.ProcessedBalanceActionStatus,
+		ReversesID: &accrualActionID,
+		Reason:     &reason,
+	}
+
+	query = `
+	   INSERT INTO balance_actions (user_id, amount, order_id, kind, status, reverses_id, reason, processed_at)
+	   VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	   RETURNING id, created_at, processed_at
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		clawback.UserID, clawback.Amount, clawback.OrderID, clawback.Kind, clawback.Status, clawback.ReversesID, clawback.Reason,
+	).Scan(&clawback.ID, &clawback.CreatedAt, &clawback.ProcessedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewClawbackLedgerEntry(clawback.UserID, orderID, *userOrder.Accrual)
+	entry.BalanceActionID = &clawback.ID
+
+	if _, err := postLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := consumeAccrualLots(ctx, tx, clawback.UserID, clawback.ID, *userOrder.Accrual, false); err != nil {
+		return nil, err
+	}
+
+	query = `
+		UPDATE user_orders
+		SET reversal_reason = $1, reversed_at = $2
+		WHERE order_id = $3
+	`
+
+	if _, err := tx.Exec(ctx, query, reason, clawback.ProcessedAt, orderID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &clawback, nil
+}
+
+// GetPageByUserID returns one keyset page of the user orders ordered by upload
+// time.
+func (r *UserOrderRepository) GetPageByUserID(
+	ctx context.Context, userID int, filter domain.HistoryFilter,
+) (*domain.UserOrdersPage, error) {
+	historyQuery := newHistoryQuery()
+	historyQuery.where("user_id = %s", userID)
+
+	if filter.Status != "" {
+		historyQuery.where("status = %s", filter.Status)
+	}
+
+	var cursorKey string
+
+	if filter.Cursor != nil {
+		cursorKey = filter.Cursor.Key
+	}
+
+	query, args := historyQuery.build(`
+		SELECT order_id, user_id, status, accrual, uploaded_at, reversal_reason, reversed_at, merchant
+		FROM user_orders`,
+		"uploaded_at", "order_id", cursorKey, filter,
+	)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]domain.UserOrder, 0)
+
+	for rows.Next() {
+		var userOrder domain.UserOrder
+
+		if err := rows.Scan(&userOrder.OrderID, &userOrder.UserID, &userOrder.Status, &userOrder.Accrual, &userOrder.UploadedAt, &userOrder.ReversalReason, &userOrder.ReversedAt, &userOrder.Merchant); err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, userOrder)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	page := domain.UserOrdersPage{Items: orders}
+
+	if len(orders) > filter.Limit {
+		page.Items = orders[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		nextCursor := domain.PageCursor{Time: last.UploadedAt, Key: last.OrderID}.Encode()
+		page.NextCursor = &nextCursor
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,428 @@
+// Package rulefile reads and writes reward rules in the files merchants keep
+// them in: CSV with a header row, or a JSON array of objects. Both use the
+// field names of the rules API; in CSV, tiers and schedule are JSON cells and
+// empty cells are left out.
+package rulefile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+const (
+	CSVFormat  = "csv"
+	JSONFormat = "json"
+)
+
+func IsValidFormat(format string) bool {
+	return format == CSVFormat || format == JSONFormat
+}
+
+// rule is a rule as it is kept in a file. A rule without an id is matched
+// with the existing rules by its match, see GoodRewardRepository.ImportRewards.
+type rule struct {
+	ID          int                 `json:"id,omitempty"`
+	Match       string              `json:"match"`
+	MatchField  string              `json:"match_field,omitempty"`
+	MatchType   string              `json:"match_type,omitempty"`
+	IgnoreCase  bool                `json:"ignore_case,omitempty"`
+	Reward      domain.Money        `json:"reward"`
+	RewardType  string              `json:"reward_type"`
+	MinBasket   *domain.Money       `json:"min_basket,omitempty"`
+	Tiers       []domain.RewardTier `json:"tiers,omitempty"`
+	Priority    int                 `json:"priority,omitempty"`
+	Stacking    string              `json:"stacking,omitempty"`
+	MaxPerGood  *domain.Money       `json:"max_per_good,omitempty"`
+	MaxPerOrder *domain.Money       `json:"max_per_order,omitempty"`
+	CampaignID  *int                `json:"campaign_id,omitempty"`
+	ValidFrom   *time.Time          `json:"valid_from,omitempty"`
+	ValidTo     *time.Time          `json:"valid_to,omitempty"`
+	Schedule    *domain.Schedule    `json:"schedule,omitempty"`
+	Disabled    bool                `json:"disabled,omitempty"`
+}
+
+// Kinds of CSV cells, which decide how a cell is put into JSON.
+const (
+	stringCell = iota
+	boolCell
+	numberCell
+	jsonCell
+)
+
+// columns are the CSV columns in the order Write puts them.
+var columns = []struct {
+	name string
+	kind int
+}{
+	{"id", numberCell},
+	{"match", stringCell},
+	{"match_field", stringCell},
+	{"match_type", stringCell},
+	{"ignore_case", boolCell},
+	{"reward", numberCell},
+	{"reward_type", stringCell},
+	{"min_basket", numberCell},
+	{"tiers", jsonCell},
+	{"priority", numberCell},
+	{"stacking", stringCell},
+	{"max_per_good", numberCell},
+	{"max_per_order", numberCell},
+	{"campaign_id", numberCell},
+	{"valid_from", stringCell},
+	{"valid_to", stringCell},
+	{"schedule", jsonCell},
+	{"disabled", boolCell},
+}
+
+func newRule(reward domain.GoodReward) rule {
+	return rule{
+		ID:          reward.ID,
+		Match:       reward.Match,
+		MatchField:  reward.MatchField,
+		MatchType:   reward.MatchType,
+		IgnoreCase:  reward.IgnoreCase,
+		Reward:      reward.Reward,
+		RewardType:  reward.RewardType,
+		MinBasket:   reward.MinBasket,
+		Tiers:       reward.Tiers,
+		Priority:    reward.Priority,
+		Stacking:    reward.Stacking,
+		MaxPerGood:  reward.MaxPerGood,
+		MaxPerOrder: reward.MaxPerOrder,
+		CampaignID:  reward.CampaignID,
+		ValidFrom:   reward.ValidFrom,
+		ValidTo:     reward.ValidTo,
+		Schedule:    reward.Schedule,
+		Disabled:    reward.Disabled,
+	}
+}
+
+func (r rule) goodReward() domain.GoodReward {
+	return domain.GoodReward{
+		ID:          r.ID,
+		Match:       r.Match,
+		MatchField:  r.MatchField,
+		MatchType:   r.MatchType,
+		IgnoreCase:  r.IgnoreCase,
+		Reward:      r.Reward,
+		RewardType:  r.RewardType,
+		MinBasket:   r.MinBasket,
+		Tiers:       r.Tiers,
+		Priority:    r.Priority,
+		Stacking:    r.Stacking,
+		MaxPerGood:  r.MaxPerGood,
+		MaxPerOrder: r.MaxPerOrder,
+		CampaignID:  r.CampaignID,
+		ValidFrom:   r.ValidFrom,
+		ValidTo:     r.ValidTo,
+		Schedule:    r.Schedule,
+		Disabled:    r.Disabled,
+	}
+}
+
+// Read reads every rule of a file. Rows that can not be read are returned
+// with their error, so they can be reported along with the others. The error
+// is for files that can not be read at all, and wraps
+// domain.ErrInvalidImport when the file itself is wrong.
+func Read(format string, reader io.Reader) ([]domain.ImportedGoodReward, error) {
+	switch format {
+	case CSVFormat:
+		return readCSV(reader)
+	case JSONFormat:
+		return readJSON(reader)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidImport, format)
+	}
+}
+
+func readCSV(reader io.Reader) ([]domain.ImportedGoodReward, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", domain.ErrInvalidImport)
+		}
+
+		return nil, csvError(err)
+	}
+
+	kinds := make([]int, len(header))
+	seen := make(map[string]struct{}, len(header))
+
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		header[i] = name
+		kind, ok := columnKind(name)
+
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", domain.ErrInvalidImport, name)
+		}
+
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%w: column %q is repeated", domain.ErrInvalidImport, name)
+		}
+
+		seen[name] = struct{}{}
+		kinds[i] = kind
+	}
+
+	rewards := make([]domain.ImportedGoodReward, 0)
+
+	for {
+		record, err := csvReader.Read()
+
+		if errors.Is(err, io.EOF) {
+			return rewards, nil
+		}
+
+		if err != nil {
+			return nil, csvError(err)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		imported := domain.ImportedGoodReward{Row: line}
+
+		if len(rewards) == domain.MaxImportedGoodRewards {
+			return nil, fmt.Errorf("%w: at most %d rules", domain.ErrInvalidImport, domain.MaxImportedGoodRewards)
+		}
+
+		if len(record) != len(header) {
+			imported.Err = fmt.Errorf("%w: %d cells for %d columns", domain.ErrInvalidImport, len(record), len(header))
+			rewards = append(rewards, imported)
+			continue
+		}
+
+		imported.Reward, imported.Err = decodeRecord(header, kinds, record)
+		rewards = append(rewards, imported)
+	}
+}
+
+// decodeRecord turns the cells of a CSV record into the JSON object of a
+// rule, so both formats are decoded the same way.
+func decodeRecord(header []string, kinds []int, record []string) (domain.GoodReward, error) {
+	object := make(map[string]json.RawMessage, len(record))
+
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+
+		if cell == "" {
+			continue
+		}
+
+		var value json.RawMessage
+		var err error
+
+		switch kinds[i] {
+		case stringCell:
+			value, err = json.Marshal(cell)
+		case boolCell:
+			var b bool
+
+			if b, err = strconv.ParseBool(cell); err == nil {
+				value, err = json.Marshal(b)
+			}
+		case numberCell:
+			if _, err = strconv.ParseFloat(cell, 64); err == nil {
+				value = json.RawMessage(cell)
+			}
+		case jsonCell:
+			if !json.Valid([]byte(cell)) {
+				err = errors.New("not JSON")
+			}
+
+			value = json.RawMessage(cell)
+		}
+
+		if err != nil {
+			return domain.GoodReward{}, fmt.Errorf("%w: invalid %s %q", domain.ErrInvalidImport, header[i], cell)
+		}
+
+		object[header[i]] = value
+	}
+
+	data, err := json.Marshal(object)
+
+	if err != nil {
+		return domain.GoodReward{}, err
+	}
+
+	return decodeRule(data)
+}
+
+func readJSON(reader io.Reader) ([]domain.ImportedGoodReward, error) {
+	var objects []json.RawMessage
+
+	decoder := json.NewDecoder(reader)
+
+	if err := decoder.Decode(&objects); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", domain.ErrInvalidImport)
+		}
+
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: the file must be a JSON array of rules", domain.ErrInvalidImport)
+		}
+
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: the file must be a single JSON array", domain.ErrInvalidImport)
+	}
+
+	if len(objects) > domain.MaxImportedGoodRewards {
+		return nil, fmt.Errorf("%w: at most %d rules", domain.ErrInvalidImport, domain.MaxImportedGoodRewards)
+	}
+
+	rewards := make([]domain.ImportedGoodReward, len(objects))
+
+	for i, object := range objects {
+		rewards[i].Row = i + 1
+		rewards[i].Reward, rewards[i].Err = decodeRule(object)
+	}
+
+	return rewards, nil
+}
+
+func decodeRule(data []byte) (domain.GoodReward, error) {
+	var r rule
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&r); err != nil {
+		var typeErr *json.UnmarshalTypeError
+
+		switch {
+		case errors.As(err, &typeErr) && typeErr.Field != "":
+			return domain.GoodReward{}, fmt.Errorf("%w: invalid %s", domain.ErrInvalidImport, typeErr.Field)
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+			return domain.GoodReward{}, fmt.Errorf("%w: unknown field %s", domain.ErrInvalidImport, field)
+		default:
+			return domain.GoodReward{}, fmt.Errorf("%w: the rule must be a JSON object of valid fields", domain.ErrInvalidImport)
+		}
+	}
+
+	return r.goodReward(), nil
+}
+
+// Write writes rewards in the format Read reads, so an export can be edited
+// and imported again.
+func Write(format string, writer io.Writer, rewards []domain.GoodReward) error {
+	switch format {
+	case CSVFormat:
+		return writeCSV(writer, rewards)
+	case JSONFormat:
+		return writeJSON(writer, rewards)
+	default:
+		return fmt.Errorf("%w: unknown format %q", domain.ErrInvalidImport, format)
+	}
+}
+
+func writeCSV(writer io.Writer, rewards []domain.GoodReward) error {
+	csvWriter := csv.NewWriter(writer)
+	header := make([]string, len(columns))
+
+	for i, column := range columns {
+		header[i] = column.name
+	}
+
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+
+	for _, reward := range rewards {
+		data, err := json.Marshal(newRule(reward))
+
+		if err != nil {
+			return err
+		}
+
+		var object map[string]json.RawMessage
+
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+
+		record := make([]string, len(columns))
+
+		for i, column := range columns {
+			value, ok := object[column.name]
+
+			if !ok {
+				continue
+			}
+
+			if column.kind == stringCell {
+				var s string
+
+				if err := json.Unmarshal(value, &s); err != nil {
+					return err
+				}
+
+				record[i] = s
+				continue
+			}
+
+			record[i] = string(value)
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+func writeJSON(writer io.Writer, rewards []domain.GoodReward) error {
+	rules := make([]rule, len(rewards))
+
+	for i, reward := range rewards {
+		rules[i] = newRule(reward)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(rules)
+}
+
+func columnKind(name string) (int, bool) {
+	for _, column := range columns {
+		if column.name == name {
+			return column.kind, true
+		}
+	}
+
+	return 0, false
+}
+
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidImport, parseErr.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,164 @@
+package rulefile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+func TestRead(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Format    string
+		File      string
+		WantRows  []int
+		WantRules []string
+		WantErrs  []string
+		WantErr   bool
+	}{
+		{
+			Name:   "valid csv",
+			Format: CSVFormat,
+			File: "match, reward, reward_type, ignore_case, tiers\n" +
+				"Bork,5,%,true,\n" +
+				"\"Big, small\",10.5,pt,,\n" +
+				",,tiered_pt,,\"[{\"\"threshold\"\": 0, \"\"reward\"\": 10}]\"\n",
+			WantRows:  []int{2, 3, 4},
+			WantRules: []string{"Bork", "Big, small", ""},
+			WantErrs:  []string{"", "", ""},
+		},
+		{
+			Name:   "invalid csv rows",
+			Format: CSVFormat,
+			File: "match,reward,reward_type,disabled\n" +
+				"Bork,five,%,\n" +
+				"Bork,5,%,maybe\n" +
+				"Bork,5\n" +
+				"Bork,5,%,no\n",
+			WantRows:  []int{2, 3, 4, 5},
+			WantRules: []string{"", "", "", ""},
+			WantErrs:  []string{"invalid reward", "invalid disabled", "2 cells for 4 columns", "invalid disabled"},
+		},
+		{
+			Name:    "unknown csv column",
+			Format:  CSVFormat,
+			File:    "match,points\nBork,5\n",
+			WantErr: true,
+		},
+		{
+			Name:    "empty csv",
+			Format:  CSVFormat,
+			File:    "",
+			WantErr: true,
+		},
+		{
+			Name:      "valid json",
+			Format:    JSONFormat,
+			File:      `[{"id": 3, "match": "Bork", "reward": 5, "reward_type": "%"}, {"match": "Acme", "color": "red"}]`,
+			WantRows:  []int{1, 2},
+			WantRules: []string{"Bork", ""},
+			WantErrs:  []string{"", "unknown field \"color\""},
+		},
+		{
+			Name:    "json object",
+			Format:  JSONFormat,
+			File:    `{"match": "Bork"}`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown format",
+			Format:  "xlsx",
+			File:    "",
+			WantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rewards, err := Read(testCase.Format, strings.NewReader(testCase.File))
+
+			if testCase.WantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidImport)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, rewards, len(testCase.WantRows))
+
+			for i, reward := range rewards {
+				assert.Equal(t, testCase.WantRows[i], reward.Row)
+				assert.Equal(t, testCase.WantRules[i], reward.Reward.Match)
+
+				if testCase.WantErrs[i] == "" {
+					assert.NoError(t, reward.Err)
+				} else {
+					assert.ErrorIs(t, reward.Err, domain.ErrInvalidImport)
+					assert.Contains(t, reward.Err.Error(), testCase.WantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	maxPerGood := domain.MoneyFromInt(100)
+	campaignID := 2
+	validFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	rewards := []domain.GoodReward{
+		{
+			ID: 1, MerchantID: 1, Match: "Bork", MatchField: domain.DescriptionMatchField,
+			MatchType: domain.SubstringMatchType, IgnoreCase: true, Reward: domain.MoneyFromInt(5),
+			RewardType: domain.PercentRewardType, Stacking: domain.StackableStackingMode, MaxPerGood: &maxPerGood,
+			CampaignID: &campaignID, ValidFrom: &validFrom, Version: 3,
+		},
+		{
+			ID: 2, MerchantID: 1, MatchField: domain.DescriptionMatchField, MatchType: domain.SubstringMatchType,
+			RewardType: domain.TieredPointRewardType, Stacking: domain.ExclusiveStackingMode, Disabled: true,
+			Tiers:    []domain.RewardTier{{Threshold: domain.MoneyFromInt(0), Reward: domain.MoneyFromInt(10)}},
+			Schedule: &domain.Schedule{Days: []int{6, 0}, FromHour: 10, ToHour: 22},
+		},
+	}
+
+	for _, format := range []string{CSVFormat, JSONFormat} {
+		t.Run(format, func(t *testing.T) {
+			var file bytes.Buffer
+
+			require.NoError(t, Write(format, &file, rewards))
+
+			if format == CSVFormat {
+				lines := strings.Split(file.String(), "\n")
+				assert.Equal(
+					t,
+					"id,match,match_field,match_type,ignore_case,reward,reward_type,min_basket,tiers,priority,stacking,"+
+						"max_per_good,max_per_order,campaign_id,valid_from,valid_to,schedule,disabled",
+					lines[0],
+				)
+				assert.Equal(
+					t,
+					"1,Bork,description,substring,true,5.00,%,,,,stackable,100.00,,2,2026-10-01T00:00:00Z,,,",
+					lines[1],
+				)
+			}
+
+			read, err := Read(format, &file)
+			require.NoError(t, err)
+			require.Len(t, read, len(rewards))
+
+			for i, imported := range read {
+				require.NoError(t, imported.Err)
+
+				want := rewards[i]
+				want.MerchantID = 0
+				want.Version = 0
+				assert.Equal(t, want, imported.Reward)
+			}
+		})
+	}
+}
@@ -2,31 +2,190 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/calculator"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 )
 
 type registeredOrdersRepository interface {
-	GetByID(ctx context.Context, orderID string) (*domain.RegisteredOrder, error)
-	RegisterOrder(ctx context.Context, orderID string, goods []domain.OrderGood) (*domain.RegisteredOrder, error)
+	GetByID(ctx context.Context, merchantID int, orderID string) (*domain.RegisteredOrder, error)
+	GetBreakdown(ctx context.Context, merchantID int, orderID string) (*domain.AccrualBreakdown, error)
+	RegisterOrder(
+		ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood,
+	) (*domain.RegisteredOrder, error)
+	GetOrderGoods(ctx context.Context, merchantID int, orderID string) ([]domain.OrderGood, error)
+	GetRecalculatedOrders(
+		ctx context.Context, merchantID int, recalculation domain.Recalculation, limit int,
+	) ([]domain.RegisteredOrder, error)
+	RecalculateOrderAccrual(
+		ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown, reason string,
+	) (*domain.AccrualAdjustment, error)
+	GetAdjustments(ctx context.Context, merchantID *int, after int, limit int) ([]domain.AccrualAdjustment, error)
+}
+
+type matchingRewardsRepository interface {
+	GetRewardsWithMatches(
+		ctx context.Context, merchantID int, goods []domain.OrderGood, at time.Time, asOf time.Time,
+	) ([]domain.GoodReward, error)
 }
 
 type AccrualOrdersService struct {
 	registeredOrdersRepository registeredOrdersRepository
+	matchingRewardsRepository  matchingRewardsRepository
 }
 
-func NewAccrualOrdersService(registeredOrdersRepository registeredOrdersRepository) *AccrualOrdersService {
+func NewAccrualOrdersService(
+	registeredOrdersRepository registeredOrdersRepository,
+	matchingRewardsRepository matchingRewardsRepository,
+) *AccrualOrdersService {
 	return &AccrualOrdersService{
 		registeredOrdersRepository: registeredOrdersRepository,
+		matchingRewardsRepository:  matchingRewardsRepository,
 	}
 }
 
+// RegisterOrder queues the order for calculation. Rules are evaluated as of
+// purchasedAt, or as of now when the merchant did not send it. Goods get
+// their defaults, see domain.OrderGood.SetDefaults, before they are checked.
 func (s *AccrualOrdersService) RegisterOrder(
-	ctx context.Context, orderID string, goods []domain.OrderGood,
+	ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood,
+) (*domain.RegisteredOrder, error) {
+	goods, err := prepareOrderGoods(goods)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.registeredOrdersRepository.RegisterOrder(ctx, merchantID, orderID, purchasedAt, goods)
+}
+
+// QuoteOrder calculates what an order of goods bought at would earn, without
+// registering it. It goes through the same calculation as registered orders,
+// with the rules as they were at that moment.
+func (s *AccrualOrdersService) QuoteOrder(
+	ctx context.Context, merchantID int, at time.Time, goods []domain.OrderGood,
+) (*domain.AccrualBreakdown, error) {
+	goods, err := prepareOrderGoods(goods)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculator.CalculateOrder(ctx, s.matchingRewardsRepository, merchantID, goods, at, at)
+}
+
+func (s *AccrualOrdersService) GetOrderInfo(
+	ctx context.Context, merchantID int, orderID string,
 ) (*domain.RegisteredOrder, error) {
-	return s.registeredOrdersRepository.RegisterOrder(ctx, orderID, goods)
+	return s.registeredOrdersRepository.GetByID(ctx, merchantID, orderID)
 }
 
-func (s *AccrualOrdersService) GetOrderInfo(ctx context.Context, orderID string) (*domain.RegisteredOrder, error) {
-	return s.registeredOrdersRepository.GetByID(ctx, orderID)
+// GetOrderBreakdown explains the accrual of a processed order with the rule
+// versions it was calculated with.
+func (s *AccrualOrdersService) GetOrderBreakdown(
+	ctx context.Context, merchantID int, orderID string,
+) (*domain.AccrualBreakdown, error) {
+	return s.registeredOrdersRepository.GetBreakdown(ctx, merchantID, orderID)
+}
+
+// RecalculateOrders calculates the processed orders picked by recalculation
+// again, as of the moments they were evaluated for, with the rules as they
+// are now. Every order is saved on its own, so after an error the orders
+// before it stay recalculated: the result is returned along with the error,
+// with the order that failed. Running the same recalculation again only
+// adjusts the rest.
+func (s *AccrualOrdersService) RecalculateOrders(
+	ctx context.Context, merchantID int, recalculation domain.Recalculation,
+) (*domain.RecalculationResult, error) {
+	if err := recalculation.Check(); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.registeredOrdersRepository.GetRecalculatedOrders(
+		ctx, merchantID, recalculation, domain.MaxRecalculatedOrders+1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) > domain.MaxRecalculatedOrders {
+		return nil, fmt.Errorf("%w: at most %d orders", domain.ErrTooManyOrders, domain.MaxRecalculatedOrders)
+	}
+
+	now := time.Now().UTC()
+
+	result := &domain.RecalculationResult{
+		Orders:      make([]string, 0, len(orders)),
+		Adjustments: make([]domain.AccrualAdjustment, 0),
+	}
+
+	for _, order := range orders {
+		goods, err := s.registeredOrdersRepository.GetOrderGoods(ctx, merchantID, order.OrderID)
+		if err != nil {
+			result.FailedOrder = order.OrderID
+			return result, fmt.Errorf("get goods of order %s %w", order.OrderID, err)
+		}
+
+		breakdown, err := calculator.CalculateOrder(
+			ctx, s.matchingRewardsRepository, merchantID, goods, order.EvaluatedAt(), now,
+		)
+		if err != nil {
+			result.FailedOrder = order.OrderID
+			return result, fmt.Errorf("calculate order %s %w", order.OrderID, err)
+		}
+
+		adjustment, err := s.registeredOrdersRepository.RecalculateOrderAccrual(
+			ctx, merchantID, order.OrderID, breakdown, recalculation.Reason,
+		)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			result.FailedOrder = order.OrderID
+			return result, fmt.Errorf("save order %s %w", order.OrderID, err)
+		}
+
+		result.Orders = append(result.Orders, order.OrderID)
+
+		if adjustment != nil {
+			result.Adjustments = append(result.Adjustments, *adjustment)
+		}
+	}
+
+	return result, nil
+}
+
+// GetAdjustments returns at most limit adjustments made after the one with id
+// after, oldest first; limit is domain.DefaultPageLimit when zero. A nil
+// merchantID returns those of every merchant.
+func (s *AccrualOrdersService) GetAdjustments(
+	ctx context.Context, merchantID *int, after int, limit int,
+) ([]domain.AccrualAdjustment, error) {
+	if limit == 0 {
+		limit = domain.DefaultPageLimit
+	}
+
+	if after < 0 || limit < 0 || limit > domain.MaxPageLimit {
+		return nil, domain.ErrInvalidHistoryFilter
+	}
+
+	return s.registeredOrdersRepository.GetAdjustments(ctx, merchantID, after, limit)
+}
+
+// prepareOrderGoods returns a copy of goods with their defaults set, see
+// domain.OrderGood.SetDefaults, once they are all checked.
+func prepareOrderGoods(goods []domain.OrderGood) ([]domain.OrderGood, error) {
+	goods = append([]domain.OrderGood(nil), goods...)
+
+	for i := range goods {
+		goods[i].SetDefaults()
+
+		if err := goods[i].Check(); err != nil {
+			return nil, err
+		}
+	}
+
+	return goods, nil
 }
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,17 +18,17 @@ import (
 func TestAccrualOrdersService_GetOrderInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	registeredOrdersRepo := repomock.NewMockregisteredOrdersRepository(ctrl)
-	service := NewAccrualOrdersService(registeredOrdersRepo)
+	service := NewAccrualOrdersService(registeredOrdersRepo, repomock.NewMockmatchingRewardsRepository(ctrl))
 
 	t.Run("valid", func(t *testing.T) {
 		orderID := "123"
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetByID(context.Background(), orderID).
+			GetByID(context.Background(), 1, orderID).
 			Return(&domain.RegisteredOrder{OrderID: orderID}, nil)
 
-		order, err := service.GetOrderInfo(context.Background(), orderID)
+		order, err := service.GetOrderInfo(context.Background(), 1, orderID)
 		require.NoError(t, err)
 		assert.NotNil(t, order)
 		assert.Equal(t, order.OrderID, orderID)
@@ -36,35 +39,69 @@ func TestAccrualOrdersService_GetOrderInfo(t *testing.T) {
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetByID(context.Background(), orderID).
+			GetByID(context.Background(), 1, orderID).
 			Return(nil, domain.ErrNotFound)
 
-		order, err := service.GetOrderInfo(context.Background(), orderID)
+		order, err := service.GetOrderInfo(context.Background(), 1, orderID)
 		require.ErrorIs(t, err, domain.ErrNotFound)
 		assert.Nil(t, order)
 	})
 }
 
+func TestAccrualOrdersService_GetOrderBreakdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registeredOrdersRepo := repomock.NewMockregisteredOrdersRepository(ctrl)
+	service := NewAccrualOrdersService(registeredOrdersRepo, repomock.NewMockmatchingRewardsRepository(ctrl))
+
+	t.Run("valid", func(t *testing.T) {
+		orderID := "123"
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetBreakdown(context.Background(), 1, orderID).
+			Return(&domain.AccrualBreakdown{Accrual: domain.MoneyFromInt(10)}, nil)
+
+		breakdown, err := service.GetOrderBreakdown(context.Background(), 1, orderID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MoneyFromInt(10), breakdown.Accrual)
+	})
+
+	t.Run("invalid (not calculated yet)", func(t *testing.T) {
+		orderID := "123"
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetBreakdown(context.Background(), 1, orderID).
+			Return(nil, domain.ErrNoBreakdown)
+
+		breakdown, err := service.GetOrderBreakdown(context.Background(), 1, orderID)
+		require.ErrorIs(t, err, domain.ErrNoBreakdown)
+		assert.Nil(t, breakdown)
+	})
+}
+
 func TestAccrualOrdersService_RegisterOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	registeredOrdersRepo := repomock.NewMockregisteredOrdersRepository(ctrl)
-	service := NewAccrualOrdersService(registeredOrdersRepo)
+	service := NewAccrualOrdersService(registeredOrdersRepo, repomock.NewMockmatchingRewardsRepository(ctrl))
 
 	t.Run("valid", func(t *testing.T) {
 		orderID := "123"
 		goods := []domain.OrderGood{
 			{
 				Description: "123",
-				Price:       123.00,
+				Price:       domain.MoneyFromInt(123),
 			},
 		}
 
 		registeredOrdersRepo.
 			EXPECT().
-			RegisterOrder(context.Background(), orderID, goods).
+			RegisterOrder(context.Background(), 1, orderID, nil, []domain.OrderGood{
+				{Description: "123", Quantity: 1, Price: domain.MoneyFromInt(123)},
+			}).
 			Return(&domain.RegisteredOrder{OrderID: orderID}, nil)
 
-		order, err := service.RegisterOrder(context.Background(), orderID, goods)
+		order, err := service.RegisterOrder(context.Background(), 1, orderID, nil, goods)
 		require.NoError(t, err)
 		assert.NotNil(t, order)
 		assert.Equal(t, order.OrderID, orderID)
@@ -75,17 +112,225 @@ func TestAccrualOrdersService_RegisterOrder(t *testing.T) {
 		goods := []domain.OrderGood{
 			{
 				Description: "123",
-				Price:       123.00,
+				Price:       domain.MoneyFromInt(123),
 			},
 		}
 
 		registeredOrdersRepo.
 			EXPECT().
-			RegisterOrder(context.Background(), orderID, goods).
+			RegisterOrder(context.Background(), 1, orderID, nil, gomock.Any()).
 			Return(nil, domain.ErrOrderAlreadyRegisteredForAccrual)
 
-		order, err := service.RegisterOrder(context.Background(), orderID, goods)
+		order, err := service.RegisterOrder(context.Background(), 1, orderID, nil, goods)
 		assert.ErrorIs(t, err, domain.ErrOrderAlreadyRegisteredForAccrual)
 		assert.Nil(t, order)
 	})
+
+	t.Run("valid (price from unit price)", func(t *testing.T) {
+		orderID := "124"
+		unitPrice := domain.MustParseMoney("2.50")
+		goods := []domain.OrderGood{{Description: "Tea", SKU: "TEA-1", Quantity: 3, UnitPrice: &unitPrice}}
+
+		registeredOrdersRepo.
+			EXPECT().
+			RegisterOrder(context.Background(), 1, orderID, nil, []domain.OrderGood{
+				{Description: "Tea", SKU: "TEA-1", Quantity: 3, UnitPrice: &unitPrice, Price: domain.MustParseMoney("7.50")},
+			}).
+			Return(&domain.RegisteredOrder{OrderID: orderID}, nil)
+
+		order, err := service.RegisterOrder(context.Background(), 1, orderID, nil, goods)
+		require.NoError(t, err)
+		assert.NotNil(t, order)
+		assert.True(t, goods[0].Price.IsZero(), "goods of the caller are left as they are")
+	})
+
+	t.Run("invalid (price does not add up)", func(t *testing.T) {
+		unitPrice := domain.MoneyFromInt(2)
+		goods := []domain.OrderGood{{Description: "Tea", Quantity: 3, UnitPrice: &unitPrice, Price: domain.MoneyFromInt(5)}}
+
+		order, err := service.RegisterOrder(context.Background(), 1, "125", nil, goods)
+		assert.ErrorIs(t, err, domain.ErrInvalidOrderGood)
+		assert.Nil(t, order)
+	})
+	t.Run("invalid (quantity too large)", func(t *testing.T) {
+		unitPrice := domain.MoneyFromInt(2)
+		goods := []domain.OrderGood{{Description: "Tea", Quantity: math.MaxInt64 / 100, UnitPrice: &unitPrice}}
+
+		order, err := service.RegisterOrder(context.Background(), 1, "126", nil, goods)
+		assert.ErrorIs(t, err, domain.ErrInvalidOrderGood)
+		assert.Nil(t, order)
+	})
+
+	t.Run("invalid (price too large)", func(t *testing.T) {
+		unitPrice := domain.MoneyFromMinor(math.MaxInt64 / 100)
+		goods := []domain.OrderGood{{Description: "Tea", Quantity: 1000, UnitPrice: &unitPrice}}
+
+		order, err := service.RegisterOrder(context.Background(), 1, "127", nil, goods)
+		assert.ErrorIs(t, err, domain.ErrInvalidOrderGood)
+		assert.Nil(t, order)
+	})
+}
+
+func TestAccrualOrdersService_QuoteOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matchingRewardsRepo := repomock.NewMockmatchingRewardsRepository(ctrl)
+	service := NewAccrualOrdersService(repomock.NewMockregisteredOrdersRepository(ctrl), matchingRewardsRepo)
+
+	at := time.Date(2026, time.October, 16, 15, 0, 0, 0, time.UTC)
+
+	t.Run("valid", func(t *testing.T) {
+		goods := []domain.OrderGood{
+			{Description: "Bork kettle", SKU: "BK-810", Quantity: 2, Price: domain.MoneyFromInt(2000)},
+			{Description: "Philips iron", Price: domain.MoneyFromInt(500)},
+		}
+		prepared := []domain.OrderGood{goods[0], goods[1]}
+		prepared[1].Quantity = 1
+
+		matchingRewardsRepo.
+			EXPECT().
+			GetRewardsWithMatches(context.Background(), 1, prepared, at, at).
+			Return([]domain.GoodReward{
+				{ID: 1, Match: "BK-810", MatchType: domain.ExactMatchType, MatchField: domain.SKUMatchField, RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(5)},
+				{ID: 2, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(1)},
+				{ID: 3, RewardType: domain.OrderPointRewardType, Reward: domain.MoneyFromInt(100), MinBasket: &prepared[0].Price},
+			}, nil)
+
+		quote, err := service.QuoteOrder(context.Background(), 1, at, goods)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MoneyFromInt(130), quote.Accrual)
+		require.Len(t, quote.Goods, 2)
+		assert.Equal(t, domain.MoneyFromInt(30), quote.Goods[0].Accrual)
+		assert.Equal(t, []domain.RuleContribution{
+			{RuleID: 1, RewardType: domain.PointRewardType, Amount: domain.MoneyFromInt(10)},
+			{RuleID: 2, RewardType: domain.PercentRewardType, Amount: domain.MoneyFromInt(20)},
+		}, quote.Goods[0].Rewards)
+		assert.Empty(t, quote.Goods[1].Rewards)
+		assert.Equal(t, []domain.RuleContribution{
+			{RuleID: 3, RewardType: domain.OrderPointRewardType, Amount: domain.MoneyFromInt(100)},
+		}, quote.Order)
+	})
+
+	t.Run("invalid (negative price)", func(t *testing.T) {
+		quote, err := service.QuoteOrder(
+			context.Background(), 1, at, []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(-1)}},
+		)
+		assert.ErrorIs(t, err, domain.ErrInvalidOrderGood)
+		assert.Nil(t, quote)
+	})
+}
+
+func TestAccrualOrdersService_RecalculateOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	registeredOrdersRepo := repomock.NewMockregisteredOrdersRepository(ctrl)
+	matchingRewardsRepo := repomock.NewMockmatchingRewardsRepository(ctrl)
+	service := NewAccrualOrdersService(registeredOrdersRepo, matchingRewardsRepo)
+
+	purchasedAt := time.Date(2026, time.October, 16, 15, 0, 0, 0, time.UTC)
+
+	t.Run("valid", func(t *testing.T) {
+		recalculation := domain.Recalculation{OrderIDs: []string{"1", "2"}, Reason: " wrong reward "}
+		goods := []domain.OrderGood{{Description: "Bork kettle", Quantity: 1, Price: domain.MoneyFromInt(2000)}}
+		rewards := []domain.GoodReward{
+			{ID: 7, Version: 2, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(5)},
+		}
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetRecalculatedOrders(context.Background(), 1, gomock.Any(), domain.MaxRecalculatedOrders+1).
+			Return([]domain.RegisteredOrder{
+				{MerchantID: 1, OrderID: "1", PurchasedAt: &purchasedAt},
+				{MerchantID: 1, OrderID: "2", PurchasedAt: &purchasedAt},
+			}, nil)
+
+		for _, orderID := range []string{"1", "2"} {
+			registeredOrdersRepo.EXPECT().GetOrderGoods(context.Background(), 1, orderID).Return(goods, nil)
+			matchingRewardsRepo.
+				EXPECT().
+				GetRewardsWithMatches(context.Background(), 1, goods, purchasedAt, gomock.Any()).
+				Return(rewards, nil)
+		}
+
+		registeredOrdersRepo.
+			EXPECT().
+			RecalculateOrderAccrual(context.Background(), 1, "1", gomock.Any(), "wrong reward").
+			DoAndReturn(func(
+				_ context.Context, _ int, orderID string, breakdown *domain.AccrualBreakdown, reason string,
+			) (*domain.AccrualAdjustment, error) {
+				assert.Equal(t, domain.MoneyFromInt(100), breakdown.Accrual)
+				return &domain.AccrualAdjustment{
+					ID: 1, OrderID: orderID, OldAccrual: domain.MoneyFromInt(40), NewAccrual: breakdown.Accrual,
+					Delta: domain.MoneyFromInt(60), Reason: reason,
+				}, nil
+			})
+		// The accrual of order 2 stays the same.
+		registeredOrdersRepo.
+			EXPECT().
+			RecalculateOrderAccrual(context.Background(), 1, "2", gomock.Any(), "wrong reward").
+			Return(nil, nil)
+
+		result, err := service.RecalculateOrders(context.Background(), 1, recalculation)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, result.Orders)
+		require.Len(t, result.Adjustments, 1)
+		assert.Equal(t, domain.MoneyFromInt(60), result.Adjustments[0].Delta)
+	})
+
+	t.Run("invalid (order fails midway)", func(t *testing.T) {
+		recalculation := domain.Recalculation{OrderIDs: []string{"1", "2"}, Reason: "wrong reward"}
+		goods := []domain.OrderGood{{Description: "Bork kettle", Quantity: 1, Price: domain.MoneyFromInt(2000)}}
+		adjustment := &domain.AccrualAdjustment{ID: 1, OrderID: "1", Delta: domain.MoneyFromInt(60)}
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetRecalculatedOrders(context.Background(), 1, gomock.Any(), domain.MaxRecalculatedOrders+1).
+			Return([]domain.RegisteredOrder{
+				{MerchantID: 1, OrderID: "1", PurchasedAt: &purchasedAt},
+				{MerchantID: 1, OrderID: "2", PurchasedAt: &purchasedAt},
+			}, nil)
+		registeredOrdersRepo.EXPECT().GetOrderGoods(context.Background(), 1, "1").Return(goods, nil)
+		matchingRewardsRepo.
+			EXPECT().
+			GetRewardsWithMatches(context.Background(), 1, goods, purchasedAt, gomock.Any()).
+			Return(nil, nil)
+		registeredOrdersRepo.
+			EXPECT().
+			RecalculateOrderAccrual(context.Background(), 1, "1", gomock.Any(), "wrong reward").
+			Return(adjustment, nil)
+		registeredOrdersRepo.
+			EXPECT().
+			GetOrderGoods(context.Background(), 1, "2").
+			Return(nil, fmt.Errorf("random error"))
+
+		result, err := service.RecalculateOrders(context.Background(), 1, recalculation)
+		assert.Error(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"1"}, result.Orders)
+		assert.Equal(t, []domain.AccrualAdjustment{*adjustment}, result.Adjustments)
+		assert.Equal(t, "2", result.FailedOrder)
+	})
+
+	t.Run("invalid (orders and range)", func(t *testing.T) {
+		to := purchasedAt.Add(time.Hour)
+
+		result, err := service.RecalculateOrders(context.Background(), 1, domain.Recalculation{
+			OrderIDs: []string{"1"}, From: &purchasedAt, To: &to, Reason: "wrong reward",
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidRecalculation)
+		assert.Nil(t, result)
+	})
+
+	t.Run("invalid (too many orders in range)", func(t *testing.T) {
+		to := purchasedAt.Add(time.Hour)
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetRecalculatedOrders(context.Background(), 1, gomock.Any(), domain.MaxRecalculatedOrders+1).
+			Return(make([]domain.RegisteredOrder, domain.MaxRecalculatedOrders+1), nil)
+
+		result, err := service.RecalculateOrders(context.Background(), 1, domain.Recalculation{
+			From: &purchasedAt, To: &to, Reason: "wrong reward",
+		})
+		assert.ErrorIs(t, err, domain.ErrTooManyOrders)
+		assert.Nil(t, result)
+	})
 }
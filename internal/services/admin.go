@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// MaxAdminReasonLength is the longest reason accepted for staff actions.
+const MaxAdminReasonLength = 255
+
+type userRepositoryForAdmin interface {
+	GetByID(ctx context.Context, id int) (*domain.User, error)
+	GetByLogin(ctx context.Context, login string) (*domain.User, error)
+	SetRole(ctx context.Context, id int, role string) (*domain.User, error)
+	Lock(ctx context.Context, id int, reason string) (*domain.User, error)
+	Unlock(ctx context.Context, id int) (*domain.User, error)
+}
+
+type balanceActionsRepositoryForAdmin interface {
+	AdjustBalance(
+		ctx context.Context, userID int, actorID int, amount domain.Money, reason string, expiresAt time.Time,
+	) (*domain.BalanceAction, error)
+}
+
+type userOrderRepositoryForAdmin interface {
+	RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error)
+}
+
+type userBalanceGetter interface {
+	GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error)
+}
+
+type loginThrottleRepositoryForAdmin interface {
+	Clear(ctx context.Context, scope string, key string) error
+}
+
+type sessionRevoker interface {
+	LogoutEverywhere(ctx context.Context, userID int) error
+}
+
+type AdminService struct {
+	userRepository           userRepositoryForAdmin
+	balanceActionsRepository balanceActionsRepositoryForAdmin
+	userOrderRepository      userOrderRepositoryForAdmin
+	balanceGetter            userBalanceGetter
+	sessionRevoker           sessionRevoker
+	loginThrottleRepository  loginThrottleRepositoryForAdmin
+	pointsTTL                time.Duration
+}
+
+func NewAdminService(
+	userRepository userRepositoryForAdmin,
+	balanceActionsRepository balanceActionsRepositoryForAdmin,
+	userOrderRepository userOrderRepositoryForAdmin,
+	balanceGetter userBalanceGetter,
+	sessionRevoker sessionRevoker,
+	loginThrottleRepository loginThrottleRepositoryForAdmin,
+	pointsTTL time.Duration,
+) *AdminService {
+	return &AdminService{
+		userRepository:           userRepository,
+		balanceActionsRepository: balanceActionsRepository,
+		userOrderRepository:      userOrderRepository,
+		balanceGetter:            balanceGetter,
+		sessionRevoker:           sessionRevoker,
+		loginThrottleRepository:  loginThrottleRepository,
+		pointsTTL:                pointsTTL,
+	}
+}
+
+func (s *AdminService) GetUser(ctx context.Context, userID int) (*domain.User, error) {
+	return s.userRepository.GetByID(ctx, userID)
+}
+
+func (s *AdminService) FindUserByLogin(ctx context.Context, login string) (*domain.User, error) {
+	return s.userRepository.GetByLogin(ctx, login)
+}
+
+func (s *AdminService) GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error) {
+	if _, err := s.userRepository.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.balanceGetter.GetUserBalance(ctx, userID)
+}
+
+// AdjustBalance credits or debits the user by hand on behalf of actorID.
+// Credited points expire like accrued ones.
+func (s *AdminService) AdjustBalance(
+	ctx context.Context, actorID int, userID int, amount domain.Money, reason string,
+) (*domain.BalanceAction, error) {
+	reason, err := normalizeAdminReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.IsZero() {
+		return nil, domain.ErrInvalidAdjustment
+	}
+
+	expiresAt := time.Now().UTC().Add(s.pointsTTL)
+
+	return s.balanceActionsRepository.AdjustBalance(ctx, userID, actorID, amount, reason, expiresAt)
+}
+
+func (s *AdminService) RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error) {
+	return s.userOrderRepository.RequeueOrder(ctx, orderID)
+}
+
+// LockUser keeps the user from logging in and ends every session they have.
+// Staff can not lock users with a higher role than their own.
+func (s *AdminService) LockUser(ctx context.Context, actorRole string, userID int, reason string) (*domain.User, error) {
+	reason, err := normalizeAdminReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkActorRole(ctx, actorRole, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.Lock(ctx, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRevoker.LogoutEverywhere(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UnlockUser lets the user log in again. Like LockUser, it is kept from
+// staff with a lower role than the user.
+func (s *AdminService) UnlockUser(ctx context.Context, actorRole string, userID int) (*domain.User, error) {
+	if err := s.checkActorRole(ctx, actorRole, userID); err != nil {
+		return nil, err
+	}
+
+	return s.userRepository.Unlock(ctx, userID)
+}
+
+// ClearLoginLockout forgets the failed logins of the user, lifting the
+// backoff or lockout they caused. Per IP counters are left alone.
+func (s *AdminService) ClearLoginLockout(ctx context.Context, userID int) error {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.loginThrottleRepository.Clear(ctx, domain.LoginThrottleScope, user.Login)
+}
+
+// SetUserRole changes the role of the user. Their sessions are ended so the
+// new role applies right away instead of when the access tokens expire.
+func (s *AdminService) SetUserRole(ctx context.Context, userID int, role string) (*domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	user, err := s.userRepository.SetRole(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRevoker.LogoutEverywhere(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkActorRole fails when the user has a higher role than the staff member
+// acting on them, so support can not lock an admin out.
+func (s *AdminService) checkActorRole(ctx context.Context, actorRole string, userID int) error {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if domain.RoleOutranks(user.Role, actorRole) {
+		return domain.ErrUserOutranksActor
+	}
+
+	return nil
+}
+
+func normalizeAdminReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+
+	if len(reason) == 0 || len(reason) > MaxAdminReasonLength {
+		return "", domain.ErrReasonRequired
+	}
+
+	return reason, nil
+}
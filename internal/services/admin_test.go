@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+type adminServiceMocks struct {
+	userRepo           *repomock.MockuserRepositoryForAdmin
+	balanceActionsRepo *repomock.MockbalanceActionsRepositoryForAdmin
+	userOrderRepo      *repomock.MockuserOrderRepositoryForAdmin
+	balanceGetter      *repomock.MockuserBalanceGetter
+	sessionRevoker     *repomock.MocksessionRevoker
+	loginThrottleRepo  *repomock.MockloginThrottleRepositoryForAdmin
+}
+
+func newAdminServiceForTest(t *testing.T) (*AdminService, adminServiceMocks) {
+	ctrl := gomock.NewController(t)
+
+	mocks := adminServiceMocks{
+		userRepo:           repomock.NewMockuserRepositoryForAdmin(ctrl),
+		balanceActionsRepo: repomock.NewMockbalanceActionsRepositoryForAdmin(ctrl),
+		userOrderRepo:      repomock.NewMockuserOrderRepositoryForAdmin(ctrl),
+		balanceGetter:      repomock.NewMockuserBalanceGetter(ctrl),
+		sessionRevoker:     repomock.NewMocksessionRevoker(ctrl),
+		loginThrottleRepo:  repomock.NewMockloginThrottleRepositoryForAdmin(ctrl),
+	}
+
+	service := NewAdminService(
+		mocks.userRepo,
+		mocks.balanceActionsRepo,
+		mocks.userOrderRepo,
+		mocks.balanceGetter,
+		mocks.sessionRevoker,
+		mocks.loginThrottleRepo,
+		time.Hour*24,
+	)
+
+	return service, mocks
+}
+
+func TestAdminService_GetUserBalance(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7}, nil)
+		mocks.balanceGetter.
+			EXPECT().
+			GetUserBalance(context.Background(), 7).
+			Return(&domain.UserBalance{Current: domain.MoneyFromInt(10)}, nil)
+
+		balance, err := service.GetUserBalance(context.Background(), 7)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MoneyFromInt(10), balance.Current)
+	})
+
+	t.Run("invalid (unknown user)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 8).Return(nil, domain.ErrNotFound)
+
+		balance, err := service.GetUserBalance(context.Background(), 8)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, balance)
+	})
+}
+
+func TestAdminService_AdjustBalance(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.balanceActionsRepo.
+			EXPECT().
+			AdjustBalance(context.Background(), 7, 1, domain.MoneyFromInt(-5), "goodwill correction", gomock.Any()).
+			Return(&domain.BalanceAction{ID: 3, Kind: domain.AdjustmentBalanceActionKind}, nil)
+
+		action, err := service.AdjustBalance(context.Background(), 1, 7, domain.MoneyFromInt(-5), "  goodwill correction ")
+		require.NoError(t, err)
+		assert.Equal(t, domain.AdjustmentBalanceActionKind, action.Kind)
+	})
+
+	t.Run("invalid (no reason)", func(t *testing.T) {
+		action, err := service.AdjustBalance(context.Background(), 1, 7, domain.MoneyFromInt(5), " ")
+		require.ErrorIs(t, err, domain.ErrReasonRequired)
+		assert.Nil(t, action)
+	})
+
+	t.Run("invalid (reason too long)", func(t *testing.T) {
+		action, err := service.AdjustBalance(context.Background(), 1, 7, domain.MoneyFromInt(5), strings.Repeat("a", MaxAdminReasonLength+1))
+		require.ErrorIs(t, err, domain.ErrReasonRequired)
+		assert.Nil(t, action)
+	})
+
+	t.Run("invalid (zero amount)", func(t *testing.T) {
+		action, err := service.AdjustBalance(context.Background(), 1, 7, domain.Money{}, "reason")
+		require.ErrorIs(t, err, domain.ErrInvalidAdjustment)
+		assert.Nil(t, action)
+	})
+}
+
+func TestAdminService_LockUser(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		lockedAt := time.Now().UTC()
+
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Role: domain.CustomerRole}, nil)
+		mocks.userRepo.EXPECT().Lock(context.Background(), 7, "fraud").Return(&domain.User{ID: 7, LockedAt: &lockedAt}, nil)
+		mocks.sessionRevoker.EXPECT().LogoutEverywhere(context.Background(), 7).Return(nil)
+
+		user, err := service.LockUser(context.Background(), domain.SupportRole, 7, "fraud")
+		require.NoError(t, err)
+		assert.True(t, user.IsLocked())
+	})
+
+	t.Run("valid (same role)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 9).Return(&domain.User{ID: 9, Role: domain.AdminRole}, nil)
+		mocks.userRepo.EXPECT().Lock(context.Background(), 9, "fraud").Return(&domain.User{ID: 9}, nil)
+		mocks.sessionRevoker.EXPECT().LogoutEverywhere(context.Background(), 9).Return(nil)
+
+		_, err := service.LockUser(context.Background(), domain.AdminRole, 9, "fraud")
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid (user outranks actor)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 9).Return(&domain.User{ID: 9, Role: domain.AdminRole}, nil)
+
+		user, err := service.LockUser(context.Background(), domain.SupportRole, 9, "fraud")
+		require.ErrorIs(t, err, domain.ErrUserOutranksActor)
+		assert.Nil(t, user)
+	})
+
+	t.Run("invalid (unknown user)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 8).Return(nil, domain.ErrNotFound)
+
+		user, err := service.LockUser(context.Background(), domain.SupportRole, 8, "fraud")
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, user)
+	})
+
+	t.Run("invalid (no reason)", func(t *testing.T) {
+		user, err := service.LockUser(context.Background(), domain.SupportRole, 7, "")
+		require.ErrorIs(t, err, domain.ErrReasonRequired)
+		assert.Nil(t, user)
+	})
+}
+
+func TestAdminService_UnlockUser(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Role: domain.SupportRole}, nil)
+		mocks.userRepo.EXPECT().Unlock(context.Background(), 7).Return(&domain.User{ID: 7}, nil)
+
+		user, err := service.UnlockUser(context.Background(), domain.SupportRole, 7)
+		require.NoError(t, err)
+		assert.False(t, user.IsLocked())
+	})
+
+	t.Run("invalid (user outranks actor)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 9).Return(&domain.User{ID: 9, Role: domain.AdminRole}, nil)
+
+		user, err := service.UnlockUser(context.Background(), domain.SupportRole, 9)
+		require.ErrorIs(t, err, domain.ErrUserOutranksActor)
+		assert.Nil(t, user)
+	})
+}
+
+func TestAdminService_SetUserRole(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.userRepo.EXPECT().SetRole(context.Background(), 7, domain.SupportRole).Return(&domain.User{ID: 7, Role: domain.SupportRole}, nil)
+		mocks.sessionRevoker.EXPECT().LogoutEverywhere(context.Background(), 7).Return(nil)
+
+		user, err := service.SetUserRole(context.Background(), 7, domain.SupportRole)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SupportRole, user.Role)
+	})
+
+	t.Run("invalid (unknown role)", func(t *testing.T) {
+		user, err := service.SetUserRole(context.Background(), 7, "root")
+		require.ErrorIs(t, err, domain.ErrInvalidRole)
+		assert.Nil(t, user)
+	})
+}
+
+func TestAdminService_RequeueOrder(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("invalid (processed)", func(t *testing.T) {
+		mocks.userOrderRepo.EXPECT().RequeueOrder(context.Background(), "123").Return(nil, domain.ErrOrderNotRequeueable)
+
+		order, err := service.RequeueOrder(context.Background(), "123")
+		require.ErrorIs(t, err, domain.ErrOrderNotRequeueable)
+		assert.Nil(t, order)
+	})
+}
+
+func TestAdminService_ClearLoginLockout(t *testing.T) {
+	service, mocks := newAdminServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Login: "alice"}, nil)
+		mocks.loginThrottleRepo.EXPECT().Clear(context.Background(), domain.LoginThrottleScope, "alice").Return(nil)
+
+		require.NoError(t, service.ClearLoginLockout(context.Background(), 7))
+	})
+
+	t.Run("invalid (unknown user)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 8).Return(nil, domain.ErrNotFound)
+
+		require.ErrorIs(t, service.ClearLoginLockout(context.Background(), 8), domain.ErrNotFound)
+	})
+}
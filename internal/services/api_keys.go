@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type apiKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	TouchLastUsed(ctx context.Context, id string) error
+}
+
+type APIKeysService struct {
+	repository apiKeyRepository
+}
+
+func NewAPIKeysService(repository apiKeyRepository) *APIKeysService {
+	return &APIKeysService{
+		repository: repository,
+	}
+}
+
+// Create issues a new key with the given scopes, bound to merchantID unless
+// it is nil. The returned Key is the only time the secret is available.
+func (s *APIKeysService) Create(
+	ctx context.Context, name string, merchantID *int, scopes []string,
+) (*domain.IssuedAPIKey, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return nil, domain.ErrAPIKeyNameRequired
+	}
+
+	scopes, err := normalizeAPIKeyScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	key := domain.APIKey{
+		ID:         id,
+		Name:       name,
+		MerchantID: merchantID,
+		SecretHash: hashToken(secret),
+		Scopes:     scopes,
+	}
+
+	if err := s.repository.Create(ctx, &key); err != nil {
+		return nil, err
+	}
+
+	return &domain.IssuedAPIKey{
+		APIKey: key,
+		Key:    key.ID + "." + secret,
+	}, nil
+}
+
+func (s *APIKeysService) List(ctx context.Context) ([]domain.APIKey, error) {
+	return s.repository.List(ctx)
+}
+
+func (s *APIKeysService) Revoke(ctx context.Context, id string) error {
+	return s.repository.Revoke(ctx, id)
+}
+
+// Authenticate returns the active key matching the presented value, or
+// ErrInvalidAPIKey.
+func (s *APIKeysService) Authenticate(ctx context.Context, presented string) (*domain.APIKey, error) {
+	id, secret, ok := strings.Cut(presented, ".")
+
+	if !ok || id == "" || secret == "" {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.repository.GetByID(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+
+		return nil, err
+	}
+
+	if !key.IsActive() || !sameHash(hashToken(secret), key.SecretHash) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > time.Minute {
+		if err := s.repository.TouchLastUsed(ctx, key.ID); err != nil {
+			log.Println("[APIKeysService]", err)
+		}
+	}
+
+	return key, nil
+}
+
+func normalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	unique := make(map[string]struct{}, len(scopes))
+
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+
+		if !domain.IsValidAPIKeyScope(scope) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidAPIKeyScope, scope)
+		}
+
+		unique[scope] = struct{}{}
+	}
+
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPIKeyScope)
+	}
+
+	normalized := make([]string, 0, len(unique))
+
+	for scope := range unique {
+		normalized = append(normalized, scope)
+	}
+
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
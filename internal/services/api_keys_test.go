@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestAPIKeysService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockapiKeyRepository(ctrl)
+	service := NewAPIKeysService(repo)
+
+	t.Run("valid", func(t *testing.T) {
+		var created domain.APIKey
+		merchantID := 2
+
+		repo.
+			EXPECT().
+			Create(context.Background(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key *domain.APIKey) error {
+				created = *key
+				return nil
+			})
+
+		issued, err := service.Create(
+			context.Background(),
+			" shop ",
+			&merchantID,
+			[]string{domain.OrdersWriteScope, domain.RulesWriteScope, domain.OrdersWriteScope},
+		)
+		require.NoError(t, err)
+
+		id, secret, ok := strings.Cut(issued.Key, ".")
+		require.True(t, ok)
+		assert.Equal(t, created.ID, id)
+		assert.Equal(t, hashToken(secret), created.SecretHash)
+		assert.Equal(t, "shop", created.Name)
+		assert.Equal(t, &merchantID, created.MerchantID)
+		assert.Equal(t, []string{domain.OrdersWriteScope, domain.RulesWriteScope}, created.Scopes)
+	})
+
+	t.Run("invalid (unknown scope)", func(t *testing.T) {
+		_, err := service.Create(context.Background(), "shop", nil, []string{"orders:delete"})
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKeyScope)
+	})
+
+	t.Run("invalid (no scopes)", func(t *testing.T) {
+		_, err := service.Create(context.Background(), "shop", nil, nil)
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKeyScope)
+	})
+
+	t.Run("invalid (no name)", func(t *testing.T) {
+		_, err := service.Create(context.Background(), " ", nil, []string{domain.OrdersReadScope})
+		require.ErrorIs(t, err, domain.ErrAPIKeyNameRequired)
+	})
+}
+
+func TestAPIKeysService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockapiKeyRepository(ctrl)
+	service := NewAPIKeysService(repo)
+
+	recently := time.Now().UTC()
+	activeKey := func() *domain.APIKey {
+		return &domain.APIKey{
+			ID:         "kid",
+			SecretHash: hashToken("secret"),
+			Scopes:     []string{domain.OrdersReadScope},
+			LastUsedAt: &recently,
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), "kid").Return(activeKey(), nil)
+
+		key, err := service.Authenticate(context.Background(), "kid.secret")
+		require.NoError(t, err)
+		assert.True(t, key.HasScope(domain.OrdersReadScope))
+	})
+
+	t.Run("valid (records first use)", func(t *testing.T) {
+		key := activeKey()
+		key.LastUsedAt = nil
+
+		repo.EXPECT().GetByID(context.Background(), "kid").Return(key, nil)
+		repo.EXPECT().TouchLastUsed(context.Background(), "kid").Return(nil)
+
+		_, err := service.Authenticate(context.Background(), "kid.secret")
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid (wrong secret)", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), "kid").Return(activeKey(), nil)
+
+		_, err := service.Authenticate(context.Background(), "kid.guess")
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("invalid (revoked)", func(t *testing.T) {
+		key := activeKey()
+		key.RevokedAt = &recently
+
+		repo.EXPECT().GetByID(context.Background(), "kid").Return(key, nil)
+
+		_, err := service.Authenticate(context.Background(), "kid.secret")
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("invalid (unknown key)", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), "nope").Return(nil, domain.ErrNotFound)
+
+		_, err := service.Authenticate(context.Background(), "nope.secret")
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("invalid (malformed)", func(t *testing.T) {
+		_, err := service.Authenticate(context.Background(), "no-dot")
+		require.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+}
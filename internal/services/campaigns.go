@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type campaignRepository interface {
+	Create(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error)
+	GetByID(ctx context.Context, merchantID int, id int) (*domain.Campaign, error)
+	List(ctx context.Context, merchantID int) ([]domain.Campaign, error)
+	Update(ctx context.Context, merchantID int, id int, update domain.CampaignUpdate) (*domain.Campaign, error)
+}
+
+type CampaignsService struct {
+	repository campaignRepository
+}
+
+func NewCampaignsService(repository campaignRepository) *CampaignsService {
+	return &CampaignsService{
+		repository: repository,
+	}
+}
+
+func (s *CampaignsService) CreateCampaign(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error) {
+	campaign.Name = strings.TrimSpace(campaign.Name)
+
+	if err := domain.CheckValidity(campaign.ValidFrom, campaign.ValidTo); err != nil {
+		return nil, err
+	}
+
+	return s.repository.Create(ctx, campaign)
+}
+
+func (s *CampaignsService) GetCampaign(ctx context.Context, merchantID int, id int) (*domain.Campaign, error) {
+	return s.repository.GetByID(ctx, merchantID, id)
+}
+
+func (s *CampaignsService) GetCampaigns(ctx context.Context, merchantID int) ([]domain.Campaign, error) {
+	return s.repository.List(ctx, merchantID)
+}
+
+// UpdateCampaign changes the fields set in update. When the validity window
+// changes, the window it would become is checked first.
+func (s *CampaignsService) UpdateCampaign(
+	ctx context.Context, merchantID int, id int, update domain.CampaignUpdate,
+) (*domain.Campaign, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		update.Name = &name
+	}
+
+	if update.ValidFrom != nil || update.ValidTo != nil {
+		campaign, err := s.repository.GetByID(ctx, merchantID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		update.Apply(campaign)
+
+		if err := domain.CheckValidity(campaign.ValidFrom, campaign.ValidTo); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repository.Update(ctx, merchantID, id, update)
+}
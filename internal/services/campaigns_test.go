@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestCampaignsService_CreateCampaign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockcampaignRepository(ctrl)
+	service := NewCampaignsService(repo)
+
+	validFrom := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	validTo := validFrom.AddDate(0, 1, 0)
+
+	t.Run("valid", func(t *testing.T) {
+		repo.
+			EXPECT().
+			Create(context.Background(), domain.Campaign{MerchantID: 1, Name: "Winter", ValidFrom: &validFrom, ValidTo: &validTo}).
+			Return(&domain.Campaign{ID: 1, Name: "Winter"}, nil)
+
+		campaign, err := service.CreateCampaign(context.Background(), domain.Campaign{
+			MerchantID: 1,
+			Name:       " Winter ",
+			ValidFrom:  &validFrom,
+			ValidTo:    &validTo,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, campaign.ID)
+	})
+
+	t.Run("invalid (empty validity window)", func(t *testing.T) {
+		campaign, err := service.CreateCampaign(context.Background(), domain.Campaign{
+			MerchantID: 1,
+			Name:       "Winter",
+			ValidFrom:  &validTo,
+			ValidTo:    &validFrom,
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidValidity)
+		assert.Nil(t, campaign)
+	})
+}
+
+func TestCampaignsService_UpdateCampaign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockcampaignRepository(ctrl)
+	service := NewCampaignsService(repo)
+
+	validFrom := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("valid (disable)", func(t *testing.T) {
+		disabled := true
+		update := domain.CampaignUpdate{Disabled: &disabled}
+
+		repo.EXPECT().Update(context.Background(), 1, 2, update).Return(&domain.Campaign{ID: 2, Disabled: true}, nil)
+
+		campaign, err := service.UpdateCampaign(context.Background(), 1, 2, update)
+		require.NoError(t, err)
+		assert.True(t, campaign.Disabled)
+	})
+
+	t.Run("invalid (ends before it starts)", func(t *testing.T) {
+		validTo := validFrom.Add(-time.Hour)
+
+		repo.
+			EXPECT().
+			GetByID(context.Background(), 1, 2).
+			Return(&domain.Campaign{ID: 2, ValidFrom: &validFrom}, nil)
+
+		campaign, err := service.UpdateCampaign(context.Background(), 1, 2, domain.CampaignUpdate{ValidTo: &validTo})
+		require.ErrorIs(t, err, domain.ErrInvalidValidity)
+		assert.Nil(t, campaign)
+	})
+}
@@ -2,12 +2,24 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 )
 
 type goodRewardRepository interface {
-	SaveReward(ctx context.Context, match string, reward float64, rewardType string) (*domain.GoodReward, error)
+	SaveReward(ctx context.Context, reward domain.GoodReward) (*domain.GoodReward, error)
+	GetByID(ctx context.Context, merchantID int, id int) (*domain.GoodReward, error)
+	GetPage(ctx context.Context, merchantID int, filter domain.GoodRewardsFilter) (*domain.GoodRewardsPage, error)
+	Update(ctx context.Context, merchantID int, id int, update domain.GoodRewardUpdate) (*domain.GoodReward, error)
+	Delete(ctx context.Context, merchantID int, id int) error
+	GetVersions(ctx context.Context, merchantID int, id int) ([]domain.GoodRewardVersion, error)
+	GetVersion(ctx context.Context, merchantID int, id int, version int) (*domain.GoodRewardVersion, error)
+	GetAll(ctx context.Context, merchantID int) ([]domain.GoodReward, error)
+	ImportRewards(
+		ctx context.Context, merchantID int, rewards []domain.GoodReward, dryRun bool,
+	) ([]domain.GoodRewardImportResult, error)
 }
 
 type GoodRewardsService struct {
@@ -22,8 +34,223 @@ func NewGoodRewardsService(
 	}
 }
 
+// SaveNewGoodReward creates a rule for reward.MerchantID. The match is
+// checked against its match type, substring when none is given, and the
+// validity window and schedule are checked as well.
 func (s *GoodRewardsService) SaveNewGoodReward(
-	ctx context.Context, match string, reward float64, rewardType string,
+	ctx context.Context, reward domain.GoodReward,
 ) (*domain.GoodReward, error) {
-	return s.goodRewardRepository.SaveReward(ctx, match, reward, rewardType)
+	reward.SetDefaults()
+
+	if err := reward.Check(); err != nil {
+		return nil, err
+	}
+
+	return s.goodRewardRepository.SaveReward(ctx, reward)
+}
+
+func (s *GoodRewardsService) GetGoodReward(ctx context.Context, merchantID int, id int) (*domain.GoodReward, error) {
+	return s.goodRewardRepository.GetByID(ctx, merchantID, id)
+}
+
+// GetGoodRewardsPage returns one page of the merchant rules, oldest first
+// unless the filter asks otherwise.
+func (s *GoodRewardsService) GetGoodRewardsPage(
+	ctx context.Context, merchantID int, filter domain.GoodRewardsFilter,
+) (*domain.GoodRewardsPage, error) {
+	err := filter.Normalize(
+		domain.AscSortDirection,
+		domain.ActiveGoodRewardStatus, domain.DisabledGoodRewardStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Search = strings.TrimSpace(filter.Search)
+
+	return s.goodRewardRepository.GetPage(ctx, merchantID, filter)
+}
+
+// UpdateGoodReward changes the fields set in update. When the match, the
+// reward, the validity window or the schedule changes, the rule it would
+// become is checked first.
+func (s *GoodRewardsService) UpdateGoodReward(
+	ctx context.Context, merchantID int, id int, update domain.GoodRewardUpdate,
+) (*domain.GoodReward, error) {
+	if update.Match != nil || update.MatchType != nil || update.MatchField != nil || update.IgnoreCase != nil ||
+		update.Reward != nil || update.RewardType != nil || update.MaxPerGood != nil ||
+		update.MinBasket != nil || update.Tiers != nil ||
+		update.ValidFrom != nil || update.ValidTo != nil || update.Schedule != nil {
+		reward, err := s.goodRewardRepository.GetByID(ctx, merchantID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		update.Apply(reward)
+
+		if err := reward.Check(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.goodRewardRepository.Update(ctx, merchantID, id, update)
+}
+
+// DeleteGoodReward stops the rule from applying and hides it from the list.
+// It stays in the database, see domain.GoodReward.
+func (s *GoodRewardsService) DeleteGoodReward(ctx context.Context, merchantID int, id int) error {
+	return s.goodRewardRepository.Delete(ctx, merchantID, id)
+}
+
+// GetGoodRewardVersions returns the history of a rule, oldest version first.
+// Deleted rules keep their history.
+func (s *GoodRewardsService) GetGoodRewardVersions(
+	ctx context.Context, merchantID int, id int,
+) ([]domain.GoodRewardVersion, error) {
+	return s.goodRewardRepository.GetVersions(ctx, merchantID, id)
+}
+
+// DiffGoodRewardVersions lists what changed in a rule from version from to
+// version to.
+func (s *GoodRewardsService) DiffGoodRewardVersions(
+	ctx context.Context, merchantID int, id int, from int, to int,
+) (*domain.GoodRewardDiff, error) {
+	fromVersion, err := s.goodRewardRepository.GetVersion(ctx, merchantID, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := s.goodRewardRepository.GetVersion(ctx, merchantID, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewGoodRewardDiff(*fromVersion, *toVersion)
+}
+
+// ImportGoodRewards creates or replaces the rules read from a file, see
+// GoodRewardRepository.ImportRewards. Every row is checked like a rule sent
+// to the API, and a row may not repeat the id or the match of an earlier one.
+// Either every row is imported or none is: rows that fail are reported and
+// the others are only tried, as in a dry run.
+func (s *GoodRewardsService) ImportGoodRewards(
+	ctx context.Context, merchantID int, rows []domain.ImportedGoodReward, dryRun bool,
+) (*domain.GoodRewardImportReport, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rules to import", domain.ErrInvalidImport)
+	}
+
+	if len(rows) > domain.MaxImportedGoodRewards {
+		return nil, fmt.Errorf("%w: at most %d rules", domain.ErrInvalidImport, domain.MaxImportedGoodRewards)
+	}
+
+	results := make([]domain.GoodRewardImportResult, len(rows))
+	rewards := make([]domain.GoodReward, 0, len(rows))
+	imported := make([]int, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	failed := false
+
+	for i, row := range rows {
+		results[i].Row = row.Row
+		reward := row.Reward
+		err := row.Err
+
+		if err == nil {
+			err = checkImportedGoodReward(&reward)
+		}
+
+		if err == nil {
+			key := importKey(reward)
+
+			if earlier, ok := seen[key]; ok {
+				err = fmt.Errorf("%w: same rule as row %d", domain.ErrInvalidImport, earlier)
+			}
+
+			seen[key] = row.Row
+		}
+
+		if err != nil {
+			failed = true
+			results[i].Action = domain.FailedImportAction
+			results[i].Error = err.Error()
+			continue
+		}
+
+		rewards = append(rewards, reward)
+		imported = append(imported, i)
+	}
+
+	repositoryResults, err := s.goodRewardRepository.ImportRewards(ctx, merchantID, rewards, dryRun || failed)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range imported {
+		result := repositoryResults[j]
+		result.Row = results[i].Row
+		results[i] = result
+	}
+
+	report := domain.GoodRewardImportReport{Rows: make([]domain.GoodRewardImportResult, 0, len(results))}
+
+	for _, result := range results {
+		report.Add(result)
+	}
+
+	report.Imported = !dryRun && report.Failed == 0
+
+	return &report, nil
+}
+
+// ExportGoodRewards returns every rule of the merchant that is not deleted,
+// to be written to a file ImportGoodRewards can read back.
+func (s *GoodRewardsService) ExportGoodRewards(ctx context.Context, merchantID int) ([]domain.GoodReward, error) {
+	return s.goodRewardRepository.GetAll(ctx, merchantID)
+}
+
+// checkImportedGoodReward sets the defaults of an imported rule and checks
+// what the API checks for a new one.
+func checkImportedGoodReward(reward *domain.GoodReward) error {
+	if reward.ID < 0 {
+		return fmt.Errorf("%w: invalid id", domain.ErrInvalidImport)
+	}
+
+	if reward.Match == "" && !domain.IsOrderRewardType(reward.RewardType) {
+		return fmt.Errorf("%w: match is required", domain.ErrInvalidMatch)
+	}
+
+	if reward.MatchType != "" && !domain.IsValidMatchType(reward.MatchType) {
+		return fmt.Errorf("%w: unknown match type %q", domain.ErrInvalidMatch, reward.MatchType)
+	}
+
+	if reward.Stacking != "" && !domain.IsValidStackingMode(reward.Stacking) {
+		return fmt.Errorf("%w: unknown stacking mode %q", domain.ErrInvalidReward, reward.Stacking)
+	}
+
+	if (reward.MaxPerGood != nil && !reward.MaxPerGood.IsPositive()) ||
+		(reward.MaxPerOrder != nil && !reward.MaxPerOrder.IsPositive()) {
+		return fmt.Errorf("%w: caps must be positive", domain.ErrInvalidReward)
+	}
+
+	if reward.CampaignID != nil && *reward.CampaignID <= 0 {
+		return fmt.Errorf("%w: invalid campaign_id", domain.ErrUnknownCampaign)
+	}
+
+	reward.SetDefaults()
+
+	return reward.Check()
+}
+
+// importKey tells which existing rule an imported rule replaces, see
+// GoodRewardRepository.ImportRewards.
+func importKey(reward domain.GoodReward) string {
+	if reward.ID != 0 {
+		return fmt.Sprintf("id %d", reward.ID)
+	}
+
+	return fmt.Sprintf(
+		"%s\x00%s\x00%s\x00%t\x00%t",
+		reward.MatchField, reward.Match, reward.MatchType, reward.IgnoreCase,
+		domain.IsOrderRewardType(reward.RewardType),
+	)
 }
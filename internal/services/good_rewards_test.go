@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,33 +19,352 @@ func TestGoodRewardsService_SaveNewGoodReward(t *testing.T) {
 	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
 	service := NewGoodRewardsService(goodRewardRepo)
 
-	t.Run("valid", func(t *testing.T) {
-		match := "Bork"
-		reward := 10.0
-		rewardType := domain.PercentRewardType
+	t.Run("valid (description, substring and stackable by default)", func(t *testing.T) {
+		reward := domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Bork",
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PercentRewardType,
+		}
+
+		expected := reward
+		expected.MatchType = domain.SubstringMatchType
+		expected.MatchField = domain.DescriptionMatchField
+		expected.Stacking = domain.StackableStackingMode
+
+		goodRewardRepo.
+			EXPECT().
+			SaveReward(context.Background(), expected).
+			Return(&domain.GoodReward{ID: 1, Match: reward.Match}, nil)
+
+		goodReward, err := service.SaveNewGoodReward(context.Background(), reward)
+		require.NoError(t, err)
+		assert.NotNil(t, goodReward)
+	})
+
+	t.Run("valid (categories are matched exactly by default)", func(t *testing.T) {
+		reward := domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Kettles",
+			MatchField: domain.CategoryMatchField,
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PointRewardType,
+		}
+
+		expected := reward
+		expected.MatchType = domain.ExactMatchType
+		expected.Stacking = domain.StackableStackingMode
 
 		goodRewardRepo.
 			EXPECT().
-			SaveReward(context.Background(), match, reward, rewardType).
-			Return(&domain.GoodReward{ID: 1, Match: match, Reward: reward, RewardType: rewardType}, nil)
+			SaveReward(context.Background(), expected).
+			Return(&domain.GoodReward{ID: 2, Match: reward.Match}, nil)
 
-		goodReward, err := service.SaveNewGoodReward(context.Background(), match, reward, rewardType)
+		goodReward, err := service.SaveNewGoodReward(context.Background(), reward)
 		require.NoError(t, err)
 		assert.NotNil(t, goodReward)
 	})
 
+	t.Run("invalid (sku matched by prefix)", func(t *testing.T) {
+		goodReward, err := service.SaveNewGoodReward(context.Background(), domain.GoodReward{
+			MerchantID: 1,
+			Match:      "BK-",
+			MatchType:  domain.PrefixMatchType,
+			MatchField: domain.SKUMatchField,
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PointRewardType,
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidMatch)
+		assert.Nil(t, goodReward)
+	})
+
 	t.Run("invalid (match key already exist error)", func(t *testing.T) {
-		match := "Bork"
-		reward := 10.0
-		rewardType := domain.PercentRewardType
+		reward := domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Bork",
+			MatchType:  domain.WordMatchType,
+			MatchField: domain.DescriptionMatchField,
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PercentRewardType,
+			Stacking:   domain.ExclusiveStackingMode,
+		}
 
 		goodRewardRepo.
 			EXPECT().
-			SaveReward(context.Background(), match, reward, rewardType).
+			SaveReward(context.Background(), reward).
 			Return(nil, domain.ErrMatchKeyAlreadyExists)
 
-		goodReward, err := service.SaveNewGoodReward(context.Background(), match, reward, rewardType)
+		goodReward, err := service.SaveNewGoodReward(context.Background(), reward)
 		require.ErrorIs(t, err, domain.ErrMatchKeyAlreadyExists)
 		assert.Nil(t, goodReward)
 	})
+
+	t.Run("invalid (empty validity window)", func(t *testing.T) {
+		validFrom := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+		goodReward, err := service.SaveNewGoodReward(context.Background(), domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Bork",
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PercentRewardType,
+			ValidFrom:  &validFrom,
+			ValidTo:    &validFrom,
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidValidity)
+		assert.Nil(t, goodReward)
+	})
+
+	t.Run("invalid (schedule)", func(t *testing.T) {
+		goodReward, err := service.SaveNewGoodReward(context.Background(), domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Bork",
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PercentRewardType,
+			Schedule:   &domain.Schedule{Days: []int{9}},
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidSchedule)
+		assert.Nil(t, goodReward)
+	})
+
+	t.Run("invalid (bad regex)", func(t *testing.T) {
+		goodReward, err := service.SaveNewGoodReward(context.Background(), domain.GoodReward{
+			MerchantID: 1,
+			Match:      "Bork(",
+			MatchType:  domain.RegexMatchType,
+			Reward:     domain.MoneyFromInt(10),
+			RewardType: domain.PercentRewardType,
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidMatch)
+		assert.Nil(t, goodReward)
+	})
+}
+
+func TestGoodRewardsService_UpdateGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
+	service := NewGoodRewardsService(goodRewardRepo)
+
+	t.Run("valid (match unchanged)", func(t *testing.T) {
+		disabled := true
+		update := domain.GoodRewardUpdate{Disabled: &disabled}
+
+		goodRewardRepo.
+			EXPECT().
+			Update(context.Background(), 1, 5, update).
+			Return(&domain.GoodReward{ID: 5, Disabled: true}, nil)
+
+		reward, err := service.UpdateGoodReward(context.Background(), 1, 5, update)
+		require.NoError(t, err)
+		assert.True(t, reward.Disabled)
+	})
+
+	t.Run("invalid (stored match is not a valid regex)", func(t *testing.T) {
+		matchType := domain.RegexMatchType
+
+		goodRewardRepo.
+			EXPECT().
+			GetByID(context.Background(), 1, 5).
+			Return(&domain.GoodReward{
+				ID:         5,
+				Match:      "Bork (K810",
+				MatchType:  domain.SubstringMatchType,
+				Reward:     domain.MoneyFromInt(10),
+				RewardType: domain.PercentRewardType,
+			}, nil)
+
+		reward, err := service.UpdateGoodReward(context.Background(), 1, 5, domain.GoodRewardUpdate{MatchType: &matchType})
+		require.ErrorIs(t, err, domain.ErrInvalidMatch)
+		assert.Nil(t, reward)
+	})
+
+	t.Run("invalid (tiered rule without tiers)", func(t *testing.T) {
+		rewardType := domain.TieredPercentRewardType
+		noReward := domain.Money{}
+
+		goodRewardRepo.
+			EXPECT().
+			GetByID(context.Background(), 1, 5).
+			Return(&domain.GoodReward{
+				ID:         5,
+				MatchType:  domain.SubstringMatchType,
+				Reward:     domain.MoneyFromInt(500),
+				RewardType: domain.OrderPointRewardType,
+			}, nil)
+
+		reward, err := service.UpdateGoodReward(
+			context.Background(), 1, 5, domain.GoodRewardUpdate{RewardType: &rewardType, Reward: &noReward},
+		)
+		require.ErrorIs(t, err, domain.ErrInvalidReward)
+		assert.Nil(t, reward)
+	})
+}
+
+func TestGoodRewardsService_GetGoodRewardsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
+	service := NewGoodRewardsService(goodRewardRepo)
+
+	t.Run("valid (defaults applied)", func(t *testing.T) {
+		expectedFilter := domain.GoodRewardsFilter{
+			HistoryFilter: domain.HistoryFilter{Limit: domain.DefaultPageLimit, Sort: domain.AscSortDirection},
+			Search:        "bork",
+		}
+
+		goodRewardRepo.
+			EXPECT().
+			GetPage(context.Background(), 1, expectedFilter).
+			Return(&domain.GoodRewardsPage{Items: []domain.GoodReward{{ID: 1, Match: "Bork"}}}, nil)
+
+		page, err := service.GetGoodRewardsPage(context.Background(), 1, domain.GoodRewardsFilter{Search: " bork "})
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("invalid (unknown status)", func(t *testing.T) {
+		page, err := service.GetGoodRewardsPage(
+			context.Background(), 1, domain.GoodRewardsFilter{HistoryFilter: domain.HistoryFilter{Status: "DELETED"}},
+		)
+		require.ErrorIs(t, err, domain.ErrInvalidHistoryFilter)
+		assert.Nil(t, page)
+	})
+}
+
+func TestGoodRewardsService_DeleteGoodReward(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
+	service := NewGoodRewardsService(goodRewardRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		goodRewardRepo.EXPECT().Delete(context.Background(), 1, 5).Return(nil)
+
+		require.NoError(t, service.DeleteGoodReward(context.Background(), 1, 5))
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		goodRewardRepo.EXPECT().Delete(context.Background(), 1, 6).Return(domain.ErrNotFound)
+
+		require.ErrorIs(t, service.DeleteGoodReward(context.Background(), 1, 6), domain.ErrNotFound)
+	})
+}
+
+func TestGoodRewardsService_DiffGoodRewardVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
+	service := NewGoodRewardsService(goodRewardRepo)
+
+	version := func(number int, reward int64) *domain.GoodRewardVersion {
+		return &domain.GoodRewardVersion{GoodReward: domain.GoodReward{
+			ID: 5, Match: "Bork", Reward: domain.MoneyFromInt(reward), RewardType: domain.PercentRewardType,
+			Version: number,
+		}}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		goodRewardRepo.EXPECT().GetVersion(context.Background(), 1, 5, 1).Return(version(1, 5), nil)
+		goodRewardRepo.EXPECT().GetVersion(context.Background(), 1, 5, 3).Return(version(3, 7), nil)
+
+		diff, err := service.DiffGoodRewardVersions(context.Background(), 1, 5, 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 1, diff.From.Version)
+		assert.Equal(t, 3, diff.To.Version)
+		require.Len(t, diff.Changes, 1)
+		assert.Equal(t, "reward", diff.Changes[0].Field)
+	})
+
+	t.Run("invalid (unknown version)", func(t *testing.T) {
+		goodRewardRepo.EXPECT().GetVersion(context.Background(), 1, 5, 1).Return(version(1, 5), nil)
+		goodRewardRepo.EXPECT().GetVersion(context.Background(), 1, 5, 9).Return(nil, domain.ErrNotFound)
+
+		diff, err := service.DiffGoodRewardVersions(context.Background(), 1, 5, 1, 9)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, diff)
+	})
+}
+
+func TestGoodRewardsService_ImportGoodRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	goodRewardRepo := repomock.NewMockgoodRewardRepository(ctrl)
+	service := NewGoodRewardsService(goodRewardRepo)
+
+	bork := domain.GoodReward{Match: "Bork", Reward: domain.MoneyFromInt(5), RewardType: domain.PercentRewardType}
+	acme := domain.GoodReward{ID: 4, Match: "Acme", Reward: domain.MoneyFromInt(10), RewardType: domain.PointRewardType}
+
+	withDefaults := func(reward domain.GoodReward) domain.GoodReward {
+		reward.SetDefaults()
+		return reward
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		goodRewardRepo.
+			EXPECT().
+			ImportRewards(context.Background(), 1, []domain.GoodReward{withDefaults(bork), withDefaults(acme)}, false).
+			Return([]domain.GoodRewardImportResult{
+				{Action: domain.CreatedImportAction, RuleID: 7},
+				{Action: domain.UnchangedImportAction, RuleID: 4},
+			}, nil)
+
+		report, err := service.ImportGoodRewards(context.Background(), 1, []domain.ImportedGoodReward{
+			{Row: 2, Reward: bork},
+			{Row: 3, Reward: acme},
+		}, false)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.GoodRewardImportReport{
+			Imported:  true,
+			Created:   1,
+			Unchanged: 1,
+			Rows: []domain.GoodRewardImportResult{
+				{Row: 2, Action: domain.CreatedImportAction, RuleID: 7},
+				{Row: 3, Action: domain.UnchangedImportAction, RuleID: 4},
+			},
+		}, report)
+	})
+
+	t.Run("valid (dry run)", func(t *testing.T) {
+		goodRewardRepo.
+			EXPECT().
+			ImportRewards(context.Background(), 1, []domain.GoodReward{withDefaults(bork)}, true).
+			Return([]domain.GoodRewardImportResult{{Action: domain.CreatedImportAction}}, nil)
+
+		report, err := service.ImportGoodRewards(context.Background(), 1, []domain.ImportedGoodReward{
+			{Row: 1, Reward: bork},
+		}, true)
+		require.NoError(t, err)
+		assert.False(t, report.Imported)
+		assert.Equal(t, 1, report.Created)
+	})
+
+	t.Run("invalid rows (the others are only tried)", func(t *testing.T) {
+		invalid := bork
+		invalid.Reward = domain.MoneyFromInt(0)
+
+		goodRewardRepo.
+			EXPECT().
+			ImportRewards(context.Background(), 1, []domain.GoodReward{withDefaults(bork)}, true).
+			Return([]domain.GoodRewardImportResult{{Action: domain.CreatedImportAction}}, nil)
+
+		report, err := service.ImportGoodRewards(context.Background(), 1, []domain.ImportedGoodReward{
+			{Row: 2, Reward: bork},
+			{Row: 3, Err: domain.ErrInvalidImport},
+			{Row: 4, Reward: invalid},
+			{Row: 5, Reward: bork},
+		}, false)
+		require.NoError(t, err)
+		assert.False(t, report.Imported)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 3, report.Failed)
+		assert.Equal(t, domain.FailedImportAction, report.Rows[2].Action)
+		assert.Contains(t, report.Rows[2].Error, "reward must be positive")
+		assert.Contains(t, report.Rows[3].Error, "same rule as row 2")
+	})
+
+	t.Run("invalid (no rows)", func(t *testing.T) {
+		report, err := service.ImportGoodRewards(context.Background(), 1, nil, false)
+		require.ErrorIs(t, err, domain.ErrInvalidImport)
+		assert.Nil(t, report)
+	})
 }
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type balanceActionRepositoryForHolds interface {
+	Hold(ctx context.Context, userID int, orderID string, amount domain.Money, expiresAt time.Time) (*domain.BalanceAction, error)
+	CaptureHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error)
+	ReleaseHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error)
+}
+
+type HoldsService struct {
+	balanceActionRepository balanceActionRepositoryForHolds
+	holdTTL                 time.Duration
+}
+
+func NewHoldsService(
+	balanceActionRepository balanceActionRepositoryForHolds,
+	holdTTL time.Duration,
+) *HoldsService {
+	return &HoldsService{
+		balanceActionRepository: balanceActionRepository,
+		holdTTL:                 holdTTL,
+	}
+}
+
+// HoldBalance reserves amount points for the order until the hold is captured,
+// released or expires.
+func (s *HoldsService) HoldBalance(ctx context.Context, userID int, orderID string, amount domain.Money) (*domain.BalanceAction, error) {
+	return s.balanceActionRepository.Hold(ctx, userID, orderID, amount, time.Now().UTC().Add(s.holdTTL))
+}
+
+func (s *HoldsService) CaptureHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error) {
+	return s.balanceActionRepository.CaptureHold(ctx, userID, holdID)
+}
+
+func (s *HoldsService) ReleaseHold(ctx context.Context, userID int, holdID int) (*domain.BalanceAction, error) {
+	return s.balanceActionRepository.ReleaseHold(ctx, userID, holdID)
+}
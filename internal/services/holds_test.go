@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestHoldsService_HoldBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForHolds(ctrl)
+	service := NewHoldsService(balanceActionRepo, time.Minute*15)
+
+	t.Run("valid", func(t *testing.T) {
+		userID := 1
+		orderID := "100"
+		amount := domain.MoneyFromInt(50)
+		lowerBound := time.Now().UTC().Add(time.Minute * 15)
+
+		balanceActionRepo.
+			EXPECT().
+			Hold(context.Background(), userID, orderID, amount, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID int, orderID string, amount domain.Money, expiresAt time.Time) (*domain.BalanceAction, error) {
+				assert.False(t, expiresAt.Before(lowerBound))
+				return &domain.BalanceAction{ID: 1, UserID: userID, Amount: amount.Neg(), Status: domain.HeldBalanceActionStatus, ExpiresAt: &expiresAt}, nil
+			})
+
+		hold, err := service.HoldBalance(context.Background(), userID, orderID, amount)
+		require.NoError(t, err)
+		assert.Equal(t, domain.HeldBalanceActionStatus, hold.Status)
+	})
+
+	t.Run("invalid (insufficient funds)", func(t *testing.T) {
+		userID := 1
+		orderID := "100"
+		amount := domain.MoneyFromInt(5000)
+
+		balanceActionRepo.
+			EXPECT().
+			Hold(context.Background(), userID, orderID, amount, gomock.Any()).
+			Return(nil, domain.ErrInsufficientFunds)
+
+		hold, err := service.HoldBalance(context.Background(), userID, orderID, amount)
+		require.ErrorIs(t, err, domain.ErrInsufficientFunds)
+		assert.Nil(t, hold)
+	})
+}
+
+func TestHoldsService_CaptureHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForHolds(ctrl)
+	service := NewHoldsService(balanceActionRepo, time.Minute)
+
+	t.Run("valid", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			CaptureHold(context.Background(), 1, 10).
+			Return(&domain.BalanceAction{ID: 10, Status: domain.ProcessedBalanceActionStatus}, nil)
+
+		hold, err := service.CaptureHold(context.Background(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProcessedBalanceActionStatus, hold.Status)
+	})
+
+	t.Run("invalid (expired)", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			CaptureHold(context.Background(), 1, 11).
+			Return(nil, domain.ErrHoldExpired)
+
+		hold, err := service.CaptureHold(context.Background(), 1, 11)
+		require.ErrorIs(t, err, domain.ErrHoldExpired)
+		assert.Nil(t, hold)
+	})
+}
+
+func TestHoldsService_ReleaseHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForHolds(ctrl)
+	service := NewHoldsService(balanceActionRepo, time.Minute)
+
+	t.Run("valid", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			ReleaseHold(context.Background(), 1, 10).
+			Return(&domain.BalanceAction{ID: 10, Status: domain.ReleasedBalanceActionStatus}, nil)
+
+		hold, err := service.ReleaseHold(context.Background(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReleasedBalanceActionStatus, hold.Status)
+	})
+
+	t.Run("invalid (not active)", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			ReleaseHold(context.Background(), 1, 12).
+			Return(nil, domain.ErrHoldNotActive)
+
+		hold, err := service.ReleaseHold(context.Background(), 1, 12)
+		require.ErrorIs(t, err, domain.ErrHoldNotActive)
+		assert.Nil(t, hold)
+	})
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type loginThrottleRepository interface {
+	Get(ctx context.Context, scope string, key string) (*domain.LoginThrottle, error)
+	RecordFailure(ctx context.Context, scope string, key string, now time.Time, windowStart time.Time) (int, error)
+	Block(ctx context.Context, scope string, key string, until time.Time) error
+	Clear(ctx context.Context, scope string, key string) error
+}
+
+// LoginThrottleService slows down password guessing. Failures are counted per
+// login and per client IP; the login counter is reset by a successful login,
+// the IP one only expires, so an attacker can not reset it with an account of
+// their own.
+type LoginThrottleService struct {
+	repo   loginThrottleRepository
+	policy domain.LoginThrottlePolicy
+}
+
+func NewLoginThrottleService(repo loginThrottleRepository, policy domain.LoginThrottlePolicy) *LoginThrottleService {
+	return &LoginThrottleService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// Check returns a LoginThrottledError when the login or the IP has to wait
+// before the next attempt. It must be called before the password is checked.
+func (s *LoginThrottleService) Check(ctx context.Context, login string, clientIP string) error {
+	now := time.Now().UTC()
+
+	for _, scope := range throttleScopes(login, clientIP) {
+		throttle, err := s.repo.Get(ctx, scope.name, scope.key)
+
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		if throttle.BlockedUntil == nil || !throttle.BlockedUntil.After(now) {
+			continue
+		}
+
+		throttledErr := domain.LoginThrottledError{
+			Err:        domain.ErrTooManyLoginAttempts,
+			RetryAfter: throttle.BlockedUntil.Sub(now),
+		}
+
+		if s.policy.IsLockout(throttle) {
+			throttledErr.Err = domain.ErrLoginLocked
+		}
+
+		return throttledErr
+	}
+
+	return nil
+}
+
+func (s *LoginThrottleService) RegisterFailure(ctx context.Context, login string, clientIP string) error {
+	now := time.Now().UTC()
+
+	for _, scope := range throttleScopes(login, clientIP) {
+		failures, err := s.repo.RecordFailure(ctx, scope.name, scope.key, now, now.Add(-s.policy.FailureWindow))
+		if err != nil {
+			return err
+		}
+
+		delay, _ := s.policy.Delay(scope.name, failures)
+
+		if delay <= 0 {
+			continue
+		}
+
+		if err := s.repo.Block(ctx, scope.name, scope.key, now.Add(delay)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *LoginThrottleService) RegisterSuccess(ctx context.Context, login string) error {
+	return s.repo.Clear(ctx, domain.LoginThrottleScope, login)
+}
+
+type throttleScope struct {
+	name string
+	key  string
+}
+
+func throttleScopes(login string, clientIP string) []throttleScope {
+	scopes := []throttleScope{{name: domain.LoginThrottleScope, key: login}}
+
+	if clientIP != "" {
+		scopes = append(scopes, throttleScope{name: domain.IPThrottleScope, key: clientIP})
+	}
+
+	return scopes
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestLoginThrottleService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockloginThrottleRepository(ctrl)
+	service := NewLoginThrottleService(repo, domain.DefaultLoginThrottlePolicy())
+
+	t.Run("valid (no failures)", func(t *testing.T) {
+		repo.EXPECT().Get(context.Background(), domain.LoginThrottleScope, "alice").Return(nil, domain.ErrNotFound)
+		repo.EXPECT().Get(context.Background(), domain.IPThrottleScope, "10.0.0.1").Return(nil, domain.ErrNotFound)
+
+		require.NoError(t, service.Check(context.Background(), "alice", "10.0.0.1"))
+	})
+
+	t.Run("valid (block is over)", func(t *testing.T) {
+		blockedUntil := time.Now().UTC().Add(-time.Second)
+
+		repo.
+			EXPECT().
+			Get(context.Background(), domain.LoginThrottleScope, "alice").
+			Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScope, Failures: 5, BlockedUntil: &blockedUntil}, nil)
+		repo.EXPECT().Get(context.Background(), domain.IPThrottleScope, "10.0.0.1").Return(nil, domain.ErrNotFound)
+
+		require.NoError(t, service.Check(context.Background(), "alice", "10.0.0.1"))
+	})
+
+	t.Run("invalid (login backoff)", func(t *testing.T) {
+		blockedUntil := time.Now().UTC().Add(time.Second * 4)
+
+		repo.
+			EXPECT().
+			Get(context.Background(), domain.LoginThrottleScope, "alice").
+			Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScope, Failures: 6, BlockedUntil: &blockedUntil}, nil)
+
+		err := service.Check(context.Background(), "alice", "10.0.0.1")
+		require.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+
+		var throttledErr domain.LoginThrottledError
+		require.True(t, errors.As(err, &throttledErr))
+		assert.Equal(t, 4, throttledErr.RetryAfterSeconds())
+	})
+
+	t.Run("invalid (login locked)", func(t *testing.T) {
+		blockedUntil := time.Now().UTC().Add(time.Minute * 30)
+
+		repo.
+			EXPECT().
+			Get(context.Background(), domain.LoginThrottleScope, "alice").
+			Return(&domain.LoginThrottle{Scope: domain.LoginThrottleScope, Failures: 10, BlockedUntil: &blockedUntil}, nil)
+
+		err := service.Check(context.Background(), "alice", "10.0.0.1")
+		require.ErrorIs(t, err, domain.ErrLoginLocked)
+	})
+
+	t.Run("invalid (ip backoff)", func(t *testing.T) {
+		blockedUntil := time.Now().UTC().Add(time.Minute)
+
+		repo.EXPECT().Get(context.Background(), domain.LoginThrottleScope, "bob").Return(nil, domain.ErrNotFound)
+		repo.
+			EXPECT().
+			Get(context.Background(), domain.IPThrottleScope, "10.0.0.1").
+			Return(&domain.LoginThrottle{Scope: domain.IPThrottleScope, Failures: 500, BlockedUntil: &blockedUntil}, nil)
+
+		err := service.Check(context.Background(), "bob", "10.0.0.1")
+		require.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+	})
+}
+
+func TestLoginThrottleService_RegisterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockloginThrottleRepository(ctrl)
+	service := NewLoginThrottleService(repo, domain.DefaultLoginThrottlePolicy())
+
+	t.Run("free attempt", func(t *testing.T) {
+		repo.EXPECT().RecordFailure(context.Background(), domain.LoginThrottleScope, "alice", gomock.Any(), gomock.Any()).Return(1, nil)
+		repo.EXPECT().RecordFailure(context.Background(), domain.IPThrottleScope, "10.0.0.1", gomock.Any(), gomock.Any()).Return(1, nil)
+
+		require.NoError(t, service.RegisterFailure(context.Background(), "alice", "10.0.0.1"))
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		before := time.Now().UTC()
+
+		repo.EXPECT().RecordFailure(context.Background(), domain.LoginThrottleScope, "alice", gomock.Any(), gomock.Any()).Return(10, nil)
+		repo.
+			EXPECT().
+			Block(context.Background(), domain.LoginThrottleScope, "alice", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, scope string, key string, until time.Time) error {
+				assert.WithinDuration(t, before.Add(time.Minute*30), until, time.Second)
+				return nil
+			})
+		repo.EXPECT().RecordFailure(context.Background(), domain.IPThrottleScope, "10.0.0.1", gomock.Any(), gomock.Any()).Return(10, nil)
+
+		require.NoError(t, service.RegisterFailure(context.Background(), "alice", "10.0.0.1"))
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type merchantRepository interface {
+	Create(ctx context.Context, code string, name string) (*domain.Merchant, error)
+	GetByID(ctx context.Context, id int) (*domain.Merchant, error)
+	GetByCode(ctx context.Context, code string) (*domain.Merchant, error)
+	List(ctx context.Context) ([]domain.Merchant, error)
+}
+
+type MerchantsService struct {
+	repository merchantRepository
+}
+
+func NewMerchantsService(repository merchantRepository) *MerchantsService {
+	return &MerchantsService{
+		repository: repository,
+	}
+}
+
+func (s *MerchantsService) Create(ctx context.Context, code string, name string) (*domain.Merchant, error) {
+	if !domain.IsValidMerchantCode(code) {
+		return nil, domain.ErrInvalidMerchantCode
+	}
+
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		name = code
+	}
+
+	return s.repository.Create(ctx, code, name)
+}
+
+func (s *MerchantsService) List(ctx context.Context) ([]domain.Merchant, error) {
+	return s.repository.List(ctx)
+}
+
+// GetByCode returns the merchant with the code, or ErrUnknownMerchant.
+func (s *MerchantsService) GetByCode(ctx context.Context, code string) (*domain.Merchant, error) {
+	merchant, err := s.repository.GetByCode(ctx, code)
+
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrUnknownMerchant
+	}
+
+	return merchant, err
+}
+
+// Resolve decides which merchant a request acts for. A key bound to a
+// merchant always acts for it, and asking for another one is refused. Platform
+// keys, and requests without a key when keys are disabled, act for the
+// requested merchant or the default one.
+func (s *MerchantsService) Resolve(ctx context.Context, key *domain.APIKey, requested string) (*domain.Merchant, error) {
+	if key != nil && key.MerchantID != nil {
+		merchant, err := s.repository.GetByID(ctx, *key.MerchantID)
+		if err != nil {
+			return nil, err
+		}
+
+		if requested != "" && requested != merchant.Code {
+			return nil, domain.ErrForeignMerchant
+		}
+
+		return merchant, nil
+	}
+
+	if requested == "" {
+		requested = domain.DefaultMerchantCode
+	}
+
+	return s.GetByCode(ctx, requested)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestMerchantsService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockmerchantRepository(ctrl)
+	service := NewMerchantsService(repo)
+
+	t.Run("valid (name defaults to code)", func(t *testing.T) {
+		repo.EXPECT().Create(context.Background(), "shop", "shop").Return(&domain.Merchant{ID: 2, Code: "shop"}, nil)
+
+		merchant, err := service.Create(context.Background(), "shop", " ")
+		require.NoError(t, err)
+		assert.Equal(t, 2, merchant.ID)
+	})
+
+	t.Run("invalid (code)", func(t *testing.T) {
+		_, err := service.Create(context.Background(), "Shop!", "Shop")
+		require.ErrorIs(t, err, domain.ErrInvalidMerchantCode)
+	})
+}
+
+func TestMerchantsService_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := repomock.NewMockmerchantRepository(ctrl)
+	service := NewMerchantsService(repo)
+
+	shopID := 2
+	shop := &domain.Merchant{ID: shopID, Code: "shop"}
+	boundKey := &domain.APIKey{ID: "kid", MerchantID: &shopID}
+	platformKey := &domain.APIKey{ID: "platform"}
+
+	t.Run("valid (bound key)", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), shopID).Return(shop, nil)
+
+		merchant, err := service.Resolve(context.Background(), boundKey, "")
+		require.NoError(t, err)
+		assert.Equal(t, shop, merchant)
+	})
+
+	t.Run("valid (bound key asks for its own merchant)", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), shopID).Return(shop, nil)
+
+		merchant, err := service.Resolve(context.Background(), boundKey, "shop")
+		require.NoError(t, err)
+		assert.Equal(t, shop, merchant)
+	})
+
+	t.Run("invalid (bound key asks for another merchant)", func(t *testing.T) {
+		repo.EXPECT().GetByID(context.Background(), shopID).Return(shop, nil)
+
+		_, err := service.Resolve(context.Background(), boundKey, "other")
+		require.ErrorIs(t, err, domain.ErrForeignMerchant)
+	})
+
+	t.Run("valid (platform key picks a merchant)", func(t *testing.T) {
+		repo.EXPECT().GetByCode(context.Background(), "shop").Return(shop, nil)
+
+		merchant, err := service.Resolve(context.Background(), platformKey, "shop")
+		require.NoError(t, err)
+		assert.Equal(t, shop, merchant)
+	})
+
+	t.Run("valid (no key falls back to the default merchant)", func(t *testing.T) {
+		repo.
+			EXPECT().
+			GetByCode(context.Background(), domain.DefaultMerchantCode).
+			Return(&domain.Merchant{ID: 1, Code: domain.DefaultMerchantCode}, nil)
+
+		merchant, err := service.Resolve(context.Background(), nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, merchant.ID)
+	})
+
+	t.Run("invalid (unknown merchant)", func(t *testing.T) {
+		repo.EXPECT().GetByCode(context.Background(), "nope").Return(nil, domain.ErrNotFound)
+
+		_, err := service.Resolve(context.Background(), platformKey, "nope")
+		require.ErrorIs(t, err, domain.ErrUnknownMerchant)
+	})
+}
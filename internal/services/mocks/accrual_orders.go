@@ -11,6 +11,7 @@ package repomock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -39,32 +40,145 @@ func (m *MockregisteredOrdersRepository) EXPECT() *MockregisteredOrdersRepositor
 	return m.recorder
 }
 
+// GetAdjustments mocks base method.
+func (m *MockregisteredOrdersRepository) GetAdjustments(ctx context.Context, merchantID *int, after, limit int) ([]domain.AccrualAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustments", ctx, merchantID, after, limit)
+	ret0, _ := ret[0].([]domain.AccrualAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustments indicates an expected call of GetAdjustments.
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetAdjustments(ctx, merchantID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustments", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetAdjustments), ctx, merchantID, after, limit)
+}
+
+// GetBreakdown mocks base method.
+func (m *MockregisteredOrdersRepository) GetBreakdown(ctx context.Context, merchantID int, orderID string) (*domain.AccrualBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBreakdown", ctx, merchantID, orderID)
+	ret0, _ := ret[0].(*domain.AccrualBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBreakdown indicates an expected call of GetBreakdown.
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetBreakdown(ctx, merchantID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBreakdown", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetBreakdown), ctx, merchantID, orderID)
+}
+
 // GetByID mocks base method.
-func (m *MockregisteredOrdersRepository) GetByID(ctx context.Context, orderID string) (*domain.RegisteredOrder, error) {
+func (m *MockregisteredOrdersRepository) GetByID(ctx context.Context, merchantID int, orderID string) (*domain.RegisteredOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, orderID)
+	ret := m.ctrl.Call(m, "GetByID", ctx, merchantID, orderID)
 	ret0, _ := ret[0].(*domain.RegisteredOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockregisteredOrdersRepositoryMockRecorder) GetByID(ctx, orderID any) *gomock.Call {
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetByID(ctx, merchantID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetByID), ctx, merchantID, orderID)
+}
+
+// GetOrderGoods mocks base method.
+func (m *MockregisteredOrdersRepository) GetOrderGoods(ctx context.Context, merchantID int, orderID string) ([]domain.OrderGood, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderGoods", ctx, merchantID, orderID)
+	ret0, _ := ret[0].([]domain.OrderGood)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderGoods indicates an expected call of GetOrderGoods.
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetOrderGoods(ctx, merchantID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderGoods", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetOrderGoods), ctx, merchantID, orderID)
+}
+
+// GetRecalculatedOrders mocks base method.
+func (m *MockregisteredOrdersRepository) GetRecalculatedOrders(ctx context.Context, merchantID int, recalculation domain.Recalculation, limit int) ([]domain.RegisteredOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecalculatedOrders", ctx, merchantID, recalculation, limit)
+	ret0, _ := ret[0].([]domain.RegisteredOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecalculatedOrders indicates an expected call of GetRecalculatedOrders.
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetRecalculatedOrders(ctx, merchantID, recalculation, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetByID), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecalculatedOrders", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetRecalculatedOrders), ctx, merchantID, recalculation, limit)
+}
+
+// RecalculateOrderAccrual mocks base method.
+func (m *MockregisteredOrdersRepository) RecalculateOrderAccrual(ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown, reason string) (*domain.AccrualAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateOrderAccrual", ctx, merchantID, orderID, breakdown, reason)
+	ret0, _ := ret[0].(*domain.AccrualAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecalculateOrderAccrual indicates an expected call of RecalculateOrderAccrual.
+func (mr *MockregisteredOrdersRepositoryMockRecorder) RecalculateOrderAccrual(ctx, merchantID, orderID, breakdown, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateOrderAccrual", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).RecalculateOrderAccrual), ctx, merchantID, orderID, breakdown, reason)
 }
 
 // RegisterOrder mocks base method.
-func (m *MockregisteredOrdersRepository) RegisterOrder(ctx context.Context, orderID string, goods []domain.OrderGood) (*domain.RegisteredOrder, error) {
+func (m *MockregisteredOrdersRepository) RegisterOrder(ctx context.Context, merchantID int, orderID string, purchasedAt *time.Time, goods []domain.OrderGood) (*domain.RegisteredOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterOrder", ctx, orderID, goods)
+	ret := m.ctrl.Call(m, "RegisterOrder", ctx, merchantID, orderID, purchasedAt, goods)
 	ret0, _ := ret[0].(*domain.RegisteredOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterOrder indicates an expected call of RegisterOrder.
-func (mr *MockregisteredOrdersRepositoryMockRecorder) RegisterOrder(ctx, orderID, goods any) *gomock.Call {
+func (mr *MockregisteredOrdersRepositoryMockRecorder) RegisterOrder(ctx, merchantID, orderID, purchasedAt, goods any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).RegisterOrder), ctx, merchantID, orderID, purchasedAt, goods)
+}
+
+// MockmatchingRewardsRepository is a mock of matchingRewardsRepository interface.
+type MockmatchingRewardsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockmatchingRewardsRepositoryMockRecorder
+}
+
+// MockmatchingRewardsRepositoryMockRecorder is the mock recorder for MockmatchingRewardsRepository.
+type MockmatchingRewardsRepositoryMockRecorder struct {
+	mock *MockmatchingRewardsRepository
+}
+
+// NewMockmatchingRewardsRepository creates a new mock instance.
+func NewMockmatchingRewardsRepository(ctrl *gomock.Controller) *MockmatchingRewardsRepository {
+	mock := &MockmatchingRewardsRepository{ctrl: ctrl}
+	mock.recorder = &MockmatchingRewardsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmatchingRewardsRepository) EXPECT() *MockmatchingRewardsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRewardsWithMatches mocks base method.
+func (m *MockmatchingRewardsRepository) GetRewardsWithMatches(ctx context.Context, merchantID int, goods []domain.OrderGood, at, asOf time.Time) ([]domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardsWithMatches", ctx, merchantID, goods, at, asOf)
+	ret0, _ := ret[0].([]domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardsWithMatches indicates an expected call of GetRewardsWithMatches.
+func (mr *MockmatchingRewardsRepositoryMockRecorder) GetRewardsWithMatches(ctx, merchantID, goods, at, asOf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterOrder", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).RegisterOrder), ctx, orderID, goods)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardsWithMatches", reflect.TypeOf((*MockmatchingRewardsRepository)(nil).GetRewardsWithMatches), ctx, merchantID, goods, at, asOf)
 }
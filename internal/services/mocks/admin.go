@@ -0,0 +1,304 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin.go -destination=./mocks/admin.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepositoryForAdmin is a mock of userRepositoryForAdmin interface.
+type MockuserRepositoryForAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForAdminMockRecorder
+}
+
+// MockuserRepositoryForAdminMockRecorder is the mock recorder for MockuserRepositoryForAdmin.
+type MockuserRepositoryForAdminMockRecorder struct {
+	mock *MockuserRepositoryForAdmin
+}
+
+// NewMockuserRepositoryForAdmin creates a new mock instance.
+func NewMockuserRepositoryForAdmin(ctrl *gomock.Controller) *MockuserRepositoryForAdmin {
+	mock := &MockuserRepositoryForAdmin{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForAdmin) EXPECT() *MockuserRepositoryForAdminMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserRepositoryForAdmin) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserRepositoryForAdminMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserRepositoryForAdmin)(nil).GetByID), ctx, id)
+}
+
+// GetByLogin mocks base method.
+func (m *MockuserRepositoryForAdmin) GetByLogin(ctx context.Context, login string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLogin", ctx, login)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLogin indicates an expected call of GetByLogin.
+func (mr *MockuserRepositoryForAdminMockRecorder) GetByLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockuserRepositoryForAdmin)(nil).GetByLogin), ctx, login)
+}
+
+// Lock mocks base method.
+func (m *MockuserRepositoryForAdmin) Lock(ctx context.Context, id int, reason string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, id, reason)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockuserRepositoryForAdminMockRecorder) Lock(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockuserRepositoryForAdmin)(nil).Lock), ctx, id, reason)
+}
+
+// SetRole mocks base method.
+func (m *MockuserRepositoryForAdmin) SetRole(ctx context.Context, id int, role string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockuserRepositoryForAdminMockRecorder) SetRole(ctx, id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockuserRepositoryForAdmin)(nil).SetRole), ctx, id, role)
+}
+
+// Unlock mocks base method.
+func (m *MockuserRepositoryForAdmin) Unlock(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockuserRepositoryForAdminMockRecorder) Unlock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockuserRepositoryForAdmin)(nil).Unlock), ctx, id)
+}
+
+// MockbalanceActionsRepositoryForAdmin is a mock of balanceActionsRepositoryForAdmin interface.
+type MockbalanceActionsRepositoryForAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionsRepositoryForAdminMockRecorder
+}
+
+// MockbalanceActionsRepositoryForAdminMockRecorder is the mock recorder for MockbalanceActionsRepositoryForAdmin.
+type MockbalanceActionsRepositoryForAdminMockRecorder struct {
+	mock *MockbalanceActionsRepositoryForAdmin
+}
+
+// NewMockbalanceActionsRepositoryForAdmin creates a new mock instance.
+func NewMockbalanceActionsRepositoryForAdmin(ctrl *gomock.Controller) *MockbalanceActionsRepositoryForAdmin {
+	mock := &MockbalanceActionsRepositoryForAdmin{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionsRepositoryForAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionsRepositoryForAdmin) EXPECT() *MockbalanceActionsRepositoryForAdminMockRecorder {
+	return m.recorder
+}
+
+// AdjustBalance mocks base method.
+func (m *MockbalanceActionsRepositoryForAdmin) AdjustBalance(ctx context.Context, userID, actorID int, amount domain.Money, reason string, expiresAt time.Time) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBalance", ctx, userID, actorID, amount, reason, expiresAt)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustBalance indicates an expected call of AdjustBalance.
+func (mr *MockbalanceActionsRepositoryForAdminMockRecorder) AdjustBalance(ctx, userID, actorID, amount, reason, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBalance", reflect.TypeOf((*MockbalanceActionsRepositoryForAdmin)(nil).AdjustBalance), ctx, userID, actorID, amount, reason, expiresAt)
+}
+
+// MockuserOrderRepositoryForAdmin is a mock of userOrderRepositoryForAdmin interface.
+type MockuserOrderRepositoryForAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserOrderRepositoryForAdminMockRecorder
+}
+
+// MockuserOrderRepositoryForAdminMockRecorder is the mock recorder for MockuserOrderRepositoryForAdmin.
+type MockuserOrderRepositoryForAdminMockRecorder struct {
+	mock *MockuserOrderRepositoryForAdmin
+}
+
+// NewMockuserOrderRepositoryForAdmin creates a new mock instance.
+func NewMockuserOrderRepositoryForAdmin(ctrl *gomock.Controller) *MockuserOrderRepositoryForAdmin {
+	mock := &MockuserOrderRepositoryForAdmin{ctrl: ctrl}
+	mock.recorder = &MockuserOrderRepositoryForAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserOrderRepositoryForAdmin) EXPECT() *MockuserOrderRepositoryForAdminMockRecorder {
+	return m.recorder
+}
+
+// RequeueOrder mocks base method.
+func (m *MockuserOrderRepositoryForAdmin) RequeueOrder(ctx context.Context, orderID string) (*domain.UserOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOrder", ctx, orderID)
+	ret0, _ := ret[0].(*domain.UserOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueOrder indicates an expected call of RequeueOrder.
+func (mr *MockuserOrderRepositoryForAdminMockRecorder) RequeueOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOrder", reflect.TypeOf((*MockuserOrderRepositoryForAdmin)(nil).RequeueOrder), ctx, orderID)
+}
+
+// MockuserBalanceGetter is a mock of userBalanceGetter interface.
+type MockuserBalanceGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserBalanceGetterMockRecorder
+}
+
+// MockuserBalanceGetterMockRecorder is the mock recorder for MockuserBalanceGetter.
+type MockuserBalanceGetterMockRecorder struct {
+	mock *MockuserBalanceGetter
+}
+
+// NewMockuserBalanceGetter creates a new mock instance.
+func NewMockuserBalanceGetter(ctrl *gomock.Controller) *MockuserBalanceGetter {
+	mock := &MockuserBalanceGetter{ctrl: ctrl}
+	mock.recorder = &MockuserBalanceGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserBalanceGetter) EXPECT() *MockuserBalanceGetterMockRecorder {
+	return m.recorder
+}
+
+// GetUserBalance mocks base method.
+func (m *MockuserBalanceGetter) GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockuserBalanceGetterMockRecorder) GetUserBalance(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockuserBalanceGetter)(nil).GetUserBalance), ctx, userID)
+}
+
+// MockloginThrottleRepositoryForAdmin is a mock of loginThrottleRepositoryForAdmin interface.
+type MockloginThrottleRepositoryForAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginThrottleRepositoryForAdminMockRecorder
+}
+
+// MockloginThrottleRepositoryForAdminMockRecorder is the mock recorder for MockloginThrottleRepositoryForAdmin.
+type MockloginThrottleRepositoryForAdminMockRecorder struct {
+	mock *MockloginThrottleRepositoryForAdmin
+}
+
+// NewMockloginThrottleRepositoryForAdmin creates a new mock instance.
+func NewMockloginThrottleRepositoryForAdmin(ctrl *gomock.Controller) *MockloginThrottleRepositoryForAdmin {
+	mock := &MockloginThrottleRepositoryForAdmin{ctrl: ctrl}
+	mock.recorder = &MockloginThrottleRepositoryForAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginThrottleRepositoryForAdmin) EXPECT() *MockloginThrottleRepositoryForAdminMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockloginThrottleRepositoryForAdmin) Clear(ctx context.Context, scope, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, scope, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockloginThrottleRepositoryForAdminMockRecorder) Clear(ctx, scope, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockloginThrottleRepositoryForAdmin)(nil).Clear), ctx, scope, key)
+}
+
+// MocksessionRevoker is a mock of sessionRevoker interface.
+type MocksessionRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionRevokerMockRecorder
+}
+
+// MocksessionRevokerMockRecorder is the mock recorder for MocksessionRevoker.
+type MocksessionRevokerMockRecorder struct {
+	mock *MocksessionRevoker
+}
+
+// NewMocksessionRevoker creates a new mock instance.
+func NewMocksessionRevoker(ctrl *gomock.Controller) *MocksessionRevoker {
+	mock := &MocksessionRevoker{ctrl: ctrl}
+	mock.recorder = &MocksessionRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionRevoker) EXPECT() *MocksessionRevokerMockRecorder {
+	return m.recorder
+}
+
+// LogoutEverywhere mocks base method.
+func (m *MocksessionRevoker) LogoutEverywhere(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutEverywhere", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutEverywhere indicates an expected call of LogoutEverywhere.
+func (mr *MocksessionRevokerMockRecorder) LogoutEverywhere(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEverywhere", reflect.TypeOf((*MocksessionRevoker)(nil).LogoutEverywhere), ctx, userID)
+}
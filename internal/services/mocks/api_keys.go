@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_keys.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_keys.go -destination=./mocks/api_keys.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockapiKeyRepository is a mock of apiKeyRepository interface.
+type MockapiKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyRepositoryMockRecorder
+}
+
+// MockapiKeyRepositoryMockRecorder is the mock recorder for MockapiKeyRepository.
+type MockapiKeyRepositoryMockRecorder struct {
+	mock *MockapiKeyRepository
+}
+
+// NewMockapiKeyRepository creates a new mock instance.
+func NewMockapiKeyRepository(ctrl *gomock.Controller) *MockapiKeyRepository {
+	mock := &MockapiKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockapiKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyRepository) EXPECT() *MockapiKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockapiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockapiKeyRepositoryMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockapiKeyRepository)(nil).Create), ctx, key)
+}
+
+// GetByID mocks base method.
+func (m *MockapiKeyRepository) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockapiKeyRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockapiKeyRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockapiKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockapiKeyRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockapiKeyRepository)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockapiKeyRepository) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockapiKeyRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockapiKeyRepository)(nil).Revoke), ctx, id)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockapiKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockapiKeyRepositoryMockRecorder) TouchLastUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockapiKeyRepository)(nil).TouchLastUsed), ctx, id)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: campaigns.go
+//
+// Generated by this command:
+//
+//	mockgen -source=campaigns.go -destination=./mocks/campaigns.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcampaignRepository is a mock of campaignRepository interface.
+type MockcampaignRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcampaignRepositoryMockRecorder
+}
+
+// MockcampaignRepositoryMockRecorder is the mock recorder for MockcampaignRepository.
+type MockcampaignRepositoryMockRecorder struct {
+	mock *MockcampaignRepository
+}
+
+// NewMockcampaignRepository creates a new mock instance.
+func NewMockcampaignRepository(ctrl *gomock.Controller) *MockcampaignRepository {
+	mock := &MockcampaignRepository{ctrl: ctrl}
+	mock.recorder = &MockcampaignRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcampaignRepository) EXPECT() *MockcampaignRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockcampaignRepository) Create(ctx context.Context, campaign domain.Campaign) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, campaign)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcampaignRepositoryMockRecorder) Create(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcampaignRepository)(nil).Create), ctx, campaign)
+}
+
+// GetByID mocks base method.
+func (m *MockcampaignRepository) GetByID(ctx context.Context, merchantID, id int) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, merchantID, id)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcampaignRepositoryMockRecorder) GetByID(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcampaignRepository)(nil).GetByID), ctx, merchantID, id)
+}
+
+// List mocks base method.
+func (m *MockcampaignRepository) List(ctx context.Context, merchantID int) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, merchantID)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockcampaignRepositoryMockRecorder) List(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockcampaignRepository)(nil).List), ctx, merchantID)
+}
+
+// Update mocks base method.
+func (m *MockcampaignRepository) Update(ctx context.Context, merchantID, id int, update domain.CampaignUpdate) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, merchantID, id, update)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockcampaignRepositoryMockRecorder) Update(ctx, merchantID, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcampaignRepository)(nil).Update), ctx, merchantID, id, update)
+}
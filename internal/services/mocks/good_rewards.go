@@ -39,17 +39,136 @@ func (m *MockgoodRewardRepository) EXPECT() *MockgoodRewardRepositoryMockRecorde
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockgoodRewardRepository) Delete(ctx context.Context, merchantID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, merchantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockgoodRewardRepositoryMockRecorder) Delete(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockgoodRewardRepository)(nil).Delete), ctx, merchantID, id)
+}
+
+// GetAll mocks base method.
+func (m *MockgoodRewardRepository) GetAll(ctx context.Context, merchantID int) ([]domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, merchantID)
+	ret0, _ := ret[0].([]domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockgoodRewardRepositoryMockRecorder) GetAll(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetAll), ctx, merchantID)
+}
+
+// GetByID mocks base method.
+func (m *MockgoodRewardRepository) GetByID(ctx context.Context, merchantID, id int) (*domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, merchantID, id)
+	ret0, _ := ret[0].(*domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockgoodRewardRepositoryMockRecorder) GetByID(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetByID), ctx, merchantID, id)
+}
+
+// GetPage mocks base method.
+func (m *MockgoodRewardRepository) GetPage(ctx context.Context, merchantID int, filter domain.GoodRewardsFilter) (*domain.GoodRewardsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, merchantID, filter)
+	ret0, _ := ret[0].(*domain.GoodRewardsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockgoodRewardRepositoryMockRecorder) GetPage(ctx, merchantID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetPage), ctx, merchantID, filter)
+}
+
+// GetVersion mocks base method.
+func (m *MockgoodRewardRepository) GetVersion(ctx context.Context, merchantID, id, version int) (*domain.GoodRewardVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, merchantID, id, version)
+	ret0, _ := ret[0].(*domain.GoodRewardVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockgoodRewardRepositoryMockRecorder) GetVersion(ctx, merchantID, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetVersion), ctx, merchantID, id, version)
+}
+
+// GetVersions mocks base method.
+func (m *MockgoodRewardRepository) GetVersions(ctx context.Context, merchantID, id int) ([]domain.GoodRewardVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, merchantID, id)
+	ret0, _ := ret[0].([]domain.GoodRewardVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockgoodRewardRepositoryMockRecorder) GetVersions(ctx, merchantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetVersions), ctx, merchantID, id)
+}
+
+// ImportRewards mocks base method.
+func (m *MockgoodRewardRepository) ImportRewards(ctx context.Context, merchantID int, rewards []domain.GoodReward, dryRun bool) ([]domain.GoodRewardImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRewards", ctx, merchantID, rewards, dryRun)
+	ret0, _ := ret[0].([]domain.GoodRewardImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRewards indicates an expected call of ImportRewards.
+func (mr *MockgoodRewardRepositoryMockRecorder) ImportRewards(ctx, merchantID, rewards, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRewards", reflect.TypeOf((*MockgoodRewardRepository)(nil).ImportRewards), ctx, merchantID, rewards, dryRun)
+}
+
 // SaveReward mocks base method.
-func (m *MockgoodRewardRepository) SaveReward(ctx context.Context, match string, reward float64, rewardType string) (*domain.GoodReward, error) {
+func (m *MockgoodRewardRepository) SaveReward(ctx context.Context, reward domain.GoodReward) (*domain.GoodReward, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveReward", ctx, match, reward, rewardType)
+	ret := m.ctrl.Call(m, "SaveReward", ctx, reward)
 	ret0, _ := ret[0].(*domain.GoodReward)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveReward indicates an expected call of SaveReward.
-func (mr *MockgoodRewardRepositoryMockRecorder) SaveReward(ctx, match, reward, rewardType any) *gomock.Call {
+func (mr *MockgoodRewardRepositoryMockRecorder) SaveReward(ctx, reward any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReward", reflect.TypeOf((*MockgoodRewardRepository)(nil).SaveReward), ctx, reward)
+}
+
+// Update mocks base method.
+func (m *MockgoodRewardRepository) Update(ctx context.Context, merchantID, id int, update domain.GoodRewardUpdate) (*domain.GoodReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, merchantID, id, update)
+	ret0, _ := ret[0].(*domain.GoodReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockgoodRewardRepositoryMockRecorder) Update(ctx, merchantID, id, update any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReward", reflect.TypeOf((*MockgoodRewardRepository)(nil).SaveReward), ctx, match, reward, rewardType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockgoodRewardRepository)(nil).Update), ctx, merchantID, id, update)
 }
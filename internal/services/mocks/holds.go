@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: holds.go
+//
+// Generated by this command:
+//
+//	mockgen -source=holds.go -destination=./mocks/holds.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbalanceActionRepositoryForHolds is a mock of balanceActionRepositoryForHolds interface.
+type MockbalanceActionRepositoryForHolds struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionRepositoryForHoldsMockRecorder
+}
+
+// MockbalanceActionRepositoryForHoldsMockRecorder is the mock recorder for MockbalanceActionRepositoryForHolds.
+type MockbalanceActionRepositoryForHoldsMockRecorder struct {
+	mock *MockbalanceActionRepositoryForHolds
+}
+
+// NewMockbalanceActionRepositoryForHolds creates a new mock instance.
+func NewMockbalanceActionRepositoryForHolds(ctrl *gomock.Controller) *MockbalanceActionRepositoryForHolds {
+	mock := &MockbalanceActionRepositoryForHolds{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionRepositoryForHoldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionRepositoryForHolds) EXPECT() *MockbalanceActionRepositoryForHoldsMockRecorder {
+	return m.recorder
+}
+
+// CaptureHold mocks base method.
+func (m *MockbalanceActionRepositoryForHolds) CaptureHold(ctx context.Context, userID, holdID int) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, userID, holdID)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockbalanceActionRepositoryForHoldsMockRecorder) CaptureHold(ctx, userID, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockbalanceActionRepositoryForHolds)(nil).CaptureHold), ctx, userID, holdID)
+}
+
+// Hold mocks base method.
+func (m *MockbalanceActionRepositoryForHolds) Hold(ctx context.Context, userID int, orderID string, amount domain.Money, expiresAt time.Time) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", ctx, userID, orderID, amount, expiresAt)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockbalanceActionRepositoryForHoldsMockRecorder) Hold(ctx, userID, orderID, amount, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockbalanceActionRepositoryForHolds)(nil).Hold), ctx, userID, orderID, amount, expiresAt)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockbalanceActionRepositoryForHolds) ReleaseHold(ctx context.Context, userID, holdID int) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, userID, holdID)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockbalanceActionRepositoryForHoldsMockRecorder) ReleaseHold(ctx, userID, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockbalanceActionRepositoryForHolds)(nil).ReleaseHold), ctx, userID, holdID)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: login_throttle.go
+//
+// Generated by this command:
+//
+//	mockgen -source=login_throttle.go -destination=./mocks/login_throttle.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockloginThrottleRepository is a mock of loginThrottleRepository interface.
+type MockloginThrottleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginThrottleRepositoryMockRecorder
+}
+
+// MockloginThrottleRepositoryMockRecorder is the mock recorder for MockloginThrottleRepository.
+type MockloginThrottleRepositoryMockRecorder struct {
+	mock *MockloginThrottleRepository
+}
+
+// NewMockloginThrottleRepository creates a new mock instance.
+func NewMockloginThrottleRepository(ctrl *gomock.Controller) *MockloginThrottleRepository {
+	mock := &MockloginThrottleRepository{ctrl: ctrl}
+	mock.recorder = &MockloginThrottleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginThrottleRepository) EXPECT() *MockloginThrottleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockloginThrottleRepository) Block(ctx context.Context, scope, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, scope, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockloginThrottleRepositoryMockRecorder) Block(ctx, scope, key, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockloginThrottleRepository)(nil).Block), ctx, scope, key, until)
+}
+
+// Clear mocks base method.
+func (m *MockloginThrottleRepository) Clear(ctx context.Context, scope, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, scope, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockloginThrottleRepositoryMockRecorder) Clear(ctx, scope, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockloginThrottleRepository)(nil).Clear), ctx, scope, key)
+}
+
+// Get mocks base method.
+func (m *MockloginThrottleRepository) Get(ctx context.Context, scope, key string) (*domain.LoginThrottle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, scope, key)
+	ret0, _ := ret[0].(*domain.LoginThrottle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockloginThrottleRepositoryMockRecorder) Get(ctx, scope, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockloginThrottleRepository)(nil).Get), ctx, scope, key)
+}
+
+// RecordFailure mocks base method.
+func (m *MockloginThrottleRepository) RecordFailure(ctx context.Context, scope, key string, now, windowStart time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, scope, key, now, windowStart)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockloginThrottleRepositoryMockRecorder) RecordFailure(ctx, scope, key, now, windowStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockloginThrottleRepository)(nil).RecordFailure), ctx, scope, key, now, windowStart)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: merchants.go
+//
+// Generated by this command:
+//
+//	mockgen -source=merchants.go -destination=./mocks/merchants.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmerchantRepository is a mock of merchantRepository interface.
+type MockmerchantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockmerchantRepositoryMockRecorder
+}
+
+// MockmerchantRepositoryMockRecorder is the mock recorder for MockmerchantRepository.
+type MockmerchantRepositoryMockRecorder struct {
+	mock *MockmerchantRepository
+}
+
+// NewMockmerchantRepository creates a new mock instance.
+func NewMockmerchantRepository(ctrl *gomock.Controller) *MockmerchantRepository {
+	mock := &MockmerchantRepository{ctrl: ctrl}
+	mock.recorder = &MockmerchantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmerchantRepository) EXPECT() *MockmerchantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockmerchantRepository) Create(ctx context.Context, code, name string) (*domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, code, name)
+	ret0, _ := ret[0].(*domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockmerchantRepositoryMockRecorder) Create(ctx, code, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockmerchantRepository)(nil).Create), ctx, code, name)
+}
+
+// GetByCode mocks base method.
+func (m *MockmerchantRepository) GetByCode(ctx context.Context, code string) (*domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockmerchantRepositoryMockRecorder) GetByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockmerchantRepository)(nil).GetByCode), ctx, code)
+}
+
+// GetByID mocks base method.
+func (m *MockmerchantRepository) GetByID(ctx context.Context, id int) (*domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockmerchantRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockmerchantRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockmerchantRepository) List(ctx context.Context) ([]domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockmerchantRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockmerchantRepository)(nil).List), ctx)
+}
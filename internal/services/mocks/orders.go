@@ -69,17 +69,47 @@ func (mr *MockuserOrderRepositoryMockRecorder) GetByUserID(ctx, userID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockuserOrderRepository)(nil).GetByUserID), ctx, userID)
 }
 
+// GetDetailsByOrderID mocks base method.
+func (m *MockuserOrderRepository) GetDetailsByOrderID(ctx context.Context, orderID string) (*domain.UserOrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailsByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(*domain.UserOrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailsByOrderID indicates an expected call of GetDetailsByOrderID.
+func (mr *MockuserOrderRepositoryMockRecorder) GetDetailsByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailsByOrderID", reflect.TypeOf((*MockuserOrderRepository)(nil).GetDetailsByOrderID), ctx, orderID)
+}
+
+// GetPageByUserID mocks base method.
+func (m *MockuserOrderRepository) GetPageByUserID(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.UserOrdersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageByUserID", ctx, userID, filter)
+	ret0, _ := ret[0].(*domain.UserOrdersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageByUserID indicates an expected call of GetPageByUserID.
+func (mr *MockuserOrderRepositoryMockRecorder) GetPageByUserID(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageByUserID", reflect.TypeOf((*MockuserOrderRepository)(nil).GetPageByUserID), ctx, userID, filter)
+}
+
 // SaveOrder mocks base method.
-func (m *MockuserOrderRepository) SaveOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error) {
+func (m *MockuserOrderRepository) SaveOrder(ctx context.Context, orderID, merchant string, userID int) (*domain.UserOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveOrder", ctx, orderID, userID)
+	ret := m.ctrl.Call(m, "SaveOrder", ctx, orderID, merchant, userID)
 	ret0, _ := ret[0].(*domain.UserOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveOrder indicates an expected call of SaveOrder.
-func (mr *MockuserOrderRepositoryMockRecorder) SaveOrder(ctx, orderID, userID any) *gomock.Call {
+func (mr *MockuserOrderRepositoryMockRecorder) SaveOrder(ctx, orderID, merchant, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockuserOrderRepository)(nil).SaveOrder), ctx, orderID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockuserOrderRepository)(nil).SaveOrder), ctx, orderID, merchant, userID)
 }
@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: passwords.go
+//
+// Generated by this command:
+//
+//	mockgen -source=passwords.go -destination=./mocks/passwords.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepositoryForPasswords is a mock of userRepositoryForPasswords interface.
+type MockuserRepositoryForPasswords struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForPasswordsMockRecorder
+}
+
+// MockuserRepositoryForPasswordsMockRecorder is the mock recorder for MockuserRepositoryForPasswords.
+type MockuserRepositoryForPasswordsMockRecorder struct {
+	mock *MockuserRepositoryForPasswords
+}
+
+// NewMockuserRepositoryForPasswords creates a new mock instance.
+func NewMockuserRepositoryForPasswords(ctrl *gomock.Controller) *MockuserRepositoryForPasswords {
+	mock := &MockuserRepositoryForPasswords{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForPasswordsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForPasswords) EXPECT() *MockuserRepositoryForPasswordsMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserRepositoryForPasswords) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserRepositoryForPasswordsMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserRepositoryForPasswords)(nil).GetByID), ctx, id)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockuserRepositoryForPasswords) UpdatePassword(ctx context.Context, id int, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockuserRepositoryForPasswordsMockRecorder) UpdatePassword(ctx, id, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockuserRepositoryForPasswords)(nil).UpdatePassword), ctx, id, hashedPassword)
+}
+
+// MockpasswordResetRepository is a mock of passwordResetRepository interface.
+type MockpasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordResetRepositoryMockRecorder
+}
+
+// MockpasswordResetRepositoryMockRecorder is the mock recorder for MockpasswordResetRepository.
+type MockpasswordResetRepositoryMockRecorder struct {
+	mock *MockpasswordResetRepository
+}
+
+// NewMockpasswordResetRepository creates a new mock instance.
+func NewMockpasswordResetRepository(ctrl *gomock.Controller) *MockpasswordResetRepository {
+	mock := &MockpasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockpasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordResetRepository) EXPECT() *MockpasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockpasswordResetRepository) Create(ctx context.Context, userID, issuedBy int, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, issuedBy, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockpasswordResetRepositoryMockRecorder) Create(ctx, userID, issuedBy, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockpasswordResetRepository)(nil).Create), ctx, userID, issuedBy, tokenHash, expiresAt)
+}
+
+// GetUserID mocks base method.
+func (m *MockpasswordResetRepository) GetUserID(ctx context.Context, tokenHash string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserID", ctx, tokenHash, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserID indicates an expected call of GetUserID.
+func (mr *MockpasswordResetRepositoryMockRecorder) GetUserID(ctx, tokenHash, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserID", reflect.TypeOf((*MockpasswordResetRepository)(nil).GetUserID), ctx, tokenHash, now)
+}
+
+// Redeem mocks base method.
+func (m *MockpasswordResetRepository) Redeem(ctx context.Context, tokenHash, hashedPassword string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, tokenHash, hashedPassword, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockpasswordResetRepositoryMockRecorder) Redeem(ctx, tokenHash, hashedPassword, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockpasswordResetRepository)(nil).Redeem), ctx, tokenHash, hashedPassword, now)
+}
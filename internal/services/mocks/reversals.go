@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reversals.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reversals.go -destination=./mocks/reversals.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbalanceActionRepositoryForReversals is a mock of balanceActionRepositoryForReversals interface.
+type MockbalanceActionRepositoryForReversals struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionRepositoryForReversalsMockRecorder
+}
+
+// MockbalanceActionRepositoryForReversalsMockRecorder is the mock recorder for MockbalanceActionRepositoryForReversals.
+type MockbalanceActionRepositoryForReversalsMockRecorder struct {
+	mock *MockbalanceActionRepositoryForReversals
+}
+
+// NewMockbalanceActionRepositoryForReversals creates a new mock instance.
+func NewMockbalanceActionRepositoryForReversals(ctrl *gomock.Controller) *MockbalanceActionRepositoryForReversals {
+	mock := &MockbalanceActionRepositoryForReversals{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionRepositoryForReversalsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionRepositoryForReversals) EXPECT() *MockbalanceActionRepositoryForReversalsMockRecorder {
+	return m.recorder
+}
+
+// RefundWithdrawal mocks base method.
+func (m *MockbalanceActionRepositoryForReversals) RefundWithdrawal(ctx context.Context, withdrawalID int, reason string) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundWithdrawal", ctx, withdrawalID, reason)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundWithdrawal indicates an expected call of RefundWithdrawal.
+func (mr *MockbalanceActionRepositoryForReversalsMockRecorder) RefundWithdrawal(ctx, withdrawalID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundWithdrawal", reflect.TypeOf((*MockbalanceActionRepositoryForReversals)(nil).RefundWithdrawal), ctx, withdrawalID, reason)
+}
+
+// MockuserOrderRepositoryForReversals is a mock of userOrderRepositoryForReversals interface.
+type MockuserOrderRepositoryForReversals struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserOrderRepositoryForReversalsMockRecorder
+}
+
+// MockuserOrderRepositoryForReversalsMockRecorder is the mock recorder for MockuserOrderRepositoryForReversals.
+type MockuserOrderRepositoryForReversalsMockRecorder struct {
+	mock *MockuserOrderRepositoryForReversals
+}
+
+// NewMockuserOrderRepositoryForReversals creates a new mock instance.
+func NewMockuserOrderRepositoryForReversals(ctrl *gomock.Controller) *MockuserOrderRepositoryForReversals {
+	mock := &MockuserOrderRepositoryForReversals{ctrl: ctrl}
+	mock.recorder = &MockuserOrderRepositoryForReversalsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserOrderRepositoryForReversals) EXPECT() *MockuserOrderRepositoryForReversalsMockRecorder {
+	return m.recorder
+}
+
+// ClawbackOrderAccrual mocks base method.
+func (m *MockuserOrderRepositoryForReversals) ClawbackOrderAccrual(ctx context.Context, orderID, reason string) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClawbackOrderAccrual", ctx, orderID, reason)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClawbackOrderAccrual indicates an expected call of ClawbackOrderAccrual.
+func (mr *MockuserOrderRepositoryForReversalsMockRecorder) ClawbackOrderAccrual(ctx, orderID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClawbackOrderAccrual", reflect.TypeOf((*MockuserOrderRepositoryForReversals)(nil).ClawbackOrderAccrual), ctx, orderID, reason)
+}
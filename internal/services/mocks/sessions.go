@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sessions.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sessions.go -destination=./mocks/sessions.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksessionRepository is a mock of sessionRepository interface.
+type MocksessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionRepositoryMockRecorder
+}
+
+// MocksessionRepositoryMockRecorder is the mock recorder for MocksessionRepository.
+type MocksessionRepositoryMockRecorder struct {
+	mock *MocksessionRepository
+}
+
+// NewMocksessionRepository creates a new mock instance.
+func NewMocksessionRepository(ctrl *gomock.Controller) *MocksessionRepository {
+	mock := &MocksessionRepository{ctrl: ctrl}
+	mock.recorder = &MocksessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionRepository) EXPECT() *MocksessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocksessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocksessionRepositoryMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocksessionRepository)(nil).Create), ctx, session)
+}
+
+// GetByID mocks base method.
+func (m *MocksessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MocksessionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MocksessionRepository)(nil).GetByID), ctx, id)
+}
+
+// Revoke mocks base method.
+func (m *MocksessionRepository) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocksessionRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocksessionRepository)(nil).Revoke), ctx, id)
+}
+
+// RevokeAllByUserID mocks base method.
+func (m *MocksessionRepository) RevokeAllByUserID(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllByUserID indicates an expected call of RevokeAllByUserID.
+func (mr *MocksessionRepositoryMockRecorder) RevokeAllByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserID", reflect.TypeOf((*MocksessionRepository)(nil).RevokeAllByUserID), ctx, userID)
+}
+
+// Rotate mocks base method.
+func (m *MocksessionRepository) Rotate(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id, oldHash, newHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MocksessionRepositoryMockRecorder) Rotate(ctx, id, oldHash, newHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MocksessionRepository)(nil).Rotate), ctx, id, oldHash, newHash, expiresAt)
+}
+
+// MockuserRepositoryForSessions is a mock of userRepositoryForSessions interface.
+type MockuserRepositoryForSessions struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryForSessionsMockRecorder
+}
+
+// MockuserRepositoryForSessionsMockRecorder is the mock recorder for MockuserRepositoryForSessions.
+type MockuserRepositoryForSessionsMockRecorder struct {
+	mock *MockuserRepositoryForSessions
+}
+
+// NewMockuserRepositoryForSessions creates a new mock instance.
+func NewMockuserRepositoryForSessions(ctrl *gomock.Controller) *MockuserRepositoryForSessions {
+	mock := &MockuserRepositoryForSessions{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryForSessionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepositoryForSessions) EXPECT() *MockuserRepositoryForSessionsMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserRepositoryForSessions) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserRepositoryForSessionsMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserRepositoryForSessions)(nil).GetByID), ctx, id)
+}
+
+// MockaccessTokenIssuer is a mock of accessTokenIssuer interface.
+type MockaccessTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessTokenIssuerMockRecorder
+}
+
+// MockaccessTokenIssuerMockRecorder is the mock recorder for MockaccessTokenIssuer.
+type MockaccessTokenIssuerMockRecorder struct {
+	mock *MockaccessTokenIssuer
+}
+
+// NewMockaccessTokenIssuer creates a new mock instance.
+func NewMockaccessTokenIssuer(ctrl *gomock.Controller) *MockaccessTokenIssuer {
+	mock := &MockaccessTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockaccessTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessTokenIssuer) EXPECT() *MockaccessTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// GenerateToken mocks base method.
+func (m *MockaccessTokenIssuer) GenerateToken(userID int, sessionID, role string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", userID, sessionID, role, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockaccessTokenIssuerMockRecorder) GenerateToken(userID, sessionID, role, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockaccessTokenIssuer)(nil).GenerateToken), userID, sessionID, role, ttl)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=statement.go -destination=./mocks/statement.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbalanceActionRepositoryForStatement is a mock of balanceActionRepositoryForStatement interface.
+type MockbalanceActionRepositoryForStatement struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionRepositoryForStatementMockRecorder
+}
+
+// MockbalanceActionRepositoryForStatementMockRecorder is the mock recorder for MockbalanceActionRepositoryForStatement.
+type MockbalanceActionRepositoryForStatementMockRecorder struct {
+	mock *MockbalanceActionRepositoryForStatement
+}
+
+// NewMockbalanceActionRepositoryForStatement creates a new mock instance.
+func NewMockbalanceActionRepositoryForStatement(ctrl *gomock.Controller) *MockbalanceActionRepositoryForStatement {
+	mock := &MockbalanceActionRepositoryForStatement{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionRepositoryForStatementMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionRepositoryForStatement) EXPECT() *MockbalanceActionRepositoryForStatementMockRecorder {
+	return m.recorder
+}
+
+// StreamStatement mocks base method.
+func (m *MockbalanceActionRepositoryForStatement) StreamStatement(ctx context.Context, userID int, from, to *time.Time, fn func(domain.StatementLine) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStatement", ctx, userID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamStatement indicates an expected call of StreamStatement.
+func (mr *MockbalanceActionRepositoryForStatementMockRecorder) StreamStatement(ctx, userID, from, to, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStatement", reflect.TypeOf((*MockbalanceActionRepositoryForStatement)(nil).StreamStatement), ctx, userID, from, to, fn)
+}
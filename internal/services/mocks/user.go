@@ -11,6 +11,7 @@ package repomock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -108,10 +109,10 @@ func (m *MockbalanceActionsRepositoryForUser) EXPECT() *MockbalanceActionsReposi
 }
 
 // GetCurrentBalance mocks base method.
-func (m *MockbalanceActionsRepositoryForUser) GetCurrentBalance(ctx context.Context, userID int) float64 {
+func (m *MockbalanceActionsRepositoryForUser) GetCurrentBalance(ctx context.Context, userID int) domain.Money {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCurrentBalance", ctx, userID)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(domain.Money)
 	return ret0
 }
 
@@ -121,11 +122,40 @@ func (mr *MockbalanceActionsRepositoryForUserMockRecorder) GetCurrentBalance(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentBalance", reflect.TypeOf((*MockbalanceActionsRepositoryForUser)(nil).GetCurrentBalance), ctx, userID)
 }
 
+// GetExpiringPoints mocks base method.
+func (m *MockbalanceActionsRepositoryForUser) GetExpiringPoints(ctx context.Context, userID int, before time.Time) ([]domain.ExpiringPoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringPoints", ctx, userID, before)
+	ret0, _ := ret[0].([]domain.ExpiringPoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringPoints indicates an expected call of GetExpiringPoints.
+func (mr *MockbalanceActionsRepositoryForUserMockRecorder) GetExpiringPoints(ctx, userID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringPoints", reflect.TypeOf((*MockbalanceActionsRepositoryForUser)(nil).GetExpiringPoints), ctx, userID, before)
+}
+
+// GetHeldAmount mocks base method.
+func (m *MockbalanceActionsRepositoryForUser) GetHeldAmount(ctx context.Context, userID int) domain.Money {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldAmount", ctx, userID)
+	ret0, _ := ret[0].(domain.Money)
+	return ret0
+}
+
+// GetHeldAmount indicates an expected call of GetHeldAmount.
+func (mr *MockbalanceActionsRepositoryForUserMockRecorder) GetHeldAmount(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldAmount", reflect.TypeOf((*MockbalanceActionsRepositoryForUser)(nil).GetHeldAmount), ctx, userID)
+}
+
 // GetWithdrawalAmount mocks base method.
-func (m *MockbalanceActionsRepositoryForUser) GetWithdrawalAmount(ctx context.Context, userID int) float64 {
+func (m *MockbalanceActionsRepositoryForUser) GetWithdrawalAmount(ctx context.Context, userID int) domain.Money {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetWithdrawalAmount", ctx, userID)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(domain.Money)
 	return ret0
 }
 
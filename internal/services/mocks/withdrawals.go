@@ -40,10 +40,10 @@ func (m *MockbalanceActionRepository) EXPECT() *MockbalanceActionRepositoryMockR
 }
 
 // GetCurrentBalance mocks base method.
-func (m *MockbalanceActionRepository) GetCurrentBalance(ctx context.Context, userID int) float64 {
+func (m *MockbalanceActionRepository) GetCurrentBalance(ctx context.Context, userID int) domain.Money {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCurrentBalance", ctx, userID)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(domain.Money)
 	return ret0
 }
 
@@ -68,8 +68,23 @@ func (mr *MockbalanceActionRepositoryMockRecorder) GetUserWithdrawals(ctx, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWithdrawals", reflect.TypeOf((*MockbalanceActionRepository)(nil).GetUserWithdrawals), ctx, userID)
 }
 
+// GetUserWithdrawalsPage mocks base method.
+func (m *MockbalanceActionRepository) GetUserWithdrawalsPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.BalanceActionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWithdrawalsPage", ctx, userID, filter)
+	ret0, _ := ret[0].(*domain.BalanceActionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWithdrawalsPage indicates an expected call of GetUserWithdrawalsPage.
+func (mr *MockbalanceActionRepositoryMockRecorder) GetUserWithdrawalsPage(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWithdrawalsPage", reflect.TypeOf((*MockbalanceActionRepository)(nil).GetUserWithdrawalsPage), ctx, userID, filter)
+}
+
 // Save mocks base method.
-func (m *MockbalanceActionRepository) Save(ctx context.Context, userID int, orderID string, amount float64) error {
+func (m *MockbalanceActionRepository) Save(ctx context.Context, userID int, orderID string, amount domain.Money) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Save", ctx, userID, orderID, amount)
 	ret0, _ := ret[0].(error)
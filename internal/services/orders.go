@@ -8,8 +8,10 @@ import (
 
 type userOrderRepository interface {
 	GetByOrderID(ctx context.Context, orderID string) (*domain.UserOrder, error)
+	GetDetailsByOrderID(ctx context.Context, orderID string) (*domain.UserOrderDetails, error)
 	GetByUserID(ctx context.Context, userID int) ([]domain.UserOrder, error)
-	SaveOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error)
+	GetPageByUserID(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.UserOrdersPage, error)
+	SaveOrder(ctx context.Context, orderID string, merchant string, userID int) (*domain.UserOrder, error)
 }
 
 type OrdersService struct {
@@ -22,7 +24,19 @@ func NewOrdersService(userOrderRepository userOrderRepository) *OrdersService {
 	}
 }
 
-func (s *OrdersService) RegisterOrder(ctx context.Context, orderID string, userID int) (*domain.UserOrder, error) {
+// RegisterOrder files an order made at merchant. The accrual for it is asked
+// from that merchant, see workers.OrderAccrualCheckingWorker.
+func (s *OrdersService) RegisterOrder(
+	ctx context.Context, orderID string, merchant string, userID int,
+) (*domain.UserOrder, error) {
+	if merchant == "" {
+		merchant = domain.DefaultMerchantCode
+	}
+
+	if !domain.IsValidMerchantCode(merchant) {
+		return nil, domain.ErrInvalidMerchantCode
+	}
+
 	userOrder, err := s.userOrderRepository.GetByOrderID(ctx, orderID)
 
 	if err == nil && userOrder != nil {
@@ -33,7 +47,7 @@ func (s *OrdersService) RegisterOrder(ctx context.Context, orderID string, userI
 		}
 	}
 
-	userOrder, err = s.userOrderRepository.SaveOrder(ctx, orderID, userID)
+	userOrder, err = s.userOrderRepository.SaveOrder(ctx, orderID, merchant, userID)
 
 	if err != nil {
 		return nil, err
@@ -45,3 +59,37 @@ func (s *OrdersService) RegisterOrder(ctx context.Context, orderID string, userI
 func (s *OrdersService) GetUserOrders(ctx context.Context, userID int) ([]domain.UserOrder, error) {
 	return s.userOrderRepository.GetByUserID(ctx, userID)
 }
+
+// GetUserOrder returns an order of the user with the breakdown of its
+// accrual. Orders of other users are not found.
+func (s *OrdersService) GetUserOrder(
+	ctx context.Context, userID int, orderID string,
+) (*domain.UserOrderDetails, error) {
+	details, err := s.userOrderRepository.GetDetailsByOrderID(ctx, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if details.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+
+	return details, nil
+}
+
+// GetUserOrdersPage returns one page of the user orders, oldest first unless
+// the filter asks otherwise.
+func (s *OrdersService) GetUserOrdersPage(
+	ctx context.Context, userID int, filter domain.HistoryFilter,
+) (*domain.UserOrdersPage, error) {
+	err := filter.Normalize(
+		domain.AscSortDirection,
+		domain.NewOrderStatus, domain.ProcessingOrderStatus, domain.InvalidOrderStatus, domain.ProcessedOrderStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userOrderRepository.GetPageByUserID(ctx, userID, filter)
+}
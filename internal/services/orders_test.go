@@ -28,10 +28,10 @@ func TestOrdersService_RegisterOrder(t *testing.T) {
 			Return(nil, domain.ErrNotFound)
 		userOrderRepo.
 			EXPECT().
-			SaveOrder(context.Background(), orderID, userID).
+			SaveOrder(context.Background(), orderID, domain.DefaultMerchantCode, userID).
 			Return(&domain.UserOrder{OrderID: orderID, UserID: userID}, nil)
 
-		order, err := service.RegisterOrder(context.Background(), orderID, userID)
+		order, err := service.RegisterOrder(context.Background(), orderID, "", userID)
 		require.NoError(t, err)
 		assert.NotNil(t, order)
 	})
@@ -45,7 +45,7 @@ func TestOrdersService_RegisterOrder(t *testing.T) {
 			GetByOrderID(context.Background(), orderID).
 			Return(&domain.UserOrder{OrderID: orderID, UserID: userID}, nil)
 
-		order, err := service.RegisterOrder(context.Background(), orderID, userID)
+		order, err := service.RegisterOrder(context.Background(), orderID, "", userID)
 		assert.ErrorIs(t, err, domain.ErrOrderRegisteredByYou)
 		assert.Nil(t, order)
 	})
@@ -60,10 +60,16 @@ func TestOrdersService_RegisterOrder(t *testing.T) {
 			GetByOrderID(context.Background(), orderID).
 			Return(&domain.UserOrder{OrderID: orderID, UserID: otherUserID}, nil)
 
-		order, err := service.RegisterOrder(context.Background(), orderID, userID)
+		order, err := service.RegisterOrder(context.Background(), orderID, "", userID)
 		assert.ErrorIs(t, err, domain.ErrOrderRegisteredByOther)
 		assert.Nil(t, order)
 	})
+
+	t.Run("invalid (merchant code)", func(t *testing.T) {
+		order, err := service.RegisterOrder(context.Background(), "4", "Shop!", 1)
+		assert.ErrorIs(t, err, domain.ErrInvalidMerchantCode)
+		assert.Nil(t, order)
+	})
 }
 
 func TestOrdersService_GetUserOrders(t *testing.T) {
@@ -97,3 +103,74 @@ func TestOrdersService_GetUserOrders(t *testing.T) {
 		assert.Len(t, orders, 0)
 	})
 }
+
+func TestOrdersService_GetUserOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userOrderRepo := repomock.NewMockuserOrderRepository(ctrl)
+	service := NewOrdersService(userOrderRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		userOrderRepo.
+			EXPECT().
+			GetDetailsByOrderID(context.Background(), "1").
+			Return(&domain.UserOrderDetails{
+				UserOrder: domain.UserOrder{OrderID: "1", UserID: 1},
+				Breakdown: &domain.AccrualBreakdown{Accrual: domain.MoneyFromInt(10)},
+			}, nil)
+
+		details, err := service.GetUserOrder(context.Background(), 1, "1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.MoneyFromInt(10), details.Breakdown.Accrual)
+	})
+
+	t.Run("invalid (order of other user)", func(t *testing.T) {
+		userOrderRepo.
+			EXPECT().
+			GetDetailsByOrderID(context.Background(), "1").
+			Return(&domain.UserOrderDetails{UserOrder: domain.UserOrder{OrderID: "1", UserID: 2}}, nil)
+
+		details, err := service.GetUserOrder(context.Background(), 1, "1")
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, details)
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		userOrderRepo.
+			EXPECT().
+			GetDetailsByOrderID(context.Background(), "1").
+			Return(nil, domain.ErrNotFound)
+
+		details, err := service.GetUserOrder(context.Background(), 1, "1")
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, details)
+	})
+}
+
+func TestOrdersService_GetUserOrdersPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userOrderRepo := repomock.NewMockuserOrderRepository(ctrl)
+	service := NewOrdersService(userOrderRepo)
+
+	t.Run("valid (defaults applied)", func(t *testing.T) {
+		userID := 1
+		expectedFilter := domain.HistoryFilter{Limit: domain.DefaultPageLimit, Sort: domain.AscSortDirection}
+
+		userOrderRepo.
+			EXPECT().
+			GetPageByUserID(context.Background(), userID, expectedFilter).
+			Return(&domain.UserOrdersPage{Items: []domain.UserOrder{{OrderID: "1", UserID: userID}}}, nil)
+
+		page, err := service.GetUserOrdersPage(context.Background(), userID, domain.HistoryFilter{})
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+		assert.Nil(t, page.NextCursor)
+	})
+
+	t.Run("invalid (unknown status)", func(t *testing.T) {
+		page, err := service.GetUserOrdersPage(context.Background(), 1, domain.HistoryFilter{Status: "LOST"})
+		require.ErrorIs(t, err, domain.ErrInvalidHistoryFilter)
+		assert.Nil(t, page)
+	})
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type userRepositoryForPasswords interface {
+	GetByID(ctx context.Context, id int) (*domain.User, error)
+	UpdatePassword(ctx context.Context, id int, hashedPassword string) error
+}
+
+type passwordResetRepository interface {
+	Create(ctx context.Context, userID int, issuedBy int, tokenHash string, expiresAt time.Time) error
+	GetUserID(ctx context.Context, tokenHash string, now time.Time) (int, error)
+	Redeem(ctx context.Context, tokenHash string, hashedPassword string, now time.Time) (int, error)
+}
+
+type PasswordService struct {
+	userRepository          userRepositoryForPasswords
+	passwordResetRepository passwordResetRepository
+	sessionRevoker          sessionRevoker
+	policy                  domain.PasswordPolicy
+	resetTokenTTL           time.Duration
+}
+
+func NewPasswordService(
+	userRepository userRepositoryForPasswords,
+	passwordResetRepository passwordResetRepository,
+	sessionRevoker sessionRevoker,
+	policy domain.PasswordPolicy,
+	resetTokenTTL time.Duration,
+) *PasswordService {
+	return &PasswordService{
+		userRepository:          userRepository,
+		passwordResetRepository: passwordResetRepository,
+		sessionRevoker:          sessionRevoker,
+		policy:                  policy,
+		resetTokenTTL:           resetTokenTTL,
+	}
+}
+
+// ChangePassword sets a new password for a user who knows the current one.
+// Every session of the user is revoked, so access tokens issued before the
+// change stop working right away.
+func (s *PasswordService) ChangePassword(
+	ctx context.Context,
+	userID int,
+	currentPassword string,
+	newPassword string,
+) (*domain.User, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return nil, domain.ErrWrongPassword
+	}
+
+	if currentPassword == newPassword {
+		return nil, domain.ErrPasswordNotChanged
+	}
+
+	hashedPassword, err := s.hashPassword(user.Login, newPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepository.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRevoker.LogoutEverywhere(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	user.Password = hashedPassword
+
+	return user, nil
+}
+
+// IssueResetToken creates a single use token the user can set a new password
+// with. Only its hash is kept, so the returned token can not be shown again.
+func (s *PasswordService) IssueResetToken(
+	ctx context.Context,
+	actorID int,
+	userID int,
+) (*domain.PasswordResetToken, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().UTC().Add(s.resetTokenTTL)
+
+	if err := s.passwordResetRepository.Create(ctx, user.ID, actorID, hashToken(token), expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &domain.PasswordResetToken{
+		UserID:    user.ID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ResetPassword spends a reset token and sets the new password. As with a
+// change, every session of the user is revoked.
+func (s *PasswordService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	tokenHash := hashToken(token)
+	now := time.Now().UTC()
+
+	userID, err := s.passwordResetRepository.GetUserID(ctx, tokenHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidResetToken
+		}
+
+		return err
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hashPassword(user.Login, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// The token is checked again while it is spent, a concurrent reset with
+	// the same token loses here.
+	if _, err := s.passwordResetRepository.Redeem(ctx, tokenHash, hashedPassword, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidResetToken
+		}
+
+		return err
+	}
+
+	return s.sessionRevoker.LogoutEverywhere(ctx, user.ID)
+}
+
+func (s *PasswordService) hashPassword(login string, password string) (string, error) {
+	if err := s.policy.Validate(login, password); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashedPassword), nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+type passwordServiceMocks struct {
+	userRepo          *repomock.MockuserRepositoryForPasswords
+	passwordResetRepo *repomock.MockpasswordResetRepository
+	sessionRevoker    *repomock.MocksessionRevoker
+}
+
+func newPasswordServiceForTest(t *testing.T) (*PasswordService, passwordServiceMocks) {
+	ctrl := gomock.NewController(t)
+
+	mocks := passwordServiceMocks{
+		userRepo:          repomock.NewMockuserRepositoryForPasswords(ctrl),
+		passwordResetRepo: repomock.NewMockpasswordResetRepository(ctrl),
+		sessionRevoker:    repomock.NewMocksessionRevoker(ctrl),
+	}
+
+	service := NewPasswordService(
+		mocks.userRepo,
+		mocks.passwordResetRepo,
+		mocks.sessionRevoker,
+		domain.PasswordPolicy{MinLength: 8, RequireDigit: true},
+		time.Hour,
+	)
+
+	return service, mocks
+}
+
+func userWithPassword(t *testing.T, password string) *domain.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &domain.User{ID: 7, Login: "alice", Password: string(hash)}
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	service, mocks := newPasswordServiceForTest(t)
+
+	t.Run("valid (revokes every session)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(userWithPassword(t, "old-pass1"), nil)
+
+		var stored string
+
+		mocks.userRepo.
+			EXPECT().
+			UpdatePassword(context.Background(), 7, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int, hashedPassword string) error {
+				stored = hashedPassword
+				return nil
+			})
+		mocks.sessionRevoker.EXPECT().LogoutEverywhere(context.Background(), 7).Return(nil)
+
+		user, err := service.ChangePassword(context.Background(), 7, "old-pass1", "new-pass2")
+		require.NoError(t, err)
+		assert.Equal(t, stored, user.Password)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored), []byte("new-pass2")))
+	})
+
+	t.Run("invalid (wrong current password)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(userWithPassword(t, "old-pass1"), nil)
+
+		_, err := service.ChangePassword(context.Background(), 7, "guess-123", "new-pass2")
+		require.ErrorIs(t, err, domain.ErrWrongPassword)
+	})
+
+	t.Run("invalid (same password)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(userWithPassword(t, "old-pass1"), nil)
+
+		_, err := service.ChangePassword(context.Background(), 7, "old-pass1", "old-pass1")
+		require.ErrorIs(t, err, domain.ErrPasswordNotChanged)
+	})
+
+	t.Run("invalid (policy)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(userWithPassword(t, "old-pass1"), nil)
+
+		_, err := service.ChangePassword(context.Background(), 7, "old-pass1", "no-digits")
+		require.ErrorIs(t, err, domain.ErrWeakPassword)
+	})
+}
+
+func TestPasswordService_IssueResetToken(t *testing.T) {
+	service, mocks := newPasswordServiceForTest(t)
+
+	t.Run("valid (stores only the hash)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7}, nil)
+
+		var storedHash string
+
+		mocks.passwordResetRepo.
+			EXPECT().
+			Create(context.Background(), 7, 1, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID int, issuedBy int, tokenHash string, expiresAt time.Time) error {
+				storedHash = tokenHash
+				return nil
+			})
+
+		token, err := service.IssueResetToken(context.Background(), 1, 7)
+		require.NoError(t, err)
+		assert.Equal(t, 7, token.UserID)
+		assert.NotEqual(t, token.Token, storedHash)
+		assert.Equal(t, hashToken(token.Token), storedHash)
+		assert.WithinDuration(t, time.Now().UTC().Add(time.Hour), token.ExpiresAt, time.Minute)
+	})
+
+	t.Run("invalid (unknown user)", func(t *testing.T) {
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 8).Return(nil, domain.ErrNotFound)
+
+		_, err := service.IssueResetToken(context.Background(), 1, 8)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestPasswordService_ResetPassword(t *testing.T) {
+	service, mocks := newPasswordServiceForTest(t)
+
+	t.Run("valid", func(t *testing.T) {
+		mocks.passwordResetRepo.EXPECT().GetUserID(context.Background(), hashToken("token"), gomock.Any()).Return(7, nil)
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Login: "alice"}, nil)
+		mocks.passwordResetRepo.EXPECT().Redeem(context.Background(), hashToken("token"), gomock.Any(), gomock.Any()).Return(7, nil)
+		mocks.sessionRevoker.EXPECT().LogoutEverywhere(context.Background(), 7).Return(nil)
+
+		require.NoError(t, service.ResetPassword(context.Background(), "token", "new-pass2"))
+	})
+
+	t.Run("invalid (unknown or expired token)", func(t *testing.T) {
+		mocks.passwordResetRepo.EXPECT().GetUserID(context.Background(), hashToken("stale"), gomock.Any()).Return(0, domain.ErrNotFound)
+
+		err := service.ResetPassword(context.Background(), "stale", "new-pass2")
+		require.ErrorIs(t, err, domain.ErrInvalidResetToken)
+	})
+
+	t.Run("invalid (token spent concurrently)", func(t *testing.T) {
+		mocks.passwordResetRepo.EXPECT().GetUserID(context.Background(), hashToken("token"), gomock.Any()).Return(7, nil)
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Login: "alice"}, nil)
+		mocks.passwordResetRepo.EXPECT().Redeem(context.Background(), hashToken("token"), gomock.Any(), gomock.Any()).Return(0, domain.ErrNotFound)
+
+		err := service.ResetPassword(context.Background(), "token", "new-pass2")
+		require.ErrorIs(t, err, domain.ErrInvalidResetToken)
+	})
+
+	t.Run("invalid (policy)", func(t *testing.T) {
+		mocks.passwordResetRepo.EXPECT().GetUserID(context.Background(), hashToken("token"), gomock.Any()).Return(7, nil)
+		mocks.userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Login: "alice"}, nil)
+
+		err := service.ResetPassword(context.Background(), "token", "short1")
+		require.ErrorIs(t, err, domain.ErrWeakPassword)
+	})
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type balanceActionRepositoryForReversals interface {
+	RefundWithdrawal(ctx context.Context, withdrawalID int, reason string) (*domain.BalanceAction, error)
+}
+
+type userOrderRepositoryForReversals interface {
+	ClawbackOrderAccrual(ctx context.Context, orderID string, reason string) (*domain.BalanceAction, error)
+}
+
+type ReversalsService struct {
+	balanceActionRepository balanceActionRepositoryForReversals
+	userOrderRepository     userOrderRepositoryForReversals
+}
+
+func NewReversalsService(
+	balanceActionRepository balanceActionRepositoryForReversals,
+	userOrderRepository userOrderRepositoryForReversals,
+) *ReversalsService {
+	return &ReversalsService{
+		balanceActionRepository: balanceActionRepository,
+		userOrderRepository:     userOrderRepository,
+	}
+}
+
+// RefundWithdrawal returns the points of a processed withdrawal to the user.
+func (s *ReversalsService) RefundWithdrawal(ctx context.Context, withdrawalID int, reason string) (*domain.BalanceAction, error) {
+	if !domain.IsValidReversalReason(reason) {
+		return nil, domain.ErrInvalidReversalReason
+	}
+
+	return s.balanceActionRepository.RefundWithdrawal(ctx, withdrawalID, reason)
+}
+
+// ClawbackOrderAccrual takes back the points accrued for a returned order.
+func (s *ReversalsService) ClawbackOrderAccrual(ctx context.Context, orderID string, reason string) (*domain.BalanceAction, error) {
+	if !domain.IsValidReversalReason(reason) {
+		return nil, domain.ErrInvalidReversalReason
+	}
+
+	return s.userOrderRepository.ClawbackOrderAccrual(ctx, orderID, reason)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestReversalsService_RefundWithdrawal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForReversals(ctrl)
+	userOrderRepo := repomock.NewMockuserOrderRepositoryForReversals(ctrl)
+	service := NewReversalsService(balanceActionRepo, userOrderRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			RefundWithdrawal(context.Background(), 10, domain.CustomerRequestReversalReason).
+			Return(&domain.BalanceAction{ID: 11, Amount: domain.MoneyFromInt(50), Kind: domain.RefundBalanceActionKind}, nil)
+
+		refund, err := service.RefundWithdrawal(context.Background(), 10, domain.CustomerRequestReversalReason)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RefundBalanceActionKind, refund.Kind)
+	})
+
+	t.Run("invalid (unknown reason)", func(t *testing.T) {
+		refund, err := service.RefundWithdrawal(context.Background(), 10, "because")
+		require.ErrorIs(t, err, domain.ErrInvalidReversalReason)
+		assert.Nil(t, refund)
+	})
+
+	t.Run("invalid (already refunded)", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			RefundWithdrawal(context.Background(), 12, domain.DuplicateReversalReason).
+			Return(nil, domain.ErrAlreadyReversed)
+
+		refund, err := service.RefundWithdrawal(context.Background(), 12, domain.DuplicateReversalReason)
+		require.ErrorIs(t, err, domain.ErrAlreadyReversed)
+		assert.Nil(t, refund)
+	})
+}
+
+func TestReversalsService_ClawbackOrderAccrual(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForReversals(ctrl)
+	userOrderRepo := repomock.NewMockuserOrderRepositoryForReversals(ctrl)
+	service := NewReversalsService(balanceActionRepo, userOrderRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		userOrderRepo.
+			EXPECT().
+			ClawbackOrderAccrual(context.Background(), "100", domain.OrderReturnedReversalReason).
+			Return(&domain.BalanceAction{ID: 5, Amount: domain.MoneyFromInt(-30), Kind: domain.ClawbackBalanceActionKind}, nil)
+
+		clawback, err := service.ClawbackOrderAccrual(context.Background(), "100", domain.OrderReturnedReversalReason)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ClawbackBalanceActionKind, clawback.Kind)
+	})
+
+	t.Run("invalid (unknown reason)", func(t *testing.T) {
+		clawback, err := service.ClawbackOrderAccrual(context.Background(), "100", "")
+		require.ErrorIs(t, err, domain.ErrInvalidReversalReason)
+		assert.Nil(t, clawback)
+	})
+
+	t.Run("invalid (not processed)", func(t *testing.T) {
+		userOrderRepo.
+			EXPECT().
+			ClawbackOrderAccrual(context.Background(), "200", domain.FraudReversalReason).
+			Return(nil, domain.ErrNotReversible)
+
+		clawback, err := service.ClawbackOrderAccrual(context.Background(), "200", domain.FraudReversalReason)
+		require.ErrorIs(t, err, domain.ErrNotReversible)
+		assert.Nil(t, clawback)
+	})
+}
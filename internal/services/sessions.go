@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type sessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	GetByID(ctx context.Context, id string) (*domain.Session, error)
+	Rotate(ctx context.Context, id string, oldHash string, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	RevokeAllByUserID(ctx context.Context, userID int) error
+}
+
+type userRepositoryForSessions interface {
+	GetByID(ctx context.Context, id int) (*domain.User, error)
+}
+
+type accessTokenIssuer interface {
+	GenerateToken(userID int, sessionID string, role string, ttl time.Duration) (string, error)
+}
+
+type SessionsService struct {
+	sessionRepository sessionRepository
+	userRepository    userRepositoryForSessions
+	tokenIssuer       accessTokenIssuer
+	accessTokenTTL    time.Duration
+	refreshTokenTTL   time.Duration
+}
+
+func NewSessionsService(
+	sessionRepository sessionRepository,
+	userRepository userRepositoryForSessions,
+	tokenIssuer accessTokenIssuer,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) *SessionsService {
+	return &SessionsService{
+		sessionRepository: sessionRepository,
+		userRepository:    userRepository,
+		tokenIssuer:       tokenIssuer,
+		accessTokenTTL:    accessTokenTTL,
+		refreshTokenTTL:   refreshTokenTTL,
+	}
+}
+
+// StartSession opens a new session for the user and returns its first token
+// pair.
+func (s *SessionsService) StartSession(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	if user.IsLocked() {
+		return nil, domain.ErrUserLocked
+	}
+
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	session := domain.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		RefreshTokenHash: hashToken(secret),
+		ExpiresAt:        time.Now().UTC().Add(s.refreshTokenTTL),
+	}
+
+	if err := s.sessionRepository.Create(ctx, &session); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokenPair(&session, user.Role, secret)
+}
+
+// Refresh exchanges a refresh token for a new pair. Every refresh token works
+// once: presenting the previous one again means it leaked, so the whole
+// session is revoked.
+func (s *SessionsService) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+
+	if !ok || sessionID == "" || secret == "" {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepository.GetByID(ctx, sessionID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		return nil, err
+	}
+
+	if !session.IsActive(time.Now().UTC()) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	presentedHash := hashToken(secret)
+
+	if !sameHash(presentedHash, session.RefreshTokenHash) {
+		if session.PrevRefreshTokenHash != nil && sameHash(presentedHash, *session.PrevRefreshTokenHash) {
+			if err := s.sessionRepository.Revoke(ctx, session.ID); err != nil {
+				return nil, err
+			}
+
+			return nil, domain.ErrRefreshTokenReused
+		}
+
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// The user is loaded on every refresh, so a changed role makes it into the
+	// next access token and a locked user can not keep the session alive.
+	user, err := s.userRepository.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsLocked() {
+		if err := s.sessionRepository.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+
+		return nil, domain.ErrUserLocked
+	}
+
+	newSecret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	session.ExpiresAt = time.Now().UTC().Add(s.refreshTokenTTL)
+
+	err = s.sessionRepository.Rotate(ctx, session.ID, presentedHash, hashToken(newSecret), session.ExpiresAt)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotActive) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		return nil, err
+	}
+
+	return s.issueTokenPair(session, user.Role, newSecret)
+}
+
+func (s *SessionsService) Logout(ctx context.Context, sessionID string) error {
+	return s.sessionRepository.Revoke(ctx, sessionID)
+}
+
+// LogoutEverywhere revokes every session of the user.
+func (s *SessionsService) LogoutEverywhere(ctx context.Context, userID int) error {
+	return s.sessionRepository.RevokeAllByUserID(ctx, userID)
+}
+
+func (s *SessionsService) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	session, err := s.sessionRepository.GetByID(ctx, sessionID)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return session.IsActive(time.Now().UTC()), nil
+}
+
+func (s *SessionsService) issueTokenPair(session *domain.Session, role string, secret string) (*domain.TokenPair, error) {
+	accessToken, err := s.tokenIssuer.GenerateToken(session.UserID, session.ID, role, s.accessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:           accessToken,
+		RefreshToken:          session.ID + "." + secret,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func randomToken(size int) (string, error) {
+	raw := make([]byte, size)
+
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func sameHash(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
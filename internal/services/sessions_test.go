@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/jwt"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+var tokenManager = jwt.NewHMACManager("test-secret")
+
+func TestSessionsService_StartSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sessionRepo := repomock.NewMocksessionRepository(ctrl)
+	userRepo := repomock.NewMockuserRepositoryForSessions(ctrl)
+	service := NewSessionsService(sessionRepo, userRepo, tokenManager, time.Minute*15, time.Hour)
+
+	t.Run("valid", func(t *testing.T) {
+		var created domain.Session
+
+		sessionRepo.
+			EXPECT().
+			Create(context.Background(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, session *domain.Session) error {
+				created = *session
+				return nil
+			})
+
+		pair, err := service.StartSession(context.Background(), &domain.User{ID: 7, Role: domain.SupportRole})
+		require.NoError(t, err)
+
+		sessionID, secret, ok := strings.Cut(pair.RefreshToken, ".")
+		require.True(t, ok)
+		assert.Equal(t, created.ID, sessionID)
+		assert.Equal(t, hashToken(secret), created.RefreshTokenHash)
+
+		claims, err := tokenManager.ParseToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, 7, claims.UserID)
+		assert.Equal(t, created.ID, claims.SessionID)
+		assert.Equal(t, domain.SupportRole, claims.Role)
+	})
+
+	t.Run("invalid (locked user)", func(t *testing.T) {
+		lockedAt := time.Now().UTC()
+
+		pair, err := service.StartSession(context.Background(), &domain.User{ID: 7, LockedAt: &lockedAt})
+		require.ErrorIs(t, err, domain.ErrUserLocked)
+		assert.Nil(t, pair)
+	})
+}
+
+func TestSessionsService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sessionRepo := repomock.NewMocksessionRepository(ctrl)
+	userRepo := repomock.NewMockuserRepositoryForSessions(ctrl)
+	service := NewSessionsService(sessionRepo, userRepo, tokenManager, time.Minute*15, time.Hour)
+
+	prevHash := hashToken("old-secret")
+	activeSession := func() *domain.Session {
+		return &domain.Session{
+			ID:                   "sid",
+			UserID:               7,
+			RefreshTokenHash:     hashToken("secret"),
+			PrevRefreshTokenHash: &prevHash,
+			ExpiresAt:            time.Now().UTC().Add(time.Hour),
+		}
+	}
+
+	t.Run("valid (rotates)", func(t *testing.T) {
+		sessionRepo.EXPECT().GetByID(context.Background(), "sid").Return(activeSession(), nil)
+		userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, Role: domain.AdminRole}, nil)
+		sessionRepo.
+			EXPECT().
+			Rotate(context.Background(), "sid", hashToken("secret"), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		pair, err := service.Refresh(context.Background(), "sid.secret")
+		require.NoError(t, err)
+		assert.NotEqual(t, "sid.secret", pair.RefreshToken)
+		assert.True(t, strings.HasPrefix(pair.RefreshToken, "sid."))
+
+		claims, err := tokenManager.ParseToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AdminRole, claims.Role)
+	})
+
+	t.Run("invalid (locked user)", func(t *testing.T) {
+		lockedAt := time.Now().UTC()
+
+		sessionRepo.EXPECT().GetByID(context.Background(), "sid").Return(activeSession(), nil)
+		userRepo.EXPECT().GetByID(context.Background(), 7).Return(&domain.User{ID: 7, LockedAt: &lockedAt}, nil)
+		sessionRepo.EXPECT().Revoke(context.Background(), "sid").Return(nil)
+
+		pair, err := service.Refresh(context.Background(), "sid.secret")
+		require.ErrorIs(t, err, domain.ErrUserLocked)
+		assert.Nil(t, pair)
+	})
+
+	t.Run("invalid (previous token reused)", func(t *testing.T) {
+		sessionRepo.EXPECT().GetByID(context.Background(), "sid").Return(activeSession(), nil)
+		sessionRepo.EXPECT().Revoke(context.Background(), "sid").Return(nil)
+
+		pair, err := service.Refresh(context.Background(), "sid.old-secret")
+		require.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+		assert.Nil(t, pair)
+	})
+
+	t.Run("invalid (unknown secret)", func(t *testing.T) {
+		sessionRepo.EXPECT().GetByID(context.Background(), "sid").Return(activeSession(), nil)
+
+		pair, err := service.Refresh(context.Background(), "sid.guess")
+		require.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.Nil(t, pair)
+	})
+
+	t.Run("invalid (revoked session)", func(t *testing.T) {
+		session := activeSession()
+		revokedAt := time.Now().UTC()
+		session.RevokedAt = &revokedAt
+
+		sessionRepo.EXPECT().GetByID(context.Background(), "sid").Return(session, nil)
+
+		pair, err := service.Refresh(context.Background(), "sid.secret")
+		require.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.Nil(t, pair)
+	})
+
+	t.Run("invalid (malformed)", func(t *testing.T) {
+		pair, err := service.Refresh(context.Background(), "no-dot")
+		require.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.Nil(t, pair)
+	})
+}
+
+func TestSessionsService_IsSessionActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	sessionRepo := repomock.NewMocksessionRepository(ctrl)
+	userRepo := repomock.NewMockuserRepositoryForSessions(ctrl)
+	service := NewSessionsService(sessionRepo, userRepo, tokenManager, time.Minute*15, time.Hour)
+
+	t.Run("valid", func(t *testing.T) {
+		sessionRepo.
+			EXPECT().
+			GetByID(context.Background(), "sid").
+			Return(&domain.Session{ID: "sid", ExpiresAt: time.Now().UTC().Add(time.Hour)}, nil)
+
+		active, err := service.IsSessionActive(context.Background(), "sid")
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("invalid (not found)", func(t *testing.T) {
+		sessionRepo.EXPECT().GetByID(context.Background(), "missing").Return(nil, domain.ErrNotFound)
+
+		active, err := service.IsSessionActive(context.Background(), "missing")
+		require.NoError(t, err)
+		assert.False(t, active)
+	})
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type balanceActionRepositoryForStatement interface {
+	StreamStatement(
+		ctx context.Context, userID int, from *time.Time, to *time.Time, fn func(line domain.StatementLine) error,
+	) error
+}
+
+type StatementService struct {
+	balanceActionRepository balanceActionRepositoryForStatement
+}
+
+func NewStatementService(balanceActionRepository balanceActionRepositoryForStatement) *StatementService {
+	return &StatementService{
+		balanceActionRepository: balanceActionRepository,
+	}
+}
+
+// StreamStatement calls fn for every statement line of the user between from
+// (inclusive) and to (exclusive), oldest first.
+func (s *StatementService) StreamStatement(
+	ctx context.Context, userID int, from *time.Time, to *time.Time, fn func(line domain.StatementLine) error,
+) error {
+	if from != nil && to != nil && !from.Before(*to) {
+		return domain.ErrInvalidHistoryFilter
+	}
+
+	return s.balanceActionRepository.StreamStatement(ctx, userID, from, to, fn)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/services/mocks"
+)
+
+func TestStatementService_StreamStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForStatement(ctrl)
+	service := NewStatementService(balanceActionRepo)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("valid", func(t *testing.T) {
+		balanceActionRepo.
+			EXPECT().
+			StreamStatement(context.Background(), 1, &from, &to, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID int, from *time.Time, to *time.Time, fn func(line domain.StatementLine) error) error {
+				if err := fn(domain.StatementLine{Amount: domain.MoneyFromInt(10), Balance: domain.MoneyFromInt(10)}); err != nil {
+					return err
+				}
+
+				return fn(domain.StatementLine{Amount: domain.MoneyFromInt(-4), Balance: domain.MoneyFromInt(6)})
+			})
+
+		lines := make([]domain.StatementLine, 0)
+
+		err := service.StreamStatement(context.Background(), 1, &from, &to, func(line domain.StatementLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, domain.MoneyFromInt(6), lines[1].Balance)
+	})
+
+	t.Run("invalid (empty range)", func(t *testing.T) {
+		err := service.StreamStatement(context.Background(), 1, &to, &from, func(line domain.StatementLine) error {
+			return nil
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidHistoryFilter)
+	})
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -15,33 +16,57 @@ type userRepository interface {
 }
 
 type balanceActionsRepositoryForUser interface {
-	GetWithdrawalAmount(ctx context.Context, userID int) float64
-	GetCurrentBalance(ctx context.Context, userID int) float64
+	GetWithdrawalAmount(ctx context.Context, userID int) domain.Money
+	GetCurrentBalance(ctx context.Context, userID int) domain.Money
+	GetHeldAmount(ctx context.Context, userID int) domain.Money
+	GetExpiringPoints(ctx context.Context, userID int, before time.Time) ([]domain.ExpiringPoints, error)
 }
 
 type UserService struct {
 	repo               userRepository
 	balanceActionsRepo balanceActionsRepositoryForUser
+	expiringSoonWindow time.Duration
+	passwordPolicy     domain.PasswordPolicy
 }
 
-func NewUserService(repo userRepository, balanceActionsRepo balanceActionsRepositoryForUser) *UserService {
+func NewUserService(
+	repo userRepository,
+	balanceActionsRepo balanceActionsRepositoryForUser,
+	expiringSoonWindow time.Duration,
+	passwordPolicy domain.PasswordPolicy,
+) *UserService {
 	return &UserService{
 		repo:               repo,
 		balanceActionsRepo: balanceActionsRepo,
+		expiringSoonWindow: expiringSoonWindow,
+		passwordPolicy:     passwordPolicy,
 	}
 }
 
 func (s *UserService) GetUserBalance(ctx context.Context, userID int) (*domain.UserBalance, error) {
 	userBalance := s.balanceActionsRepo.GetCurrentBalance(ctx, userID)
 	withdrawalAmount := s.balanceActionsRepo.GetWithdrawalAmount(ctx, userID)
+	heldAmount := s.balanceActionsRepo.GetHeldAmount(ctx, userID)
+
+	expiringSoon, err := s.balanceActionsRepo.GetExpiringPoints(ctx, userID, time.Now().UTC().Add(s.expiringSoonWindow))
+
+	if err != nil {
+		return nil, err
+	}
 
 	return &domain.UserBalance{
-		Current:   userBalance,
-		Withdrawn: withdrawalAmount,
+		Current:      userBalance,
+		Withdrawn:    withdrawalAmount,
+		Held:         heldAmount,
+		ExpiringSoon: expiringSoon,
 	}, nil
 }
 
 func (s *UserService) Register(ctx context.Context, login string, password string) (*domain.User, error) {
+	if err := s.passwordPolicy.Validate(login, password); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 
 	if err != nil {
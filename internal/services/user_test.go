@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,7 +20,7 @@ func TestUserService_Register(t *testing.T) {
 	userRepo := repomock.NewMockuserRepository(ctrl)
 	balanceActionRepo := repomock.NewMockbalanceActionsRepositoryForUser(ctrl)
 
-	service := NewUserService(userRepo, balanceActionRepo)
+	service := NewUserService(userRepo, balanceActionRepo, time.Hour*24*30, domain.DefaultPasswordPolicy())
 
 	t.Run("valid", func(t *testing.T) {
 		login := "User"
@@ -48,6 +49,13 @@ func TestUserService_Register(t *testing.T) {
 		require.ErrorIs(t, err, domain.ErrLoginAlreadyTaken)
 		assert.Nil(t, user)
 	})
+
+	t.Run("invalid (weak password)", func(t *testing.T) {
+		user, err := service.Register(context.Background(), "User2", "short")
+
+		require.ErrorIs(t, err, domain.ErrWeakPassword)
+		assert.Nil(t, user)
+	})
 }
 
 func TestUserService_Auth(t *testing.T) {
@@ -56,7 +64,7 @@ func TestUserService_Auth(t *testing.T) {
 	userRepo := repomock.NewMockuserRepository(ctrl)
 	balanceActionRepo := repomock.NewMockbalanceActionsRepositoryForUser(ctrl)
 
-	service := NewUserService(userRepo, balanceActionRepo)
+	service := NewUserService(userRepo, balanceActionRepo, time.Hour*24*30, domain.DefaultPasswordPolicy())
 
 	t.Run("valid", func(t *testing.T) {
 		login := "User"
@@ -111,12 +119,14 @@ func TestUserService_GetUserBalance(t *testing.T) {
 	userRepo := repomock.NewMockuserRepository(ctrl)
 	balanceActionRepo := repomock.NewMockbalanceActionsRepositoryForUser(ctrl)
 
-	service := NewUserService(userRepo, balanceActionRepo)
+	service := NewUserService(userRepo, balanceActionRepo, time.Hour*24*30, domain.DefaultPasswordPolicy())
 
 	t.Run("valid", func(t *testing.T) {
 		userID := 1
-		balance := 70.0
-		withdrawn := 30.0
+		balance := domain.MoneyFromInt(70)
+		withdrawn := domain.MoneyFromInt(30)
+		held := domain.MoneyFromInt(20)
+		expiring := []domain.ExpiringPoints{{Amount: domain.MoneyFromInt(15), ExpiresAt: time.Now().UTC().Add(time.Hour * 48)}}
 
 		balanceActionRepo.
 			EXPECT().
@@ -126,17 +136,29 @@ func TestUserService_GetUserBalance(t *testing.T) {
 			EXPECT().
 			GetWithdrawalAmount(context.Background(), userID).
 			Return(withdrawn)
+		balanceActionRepo.
+			EXPECT().
+			GetHeldAmount(context.Background(), userID).
+			Return(held)
+		balanceActionRepo.
+			EXPECT().
+			GetExpiringPoints(context.Background(), userID, gomock.Any()).
+			Return(expiring, nil)
 		userBalance, err := service.GetUserBalance(context.Background(), userID)
 
 		require.NoError(t, err)
 		assert.Equal(t, balance, userBalance.Current)
 		assert.Equal(t, withdrawn, userBalance.Withdrawn)
+		assert.Equal(t, held, userBalance.Held)
+		assert.Equal(t, expiring, userBalance.ExpiringSoon)
 	})
 
 	t.Run("valid zero", func(t *testing.T) {
 		userID := 1
-		balance := 0.0
-		withdrawn := 0.0
+		balance := domain.Money{}
+		withdrawn := domain.Money{}
+		held := domain.Money{}
+		expiring := []domain.ExpiringPoints{}
 
 		balanceActionRepo.
 			EXPECT().
@@ -146,10 +168,20 @@ func TestUserService_GetUserBalance(t *testing.T) {
 			EXPECT().
 			GetWithdrawalAmount(context.Background(), userID).
 			Return(withdrawn)
+		balanceActionRepo.
+			EXPECT().
+			GetHeldAmount(context.Background(), userID).
+			Return(held)
+		balanceActionRepo.
+			EXPECT().
+			GetExpiringPoints(context.Background(), userID, gomock.Any()).
+			Return(expiring, nil)
 		userBalance, err := service.GetUserBalance(context.Background(), userID)
 
 		require.NoError(t, err)
 		assert.Equal(t, balance, userBalance.Current)
 		assert.Equal(t, withdrawn, userBalance.Withdrawn)
+		assert.Equal(t, held, userBalance.Held)
+		assert.Equal(t, expiring, userBalance.ExpiringSoon)
 	})
 }
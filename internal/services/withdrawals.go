@@ -7,9 +7,10 @@ import (
 )
 
 type balanceActionRepository interface {
-	GetCurrentBalance(ctx context.Context, userID int) float64
+	GetCurrentBalance(ctx context.Context, userID int) domain.Money
 	GetUserWithdrawals(ctx context.Context, userID int) ([]domain.BalanceAction, error)
-	Save(ctx context.Context, userID int, orderID string, amount float64) error
+	GetUserWithdrawalsPage(ctx context.Context, userID int, filter domain.HistoryFilter) (*domain.BalanceActionsPage, error)
+	Save(ctx context.Context, userID int, orderID string, amount domain.Money) error
 }
 
 type WithdrawalsService struct {
@@ -28,8 +29,24 @@ func (s *WithdrawalsService) GetWithdrawalsHistory(ctx context.Context, userID i
 	return s.balanceActionRepository.GetUserWithdrawals(ctx, userID)
 }
 
-func (s *WithdrawalsService) WithdrawBalance(ctx context.Context, userID int, orderID string, amount float64) error {
-	err := s.balanceActionRepository.Save(ctx, userID, orderID, -amount)
+// GetWithdrawalsHistoryPage returns one page of the user withdrawals, newest
+// first unless the filter asks otherwise.
+func (s *WithdrawalsService) GetWithdrawalsHistoryPage(
+	ctx context.Context, userID int, filter domain.HistoryFilter,
+) (*domain.BalanceActionsPage, error) {
+	err := filter.Normalize(
+		domain.DescSortDirection,
+		domain.ProcessedBalanceActionStatus, domain.RefundedWithdrawalStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.balanceActionRepository.GetUserWithdrawalsPage(ctx, userID, filter)
+}
+
+func (s *WithdrawalsService) WithdrawBalance(ctx context.Context, userID int, orderID string, amount domain.Money) error {
+	err := s.balanceActionRepository.Save(ctx, userID, orderID, amount.Neg())
 
 	if err != nil {
 		return err
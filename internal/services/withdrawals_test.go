@@ -20,11 +20,11 @@ func TestWithdrawalsService_WithdrawBalance(t *testing.T) {
 	t.Run("valid withdrawal", func(t *testing.T) {
 		userID := 1
 		orderID := "100"
-		amount := 100.0
+		amount := domain.MoneyFromInt(100)
 
 		balanceActionRepo.
 			EXPECT().
-			Save(context.Background(), userID, orderID, -amount).
+			Save(context.Background(), userID, orderID, amount.Neg()).
 			Return(nil)
 
 		err := service.WithdrawBalance(context.Background(), userID, orderID, amount)
@@ -34,11 +34,11 @@ func TestWithdrawalsService_WithdrawBalance(t *testing.T) {
 	t.Run("invalid withdrawal", func(t *testing.T) {
 		userID := 1
 		orderID := "100"
-		amount := 500.0
+		amount := domain.MoneyFromInt(500)
 
 		balanceActionRepo.
 			EXPECT().
-			Save(context.Background(), userID, orderID, -amount).
+			Save(context.Background(), userID, orderID, amount.Neg()).
 			Return(domain.ErrInsufficientFunds)
 
 		err := service.WithdrawBalance(context.Background(), userID, orderID, amount)
@@ -58,7 +58,7 @@ func TestWithdrawalsService_GetWithdrawalsHistory(t *testing.T) {
 		balanceActionRepo.
 			EXPECT().
 			GetUserWithdrawals(context.Background(), userID).
-			Return([]domain.BalanceAction{{UserID: userID, Amount: 100}, {UserID: userID, Amount: 100}}, nil)
+			Return([]domain.BalanceAction{{UserID: userID, Amount: domain.MoneyFromInt(100)}, {UserID: userID, Amount: domain.MoneyFromInt(100)}}, nil)
 
 		withdrawals, err := service.GetWithdrawalsHistory(context.Background(), userID)
 		assert.NoError(t, err)
@@ -78,3 +78,36 @@ func TestWithdrawalsService_GetWithdrawalsHistory(t *testing.T) {
 		assert.Len(t, withdrawals, 0)
 	})
 }
+
+func TestWithdrawalsService_GetWithdrawalsHistoryPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	balanceActionRepo := repomock.NewMockbalanceActionRepository(ctrl)
+	service := NewWithdrawalsService(balanceActionRepo)
+
+	t.Run("valid (defaults applied)", func(t *testing.T) {
+		userID := 1
+		nextCursor := "next"
+		expectedFilter := domain.HistoryFilter{Limit: 2, Sort: domain.DescSortDirection, Status: domain.RefundedWithdrawalStatus}
+
+		balanceActionRepo.
+			EXPECT().
+			GetUserWithdrawalsPage(context.Background(), userID, expectedFilter).
+			Return(&domain.BalanceActionsPage{Items: []domain.BalanceAction{{ID: 2}, {ID: 1}}, NextCursor: &nextCursor}, nil)
+
+		page, err := service.GetWithdrawalsHistoryPage(
+			context.Background(),
+			userID,
+			domain.HistoryFilter{Limit: 2, Status: domain.RefundedWithdrawalStatus},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, &nextCursor, page.NextCursor)
+	})
+
+	t.Run("invalid (limit too big)", func(t *testing.T) {
+		page, err := service.GetWithdrawalsHistoryPage(context.Background(), 1, domain.HistoryFilter{Limit: domain.MaxPageLimit + 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidHistoryFilter)
+		assert.Nil(t, page)
+	})
+}
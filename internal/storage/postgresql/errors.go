@@ -2,4 +2,5 @@ package postgresql
 
 var (
 	PgUniqueIndexErrorCode = "23505"
+	PgForeignKeyErrorCode  = "23503"
 )
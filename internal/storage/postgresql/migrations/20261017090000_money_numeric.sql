@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Amounts used to be DOUBLE PRECISION. Money keeps two fractional digits, so any
+-- value carrying a sub-cent residue is copied here verbatim before rounding.
+CREATE TABLE IF NOT EXISTS money_migration_residues (
+    table_name VARCHAR(64) NOT NULL,
+    row_key VARCHAR(255) NOT NULL,
+    column_name VARCHAR(64) NOT NULL,
+    original_value DOUBLE PRECISION NOT NULL,
+    migrated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+INSERT INTO money_migration_residues (table_name, row_key, column_name, original_value)
+SELECT 'balance_actions', id::text, 'amount', amount
+FROM balance_actions
+WHERE amount::numeric <> ROUND(amount::numeric, 2);
+INSERT INTO money_migration_residues (table_name, row_key, column_name, original_value)
+SELECT 'user_orders', order_id, 'accrual', accrual
+FROM user_orders
+WHERE accrual IS NOT NULL AND accrual::numeric <> ROUND(accrual::numeric, 2);
+INSERT INTO money_migration_residues (table_name, row_key, column_name, original_value)
+SELECT 'good_rewards', id::text, 'reward', reward
+FROM good_rewards
+WHERE reward::numeric <> ROUND(reward::numeric, 2);
+INSERT INTO money_migration_residues (table_name, row_key, column_name, original_value)
+SELECT 'registered_orders', order_id, 'accrual', accrual
+FROM registered_orders
+WHERE accrual IS NOT NULL AND accrual::numeric <> ROUND(accrual::numeric, 2);
+INSERT INTO money_migration_residues (table_name, row_key, column_name, original_value)
+SELECT 'orders_goods', order_id, 'price', price
+FROM orders_goods
+WHERE price IS NOT NULL AND price::numeric <> ROUND(price::numeric, 2);
+
+ALTER TABLE balance_actions ALTER COLUMN amount TYPE NUMERIC(19, 2) USING ROUND(amount::numeric, 2);
+ALTER TABLE user_orders ALTER COLUMN accrual TYPE NUMERIC(19, 2) USING ROUND(accrual::numeric, 2);
+ALTER TABLE good_rewards ALTER COLUMN reward TYPE NUMERIC(19, 2) USING ROUND(reward::numeric, 2);
+ALTER TABLE registered_orders ALTER COLUMN accrual TYPE NUMERIC(19, 2) USING ROUND(accrual::numeric, 2);
+ALTER TABLE orders_goods ALTER COLUMN price TYPE NUMERIC(19, 2) USING ROUND(price::numeric, 2);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE balance_actions ALTER COLUMN amount TYPE DOUBLE PRECISION;
+ALTER TABLE user_orders ALTER COLUMN accrual TYPE DOUBLE PRECISION;
+ALTER TABLE good_rewards ALTER COLUMN reward TYPE DOUBLE PRECISION;
+ALTER TABLE registered_orders ALTER COLUMN accrual TYPE DOUBLE PRECISION;
+ALTER TABLE orders_goods ALTER COLUMN price TYPE DOUBLE PRECISION;
+DROP TABLE IF EXISTS money_migration_residues;
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(40) NOT NULL,
+    user_id INTEGER NOT NULL DEFAULT 0,
+    balance NUMERIC(19, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (type, user_id)
+);
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(40) NOT NULL,
+    order_id VARCHAR(255) NOT NULL DEFAULT '',
+    balance_action_id INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id SERIAL PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES ledger_entries(id),
+    account_id INTEGER NOT NULL REFERENCES ledger_accounts(id),
+    amount NUMERIC(19, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS ledger_postings_account_id_idx ON ledger_postings (account_id, id);
+CREATE INDEX IF NOT EXISTS ledger_entries_balance_action_id_idx ON ledger_entries (balance_action_id);
+
+INSERT INTO ledger_accounts (type, user_id)
+VALUES ('SYSTEM_ISSUANCE', 0), ('REDEMPTION_SINK', 0)
+ON CONFLICT (type, user_id) DO NOTHING;
+
+INSERT INTO ledger_accounts (type, user_id)
+SELECT 'USER_POINTS', id FROM users
+UNION
+SELECT 'USER_POINTS', user_id FROM balance_actions
+ON CONFLICT (type, user_id) DO NOTHING;
+
+-- Replay the existing history so every balance action has a balanced entry.
+INSERT INTO ledger_entries (kind, order_id, balance_action_id, created_at)
+SELECT CASE WHEN amount < 0 THEN 'WITHDRAWAL' ELSE 'ACCRUAL' END, order_id, id, created_at
+FROM balance_actions
+WHERE amount <> 0
+ORDER BY id;
+
+INSERT INTO ledger_postings (entry_id, account_id, amount, created_at)
+SELECT e.id, a.id, ba.amount, ba.created_at
+FROM ledger_entries e
+JOIN balance_actions ba ON ba.id = e.balance_action_id
+JOIN ledger_accounts a ON a.type = 'USER_POINTS' AND a.user_id = ba.user_id;
+
+INSERT INTO ledger_postings (entry_id, account_id, amount, created_at)
+SELECT e.id, a.id, -ba.amount, ba.created_at
+FROM ledger_entries e
+JOIN balance_actions ba ON ba.id = e.balance_action_id
+JOIN ledger_accounts a ON a.user_id = 0
+    AND a.type = CASE WHEN ba.amount < 0 THEN 'REDEMPTION_SINK' ELSE 'SYSTEM_ISSUANCE' END;
+
+UPDATE ledger_accounts
+SET balance = COALESCE((SELECT SUM(p.amount) FROM ledger_postings p WHERE p.account_id = ledger_accounts.id), 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+-- +goose StatementEnd
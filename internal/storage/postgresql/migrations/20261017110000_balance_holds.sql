@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'PROCESSED';
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS balance_actions_held_expires_at_idx ON balance_actions (expires_at) WHERE status = 'HELD';
+CREATE INDEX IF NOT EXISTS balance_actions_user_id_idx ON balance_actions (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS balance_actions_user_id_idx;
+DROP INDEX IF EXISTS balance_actions_held_expires_at_idx;
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS kind VARCHAR(20);
+UPDATE balance_actions SET kind = CASE WHEN amount < 0 THEN 'WITHDRAWAL' ELSE 'ACCRUAL' END WHERE kind IS NULL;
+ALTER TABLE balance_actions ALTER COLUMN kind SET NOT NULL;
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS reverses_id INTEGER REFERENCES balance_actions (id);
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS reason VARCHAR(50);
+CREATE UNIQUE INDEX IF NOT EXISTS balance_actions_reverses_id_idx ON balance_actions (reverses_id) WHERE reverses_id IS NOT NULL;
+ALTER TABLE user_orders ADD COLUMN IF NOT EXISTS reversal_reason VARCHAR(50);
+ALTER TABLE user_orders ADD COLUMN IF NOT EXISTS reversed_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE user_orders DROP COLUMN IF EXISTS reversed_at;
+ALTER TABLE user_orders DROP COLUMN IF EXISTS reversal_reason;
+DROP INDEX IF EXISTS balance_actions_reverses_id_idx;
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS reason;
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS reverses_id;
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS kind;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS accrual_lots (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    balance_action_id INTEGER REFERENCES balance_actions(id),
+    order_id VARCHAR(255),
+    amount NUMERIC(19, 2) NOT NULL,
+    remaining NUMERIC(19, 2) NOT NULL,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE TABLE IF NOT EXISTS lot_consumptions (
+    id SERIAL PRIMARY KEY,
+    lot_id INTEGER NOT NULL REFERENCES accrual_lots(id),
+    balance_action_id INTEGER NOT NULL REFERENCES balance_actions(id),
+    amount NUMERIC(19, 2) NOT NULL,
+    restored_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS accrual_lots_user_id_idx ON accrual_lots (user_id, expires_at) WHERE remaining > 0;
+CREATE INDEX IF NOT EXISTS accrual_lots_expires_at_idx ON accrual_lots (expires_at) WHERE remaining > 0;
+CREATE INDEX IF NOT EXISTS lot_consumptions_balance_action_id_idx ON lot_consumptions (balance_action_id);
+
+INSERT INTO ledger_accounts (type, user_id)
+VALUES ('SYSTEM_EXPIRED', 0)
+ON CONFLICT (type, user_id) DO NOTHING;
+
+-- Points accrued before lots existed become one lot per user. It expires 365
+-- days after the migration, the default POINTS_TTL; migrations can not read
+-- the configured one.
+INSERT INTO accrual_lots (user_id, amount, remaining, expires_at)
+SELECT ba.user_id, SUM(ba.amount), LEAST(SUM(ba.amount), GREATEST(COALESCE(MAX(a.balance), 0), 0)), NOW() + INTERVAL '365 days'
+FROM balance_actions ba
+LEFT JOIN ledger_accounts a ON a.type = 'USER_POINTS' AND a.user_id = ba.user_id
+WHERE ba.kind = 'ACCRUAL' AND ba.amount > 0
+GROUP BY ba.user_id;
+
+-- Link the existing withdrawals and holds to that lot so refunds and releases
+-- can put the points back.
+INSERT INTO lot_consumptions (lot_id, balance_action_id, amount, created_at)
+SELECT l.id, ba.id, -ba.amount, ba.created_at
+FROM balance_actions ba
+JOIN accrual_lots l ON l.user_id = ba.user_id AND l.balance_action_id IS NULL
+WHERE ba.kind = 'WITHDRAWAL' AND ba.status IN ('PROCESSED', 'HELD')
+    AND NOT EXISTS (SELECT 1 FROM balance_actions r WHERE r.reverses_id = ba.id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS lot_consumptions;
+DROP TABLE IF EXISTS accrual_lots;
+-- +goose StatementEnd
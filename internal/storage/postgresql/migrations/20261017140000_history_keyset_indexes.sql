@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE INDEX IF NOT EXISTS user_orders_user_id_uploaded_at_idx ON user_orders (user_id, uploaded_at, order_id);
+CREATE INDEX IF NOT EXISTS balance_actions_user_id_kind_created_at_idx ON balance_actions (user_id, kind, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS balance_actions_user_id_kind_created_at_idx;
+DROP INDEX IF EXISTS user_orders_user_id_uploaded_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    prev_refresh_token_hash VARCHAR(64),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    refreshed_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id) WHERE revoked_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
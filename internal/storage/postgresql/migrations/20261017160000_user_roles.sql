@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'customer';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS lock_reason VARCHAR(255);
+ALTER TABLE balance_actions ALTER COLUMN reason TYPE VARCHAR(255);
+ALTER TABLE balance_actions ADD COLUMN IF NOT EXISTS actor_id INTEGER REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE balance_actions DROP COLUMN IF EXISTS actor_id;
+ALTER TABLE users DROP COLUMN IF EXISTS lock_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS locked_at;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
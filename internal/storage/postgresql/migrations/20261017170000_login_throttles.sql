@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS login_throttles (
+    scope VARCHAR(10) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    blocked_until TIMESTAMP,
+    PRIMARY KEY (scope, key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS login_throttles;
+-- +goose StatementEnd
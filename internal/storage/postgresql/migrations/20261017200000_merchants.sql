@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS merchants (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+INSERT INTO merchants (id, code, name) VALUES (1, 'default', 'Default merchant') ON CONFLICT DO NOTHING;
+SELECT setval('merchants_id_seq', (SELECT MAX(id) FROM merchants));
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS merchant_id INTEGER REFERENCES merchants(id);
+
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS merchant_id INTEGER NOT NULL DEFAULT 1 REFERENCES merchants(id);
+ALTER TABLE good_rewards ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE good_rewards DROP CONSTRAINT IF EXISTS good_rewards_match_key;
+ALTER TABLE good_rewards ADD CONSTRAINT good_rewards_merchant_id_match_key UNIQUE (merchant_id, match);
+
+ALTER TABLE registered_orders ADD COLUMN IF NOT EXISTS merchant_id INTEGER NOT NULL DEFAULT 1 REFERENCES merchants(id);
+ALTER TABLE registered_orders ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS merchant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE orders_goods ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE orders_goods DROP CONSTRAINT IF EXISTS orders_goods_order_id_fkey;
+ALTER TABLE registered_orders DROP CONSTRAINT IF EXISTS registered_orders_pkey;
+ALTER TABLE registered_orders ADD CONSTRAINT registered_orders_pkey PRIMARY KEY (merchant_id, order_id);
+ALTER TABLE orders_goods ADD CONSTRAINT orders_goods_merchant_id_order_id_fkey
+    FOREIGN KEY (merchant_id, order_id) REFERENCES registered_orders (merchant_id, order_id);
+CREATE INDEX IF NOT EXISTS orders_goods_merchant_id_order_id_idx ON orders_goods (merchant_id, order_id);
+
+ALTER TABLE user_orders ADD COLUMN IF NOT EXISTS merchant VARCHAR(64) NOT NULL DEFAULT 'default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE user_orders DROP COLUMN IF EXISTS merchant;
+
+DROP INDEX IF EXISTS orders_goods_merchant_id_order_id_idx;
+ALTER TABLE orders_goods DROP CONSTRAINT IF EXISTS orders_goods_merchant_id_order_id_fkey;
+ALTER TABLE registered_orders DROP CONSTRAINT IF EXISTS registered_orders_pkey;
+ALTER TABLE registered_orders ADD CONSTRAINT registered_orders_pkey PRIMARY KEY (order_id);
+ALTER TABLE orders_goods ADD CONSTRAINT orders_goods_order_id_fkey
+    FOREIGN KEY (order_id) REFERENCES registered_orders (order_id);
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE registered_orders DROP COLUMN IF EXISTS merchant_id;
+
+ALTER TABLE good_rewards DROP CONSTRAINT IF EXISTS good_rewards_merchant_id_match_key;
+ALTER TABLE good_rewards ADD CONSTRAINT good_rewards_match_key UNIQUE (match);
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS merchant_id;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS merchant_id;
+DROP TABLE IF EXISTS merchants;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+UPDATE good_rewards SET updated_at = created_at;
+
+-- A deleted rule must not block a new one with the same match.
+ALTER TABLE good_rewards DROP CONSTRAINT IF EXISTS good_rewards_merchant_id_match_key;
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx ON good_rewards (merchant_id, match)
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS good_rewards_merchant_id_created_at_idx ON good_rewards (merchant_id, created_at, id)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS good_rewards_merchant_id_created_at_idx;
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+DELETE FROM good_rewards WHERE deleted_at IS NOT NULL;
+ALTER TABLE good_rewards ADD CONSTRAINT good_rewards_merchant_id_match_key UNIQUE (merchant_id, match);
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS disabled;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS match_type VARCHAR(16) NOT NULL DEFAULT 'substring';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS ignore_case BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- "Bork" as a word and "Bork" as a prefix are different rules.
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx
+    ON good_rewards (merchant_id, match, match_type, ignore_case)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+UPDATE good_rewards SET deleted_at = NOW()
+WHERE deleted_at IS NULL AND id NOT IN (
+    SELECT MIN(id) FROM good_rewards WHERE deleted_at IS NULL GROUP BY merchant_id, match
+);
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx ON good_rewards (merchant_id, match)
+    WHERE deleted_at IS NULL;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS ignore_case;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS match_type;
+-- +goose StatementEnd
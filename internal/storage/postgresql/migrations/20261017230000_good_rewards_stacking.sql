@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS stacking VARCHAR(16) NOT NULL DEFAULT 'stackable';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS max_per_good NUMERIC(19, 2);
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS max_per_order NUMERIC(19, 2);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS max_per_order;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS max_per_good;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS stacking;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS campaigns (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    name VARCHAR(255) NOT NULL,
+    valid_from TIMESTAMP,
+    valid_to TIMESTAMP,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT campaigns_merchant_id_name_key UNIQUE (merchant_id, name),
+    CONSTRAINT campaigns_merchant_id_id_key UNIQUE (merchant_id, id)
+);
+
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS campaign_id INTEGER;
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS valid_from TIMESTAMP;
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS valid_to TIMESTAMP;
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS schedule JSONB;
+-- A rule can only join a campaign of its own merchant.
+ALTER TABLE good_rewards ADD CONSTRAINT good_rewards_merchant_id_campaign_id_fkey
+    FOREIGN KEY (merchant_id, campaign_id) REFERENCES campaigns (merchant_id, id);
+CREATE INDEX IF NOT EXISTS good_rewards_campaign_id_idx ON good_rewards (campaign_id)
+    WHERE campaign_id IS NOT NULL;
+
+ALTER TABLE registered_orders ADD COLUMN IF NOT EXISTS purchased_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE registered_orders DROP COLUMN IF EXISTS purchased_at;
+
+DROP INDEX IF EXISTS good_rewards_campaign_id_idx;
+ALTER TABLE good_rewards DROP CONSTRAINT IF EXISTS good_rewards_merchant_id_campaign_id_fkey;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS schedule;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS valid_to;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS valid_from;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS campaign_id;
+
+DROP TABLE IF EXISTS campaigns;
+-- +goose StatementEnd
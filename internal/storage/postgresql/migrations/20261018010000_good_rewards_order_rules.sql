@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS min_basket NUMERIC(19, 2);
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS tiers JSONB;
+
+-- Order rules may share a match, or have none, e.g. one bonus per threshold.
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx
+    ON good_rewards (merchant_id, match, match_type, ignore_case)
+    WHERE deleted_at IS NULL AND reward_type IN ('%', 'pt');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+UPDATE good_rewards SET deleted_at = NOW()
+WHERE deleted_at IS NULL AND reward_type NOT IN ('%', 'pt');
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx
+    ON good_rewards (merchant_id, match, match_type, ignore_case)
+    WHERE deleted_at IS NULL;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS tiers;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS min_basket;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS sku VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS category VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS unit_price NUMERIC(19, 2);
+
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS match_field VARCHAR(16) NOT NULL DEFAULT 'description';
+
+-- "Bork" as a description and "Bork" as a category are different rules.
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx
+    ON good_rewards (merchant_id, match_field, match, match_type, ignore_case)
+    WHERE deleted_at IS NULL AND reward_type IN ('%', 'pt');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS good_rewards_merchant_id_match_idx;
+UPDATE good_rewards SET deleted_at = NOW()
+WHERE deleted_at IS NULL AND match_field <> 'description';
+CREATE UNIQUE INDEX IF NOT EXISTS good_rewards_merchant_id_match_idx
+    ON good_rewards (merchant_id, match, match_type, ignore_case)
+    WHERE deleted_at IS NULL AND reward_type IN ('%', 'pt');
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS match_field;
+
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS unit_price;
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS quantity;
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS category;
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS sku;
+-- +goose StatementEnd
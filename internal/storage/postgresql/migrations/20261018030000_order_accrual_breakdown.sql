@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- Goods are numbered in the order they were registered, older ones in the
+-- order they happen to be stored.
+ALTER TABLE orders_goods ADD COLUMN IF NOT EXISTS line INTEGER;
+UPDATE orders_goods SET line = numbered.line
+FROM (
+    SELECT ctid, row_number() OVER (PARTITION BY merchant_id, order_id ORDER BY ctid) - 1 AS line
+    FROM orders_goods
+) AS numbered
+WHERE orders_goods.ctid = numbered.ctid;
+ALTER TABLE orders_goods ALTER COLUMN line SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS orders_goods_merchant_id_order_id_line_idx
+    ON orders_goods (merchant_id, order_id, line);
+
+-- Orders calculated before breakdowns were kept have no calculated_at.
+ALTER TABLE registered_orders ADD COLUMN IF NOT EXISTS calculated_at TIMESTAMP;
+
+-- line is NULL for what order rules gave for the whole order.
+CREATE TABLE IF NOT EXISTS order_accrual_contributions (
+    merchant_id INTEGER NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    line INTEGER,
+    rule_id INTEGER NOT NULL REFERENCES good_rewards(id),
+    rule_version INTEGER NOT NULL,
+    reward_type VARCHAR(10) NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL,
+    PRIMARY KEY (merchant_id, order_id, position),
+    FOREIGN KEY (merchant_id, order_id) REFERENCES registered_orders (merchant_id, order_id)
+);
+
+ALTER TABLE user_orders ADD COLUMN IF NOT EXISTS accrual_breakdown JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE user_orders DROP COLUMN IF EXISTS accrual_breakdown;
+DROP TABLE IF EXISTS order_accrual_contributions;
+ALTER TABLE registered_orders DROP COLUMN IF EXISTS calculated_at;
+DROP INDEX IF EXISTS orders_goods_merchant_id_order_id_line_idx;
+ALTER TABLE orders_goods DROP COLUMN IF EXISTS line;
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Every version of a rule as it was from effective_at on. Rows are only ever
+-- inserted, good_rewards holds the latest version.
+CREATE TABLE IF NOT EXISTS good_reward_versions (
+    rule_id INTEGER NOT NULL REFERENCES good_rewards(id),
+    version INTEGER NOT NULL,
+    effective_at TIMESTAMP NOT NULL,
+    merchant_id INTEGER NOT NULL,
+    match VARCHAR(255) NOT NULL,
+    match_type VARCHAR(16) NOT NULL,
+    match_field VARCHAR(16) NOT NULL,
+    ignore_case BOOLEAN NOT NULL,
+    reward NUMERIC(19, 2) NOT NULL,
+    reward_type VARCHAR(10) NOT NULL,
+    min_basket NUMERIC(19, 2),
+    tiers JSONB,
+    priority INTEGER NOT NULL,
+    stacking VARCHAR(16) NOT NULL,
+    max_per_good NUMERIC(19, 2),
+    max_per_order NUMERIC(19, 2),
+    campaign_id INTEGER,
+    valid_from TIMESTAMP,
+    valid_to TIMESTAMP,
+    schedule JSONB,
+    disabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    PRIMARY KEY (rule_id, version)
+);
+
+-- Earlier versions were overwritten, only the latest one is known.
+INSERT INTO good_reward_versions (
+    rule_id, version, effective_at, merchant_id, match, match_type, match_field, ignore_case, reward, reward_type,
+    min_basket, tiers, priority, stacking, max_per_good, max_per_order, campaign_id, valid_from, valid_to, schedule,
+    disabled, created_at, updated_at, deleted_at
+)
+SELECT
+    id, version, COALESCE(deleted_at, updated_at), merchant_id, match, match_type, match_field, ignore_case, reward,
+    reward_type, min_basket, tiers, priority, stacking, max_per_good, max_per_order, campaign_id, valid_from, valid_to,
+    schedule, disabled, created_at, updated_at, deleted_at
+FROM good_rewards
+ON CONFLICT DO NOTHING;
+
+-- Contributions kept before this migration may name versions that are lost.
+ALTER TABLE order_accrual_contributions ADD CONSTRAINT order_accrual_contributions_rule_version_fkey
+    FOREIGN KEY (rule_id, rule_version) REFERENCES good_reward_versions (rule_id, version) NOT VALID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE order_accrual_contributions DROP CONSTRAINT IF EXISTS order_accrual_contributions_rule_version_fkey;
+DROP TABLE IF EXISTS good_reward_versions;
+-- +goose StatementEnd
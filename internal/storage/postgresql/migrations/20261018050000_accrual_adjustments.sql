@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- What recalculations changed in the accrual of processed orders, read by
+-- gophermart in id order.
+CREATE TABLE IF NOT EXISTS order_accrual_adjustments (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    old_accrual NUMERIC(19, 2) NOT NULL,
+    new_accrual NUMERIC(19, 2) NOT NULL,
+    delta NUMERIC(19, 2) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (merchant_id, order_id) REFERENCES registered_orders (merchant_id, order_id)
+);
+
+-- Adjustments gophermart has seen. balance_action_id is NULL for those that
+-- did not change any balance, e.g. of orders no user uploaded.
+CREATE TABLE IF NOT EXISTS applied_accrual_adjustments (
+    adjustment_id INTEGER PRIMARY KEY,
+    order_id VARCHAR(255) NOT NULL,
+    balance_action_id INTEGER REFERENCES balance_actions(id),
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS applied_accrual_adjustments;
+DROP TABLE IF EXISTS order_accrual_adjustments;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Adjustments of orders whose accrual is not final yet are kept until it is,
+-- with applied_at NULL, so they carry what is needed to apply them later.
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS merchant VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS old_accrual NUMERIC(19, 2) NOT NULL DEFAULT 0;
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS new_accrual NUMERIC(19, 2) NOT NULL DEFAULT 0;
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS delta NUMERIC(19, 2) NOT NULL DEFAULT 0;
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS reason VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE applied_accrual_adjustments ADD COLUMN IF NOT EXISTS seen_at TIMESTAMP NOT NULL DEFAULT NOW();
+UPDATE applied_accrual_adjustments SET seen_at = applied_at;
+ALTER TABLE applied_accrual_adjustments ALTER COLUMN applied_at DROP NOT NULL;
+ALTER TABLE applied_accrual_adjustments ALTER COLUMN applied_at DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS applied_accrual_adjustments_pending_idx
+    ON applied_accrual_adjustments (order_id, adjustment_id)
+    WHERE applied_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS applied_accrual_adjustments_pending_idx;
+DELETE FROM applied_accrual_adjustments WHERE applied_at IS NULL;
+ALTER TABLE applied_accrual_adjustments ALTER COLUMN applied_at SET DEFAULT NOW();
+ALTER TABLE applied_accrual_adjustments ALTER COLUMN applied_at SET NOT NULL;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS seen_at;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS reason;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS delta;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS new_accrual;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS old_accrual;
+ALTER TABLE applied_accrual_adjustments DROP COLUMN IF EXISTS merchant;
+-- +goose StatementEnd
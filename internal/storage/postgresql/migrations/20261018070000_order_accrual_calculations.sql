@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Every calculation of an order, the first one and every recalculation, with
+-- the contributions it was made of. The breakdown of an order is its latest
+-- calculation.
+CREATE TABLE IF NOT EXISTS order_accrual_calculations (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    accrual NUMERIC(19, 2) NOT NULL,
+    calculated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (merchant_id, order_id) REFERENCES registered_orders (merchant_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS order_accrual_calculations_merchant_id_order_id_idx
+    ON order_accrual_calculations (merchant_id, order_id, id);
+
+-- Only the latest calculation of orders calculated before is known.
+INSERT INTO order_accrual_calculations (merchant_id, order_id, accrual, calculated_at)
+SELECT merchant_id, order_id, COALESCE(accrual, 0), calculated_at
+FROM registered_orders
+WHERE calculated_at IS NOT NULL;
+
+ALTER TABLE order_accrual_contributions ADD COLUMN IF NOT EXISTS calculation_id INTEGER
+    REFERENCES order_accrual_calculations(id);
+UPDATE order_accrual_contributions SET calculation_id = calculations.id
+FROM order_accrual_calculations AS calculations
+WHERE calculations.merchant_id = order_accrual_contributions.merchant_id
+    AND calculations.order_id = order_accrual_contributions.order_id;
+DELETE FROM order_accrual_contributions WHERE calculation_id IS NULL;
+ALTER TABLE order_accrual_contributions ALTER COLUMN calculation_id SET NOT NULL;
+ALTER TABLE order_accrual_contributions DROP CONSTRAINT IF EXISTS order_accrual_contributions_pkey;
+ALTER TABLE order_accrual_contributions ADD PRIMARY KEY (calculation_id, position);
+
+-- The calculation that gave new_accrual; the one before it gave old_accrual.
+ALTER TABLE order_accrual_adjustments ADD COLUMN IF NOT EXISTS calculation_id INTEGER
+    REFERENCES order_accrual_calculations(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE order_accrual_adjustments DROP COLUMN IF EXISTS calculation_id;
+
+DELETE FROM order_accrual_contributions
+WHERE calculation_id NOT IN (
+    SELECT MAX(id) FROM order_accrual_calculations GROUP BY merchant_id, order_id
+);
+ALTER TABLE order_accrual_contributions DROP CONSTRAINT IF EXISTS order_accrual_contributions_pkey;
+ALTER TABLE order_accrual_contributions ADD PRIMARY KEY (merchant_id, order_id, position);
+ALTER TABLE order_accrual_contributions DROP COLUMN IF EXISTS calculation_id;
+
+DROP TABLE IF EXISTS order_accrual_calculations;
+-- +goose StatementEnd
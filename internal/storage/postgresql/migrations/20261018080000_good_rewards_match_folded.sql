@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- The match of rules that ignore case, folded by the accrual service the same
+-- way its matcher folds goods. lower() depends on the database locale, so only
+-- ASCII matches are folded here; the others stay NULL until they are saved
+-- again and are always handed to the matcher meanwhile.
+ALTER TABLE good_rewards ADD COLUMN IF NOT EXISTS match_folded VARCHAR(255);
+UPDATE good_rewards
+SET match_folded = translate(match, 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz')
+WHERE match !~ '[^\x01-\x7f]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE good_rewards DROP COLUMN IF EXISTS match_folded;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Rules are matched as the version in force when the goods were bought, so the
+-- versions need the folded match too, see good_rewards.match_folded.
+ALTER TABLE good_reward_versions ADD COLUMN IF NOT EXISTS match_folded VARCHAR(255);
+UPDATE good_reward_versions
+SET match_folded = translate(match, 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz')
+WHERE match !~ '[^\x01-\x7f]';
+
+-- Only the latest version of rules older than their versions is known. It is
+-- taken to have been in force since the rule was created, so purchases made
+-- before its last edit still find the rule.
+UPDATE good_reward_versions AS versions
+SET effective_at = versions.created_at
+WHERE versions.deleted_at IS NULL AND versions.effective_at > versions.created_at
+    AND versions.version = (
+        SELECT MIN(version) FROM good_reward_versions WHERE rule_id = versions.rule_id
+    );
+
+CREATE INDEX IF NOT EXISTS good_reward_versions_merchant_id_rule_id_idx
+    ON good_reward_versions (merchant_id, rule_id, effective_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS good_reward_versions_merchant_id_rule_id_idx;
+ALTER TABLE good_reward_versions DROP COLUMN IF EXISTS match_folded;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Every entry posts to a system account, so keeping their running balance made
+-- every entry wait for the one before it to commit. Their balance is the sum of
+-- their postings instead.
+UPDATE ledger_accounts SET balance = 0 WHERE user_id = 0;
+COMMENT ON COLUMN ledger_accounts.balance IS
+    'Running balance of user accounts. Always 0 for system accounts (user_id 0), sum their postings instead.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+COMMENT ON COLUMN ledger_accounts.balance IS NULL;
+UPDATE ledger_accounts
+SET balance = COALESCE((SELECT SUM(p.amount) FROM ledger_postings p WHERE p.account_id = ledger_accounts.id), 0)
+WHERE user_id = 0;
+-- +goose StatementEnd
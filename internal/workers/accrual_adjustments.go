@@ -0,0 +1,156 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+type accrualAdjustmentsRepository interface {
+	GetAccrualAdjustmentsCursor(ctx context.Context, lookback time.Duration) (int, error)
+	GetPendingAccrualAdjustments(ctx context.Context, after int, limit int) ([]domain.AccrualAdjustment, error)
+	ApplyAccrualAdjustment(
+		ctx context.Context, adjustment domain.AccrualAdjustment, pointsExpireAt time.Time,
+	) (*domain.BalanceAction, error)
+}
+
+// AccrualAdjustmentsWorker posts what recalculations in the accrual system
+// change in the accrual of processed orders to the balances of their users.
+type AccrualAdjustmentsWorker struct {
+	repository    accrualAdjustmentsRepository
+	accrualClient *accrualClient
+	pointsTTL     time.Duration
+	batchSize     int
+	// lookback is how long adjustments are read from the feed again, in
+	// case an adjustment with a lower id commits after them.
+	lookback time.Duration
+}
+
+func NewAccrualAdjustmentsWorker(
+	repository accrualAdjustmentsRepository,
+	accrualBaseURL string,
+	accrualAPIKey string,
+	pointsTTL time.Duration,
+) *AccrualAdjustmentsWorker {
+	return &AccrualAdjustmentsWorker{
+		repository:    repository,
+		accrualClient: newAccrualClient(accrualBaseURL, accrualAPIKey),
+		pointsTTL:     pointsTTL,
+		batchSize:     100,
+		lookback:      time.Minute * 10,
+	}
+}
+
+func (w *AccrualAdjustmentsWorker) Start(ctx context.Context) {
+	log.Println("Start accrual_adjustments worker")
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[accrual_adjustments]: complete")
+			return
+		case <-ticker.C:
+			applied, err := w.applyAdjustments(ctx)
+
+			if err != nil {
+				log.Println("[accrual_adjustments]: apply adjustments", err)
+			}
+
+			if applied > 0 {
+				log.Println("[accrual_adjustments]: applied", applied, "adjustments")
+			}
+		}
+	}
+}
+
+// applyAdjustments first applies the pending adjustments whose order has a
+// final accrual now, then the adjustments of the feed from the cursor on, in
+// id order, batch by batch. It stops at the first failure, so the next run
+// tries that adjustment again. It returns how many balances changed.
+func (w *AccrualAdjustmentsWorker) applyAdjustments(ctx context.Context) (int, error) {
+	applied, err := w.applyPendingAdjustments(ctx)
+
+	if err != nil {
+		return applied, err
+	}
+
+	after, err := w.repository.GetAccrualAdjustmentsCursor(ctx, w.lookback)
+
+	if err != nil {
+		return applied, fmt.Errorf("get accrual adjustments cursor %w", err)
+	}
+
+	for {
+		var adjustments []domain.AccrualAdjustment
+
+		path := "/api/adjustments?after=" + strconv.Itoa(after) + "&limit=" + strconv.Itoa(w.batchSize)
+
+		if err := w.accrualClient.get(path, "", &adjustments); err != nil {
+			return applied, fmt.Errorf("get adjustments from accrual system %w", err)
+		}
+
+		count, err := w.apply(ctx, adjustments)
+		applied += count
+
+		if err != nil {
+			return applied, err
+		}
+
+		if len(adjustments) < w.batchSize {
+			return applied, nil
+		}
+
+		after = adjustments[len(adjustments)-1].ID
+	}
+}
+
+func (w *AccrualAdjustmentsWorker) applyPendingAdjustments(ctx context.Context) (int, error) {
+	applied := 0
+	after := 0
+
+	for {
+		adjustments, err := w.repository.GetPendingAccrualAdjustments(ctx, after, w.batchSize)
+
+		if err != nil {
+			return applied, fmt.Errorf("get pending adjustments %w", err)
+		}
+
+		count, err := w.apply(ctx, adjustments)
+		applied += count
+
+		if err != nil {
+			return applied, err
+		}
+
+		if len(adjustments) < w.batchSize {
+			return applied, nil
+		}
+
+		after = adjustments[len(adjustments)-1].ID
+	}
+}
+
+func (w *AccrualAdjustmentsWorker) apply(ctx context.Context, adjustments []domain.AccrualAdjustment) (int, error) {
+	applied := 0
+	expiresAt := time.Now().UTC().Add(w.pointsTTL)
+
+	for _, adjustment := range adjustments {
+		action, err := w.repository.ApplyAccrualAdjustment(ctx, adjustment, expiresAt)
+
+		if err != nil {
+			return applied, fmt.Errorf("apply adjustment %d %w", adjustment.ID, err)
+		}
+
+		if action != nil {
+			applied++
+		}
+	}
+
+	return applied, nil
+}
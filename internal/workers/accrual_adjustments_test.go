@@ -0,0 +1,152 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/workers/mocks"
+)
+
+func TestAccrualAdjustmentsWorker_applyAdjustments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := repomock.NewMockaccrualAdjustmentsRepository(ctrl)
+
+	// committed are the adjustments the accrual system shows in its feed.
+	var committed []domain.AccrualAdjustment
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/adjustments", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get(domain.APIKeyHeader))
+
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := make([]domain.AccrualAdjustment, 0)
+
+		for _, adjustment := range committed {
+			if adjustment.ID > after && len(page) < limit {
+				page = append(page, adjustment)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(page))
+	}))
+	defer server.Close()
+
+	worker := NewAccrualAdjustmentsWorker(repository, server.URL, "key", 0)
+	worker.batchSize = 2
+
+	adjustment := func(id int) domain.AccrualAdjustment {
+		return domain.AccrualAdjustment{ID: id, OrderID: strconv.Itoa(id), Delta: domain.MoneyFromInt(int64(id))}
+	}
+
+	applyIDs := func(ctx context.Context, appliedIDs ...int) *gomock.Call {
+		return repository.
+			EXPECT().
+			ApplyAccrualAdjustment(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, adjustment domain.AccrualAdjustment, _ time.Time) (*domain.BalanceAction, error) {
+				for _, id := range appliedIDs {
+					if adjustment.ID == id {
+						return &domain.BalanceAction{ID: 10 + id}, nil
+					}
+				}
+
+				return nil, nil
+			})
+	}
+
+	t.Run("valid (several batches)", func(t *testing.T) {
+		ctx := context.Background()
+		committed = []domain.AccrualAdjustment{adjustment(1), adjustment(2), adjustment(3)}
+
+		gomock.InOrder(
+			repository.EXPECT().GetPendingAccrualAdjustments(ctx, 0, 2).Return(nil, nil),
+			repository.EXPECT().GetAccrualAdjustmentsCursor(ctx, worker.lookback).Return(0, nil),
+			// The order of adjustment 2 was not uploaded by anyone.
+			applyIDs(ctx, 1, 3).Times(3),
+		)
+
+		applied, err := worker.applyAdjustments(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+	})
+
+	t.Run("valid (adjustments commit out of order)", func(t *testing.T) {
+		ctx := context.Background()
+
+		// Adjustment 2 is not committed yet when adjustment 3 is read.
+		committed = []domain.AccrualAdjustment{adjustment(1), adjustment(3)}
+
+		gomock.InOrder(
+			repository.EXPECT().GetPendingAccrualAdjustments(ctx, 0, 2).Return(nil, nil),
+			repository.EXPECT().GetAccrualAdjustmentsCursor(ctx, worker.lookback).Return(0, nil),
+			applyIDs(ctx, 1, 3).Times(2),
+		)
+
+		applied, err := worker.applyAdjustments(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+
+		// 1 and 3 were seen within the lookback, so the feed is read from the
+		// same cursor and 2 is applied once it commits.
+		committed = []domain.AccrualAdjustment{adjustment(1), adjustment(2), adjustment(3)}
+
+		gomock.InOrder(
+			repository.EXPECT().GetPendingAccrualAdjustments(ctx, 0, 2).Return(nil, nil),
+			repository.EXPECT().GetAccrualAdjustmentsCursor(ctx, worker.lookback).Return(0, nil),
+			applyIDs(ctx, 2).Times(3),
+		)
+
+		applied, err = worker.applyAdjustments(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("valid (pending adjustments first)", func(t *testing.T) {
+		ctx := context.Background()
+		committed = []domain.AccrualAdjustment{adjustment(1), adjustment(2), adjustment(3)}
+
+		gomock.InOrder(
+			repository.
+				EXPECT().
+				GetPendingAccrualAdjustments(ctx, 0, 2).
+				Return([]domain.AccrualAdjustment{adjustment(1), adjustment(2)}, nil),
+			applyIDs(ctx, 2).Times(2),
+			repository.EXPECT().GetPendingAccrualAdjustments(ctx, 2, 2).Return(nil, nil),
+			repository.EXPECT().GetAccrualAdjustmentsCursor(ctx, worker.lookback).Return(3, nil),
+		)
+
+		applied, err := worker.applyAdjustments(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("invalid (repository error)", func(t *testing.T) {
+		ctx := context.Background()
+		committed = []domain.AccrualAdjustment{adjustment(1), adjustment(2), adjustment(3)}
+
+		gomock.InOrder(
+			repository.EXPECT().GetPendingAccrualAdjustments(ctx, 0, 2).Return(nil, nil),
+			repository.EXPECT().GetAccrualAdjustmentsCursor(ctx, worker.lookback).Return(2, nil),
+			repository.
+				EXPECT().
+				ApplyAccrualAdjustment(ctx, gomock.Any(), gomock.Any()).
+				Return(nil, fmt.Errorf("random error")),
+		)
+
+		applied, err := worker.applyAdjustments(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, applied)
+	})
+}
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+)
+
+// accrualClient calls the accrual system on behalf of gophermart.
+type accrualClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+func newAccrualClient(baseURL string, apiKey string) *accrualClient {
+	return &accrualClient{
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+		baseURL: baseURL,
+		apiKey:  apiKey,
+	}
+}
+
+// get decodes the response to a GET of path into responseBody. merchant is
+// sent in the merchant header unless it is empty. A 429 response is returned
+// as domain.RetryAfterError.
+func (c *accrualClient) get(path string, merchant string, responseBody any) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set(domain.APIKeyHeader, c.apiKey)
+	}
+
+	if merchant != "" {
+		req.Header.Set(domain.MerchantHeader, merchant)
+	}
+
+	response, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode > 299 {
+		if response.StatusCode == http.StatusTooManyRequests {
+			var retryAfter int
+			retryAfter, err := strconv.Atoi(response.Header.Get("Retry-After"))
+
+			if err != nil || retryAfter <= 0 {
+				retryAfter = 60
+			}
+
+			return domain.RetryAfterError{Seconds: retryAfter}
+		}
+
+		body, err := io.ReadAll(response.Body)
+
+		if err != nil {
+			return err
+		}
+
+		return errors.New("not success response" + string(body))
+	}
+
+	return json.NewDecoder(response.Body).Decode(responseBody)
+}
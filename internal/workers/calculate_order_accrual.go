@@ -4,22 +4,25 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math"
-	"strings"
 	"time"
 
+	"github.com/MowlCoder/accumulative-loyalty-system/internal/calculator"
 	"github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 )
 
 type registeredOrdersRepository interface {
 	TakeOrdersForProcessing(ctx context.Context, limit int) ([]domain.RegisteredOrder, error)
-	ChangeOrdersStatus(ctx context.Context, orderIDs []string, status string) error
-	GetOrderGoods(ctx context.Context, orderID string) ([]domain.OrderGood, error)
-	SetCalculatedOrderAccrual(ctx context.Context, orderID string, accrual float64) error
+	ChangeOrdersStatus(ctx context.Context, orders []domain.RegisteredOrder, status string) error
+	GetOrderGoods(ctx context.Context, merchantID int, orderID string) ([]domain.OrderGood, error)
+	SetCalculatedOrderAccrual(
+		ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown,
+	) error
 }
 
 type goodRewardRepository interface {
-	GetRewardsWithMatches(ctx context.Context, descriptions []string) ([]domain.GoodReward, error)
+	GetRewardsWithMatches(
+		ctx context.Context, merchantID int, goods []domain.OrderGood, at time.Time, asOf time.Time,
+	) ([]domain.GoodReward, error)
 }
 
 type CalculateOrderAccrualWorker struct {
@@ -55,13 +58,7 @@ func (w *CalculateOrderAccrualWorker) Start(ctx context.Context) {
 				continue
 			}
 
-			ids := make([]string, len(orders))
-
-			for i := 0; i < len(ids); i++ {
-				ids[i] = orders[i].OrderID
-			}
-
-			err = w.registeredOrdersRepository.ChangeOrdersStatus(ctx, ids, domain.ProcessingOrderStatus)
+			err = w.registeredOrdersRepository.ChangeOrdersStatus(ctx, orders, domain.ProcessingOrderStatus)
 
 			if err != nil {
 				log.Println("[calculate_order_accrual]: change orders status", err)
@@ -86,42 +83,23 @@ func (w *CalculateOrderAccrualWorker) processOrder(ctx context.Context, order *d
 		return ErrNilPointerToOrder
 	}
 
-	goods, err := w.registeredOrdersRepository.GetOrderGoods(ctx, order.OrderID)
+	goods, err := w.registeredOrdersRepository.GetOrderGoods(ctx, order.MerchantID, order.OrderID)
 
 	if err != nil {
 		return fmt.Errorf("get order goods %w", err)
 	}
 
-	descriptions := make([]string, len(goods))
-
-	for i := 0; i < len(descriptions); i++ {
-		descriptions[i] = goods[i].Description
-	}
-
-	rewards, err := w.goodRewardRepository.GetRewardsWithMatches(ctx, descriptions)
+	// Only the rules of the merchant the order was registered with apply, in
+	// the versions in force when the goods were bought rather than now. Quotes
+	// are calculated the same way, see calculator.CalculateOrder.
+	at := order.EvaluatedAt()
+	breakdown, err := calculator.CalculateOrder(ctx, w.goodRewardRepository, order.MerchantID, goods, at, at)
 
 	if err != nil {
-		return fmt.Errorf("get rewards with matches %w", err)
-	}
-
-	var accrual float64
-
-	for _, good := range goods {
-		for _, reward := range rewards {
-			if !strings.Contains(good.Description, reward.Match) {
-				continue
-			}
-
-			switch reward.RewardType {
-			case domain.PercentRewardType:
-				accrual += good.Price * (reward.Reward / 100)
-			case domain.PointRewardType:
-				accrual += reward.Reward
-			}
-		}
+		return err
 	}
 
-	err = w.registeredOrdersRepository.SetCalculatedOrderAccrual(ctx, order.OrderID, math.Round(accrual*100)/100)
+	err = w.registeredOrdersRepository.SetCalculatedOrderAccrual(ctx, order.MerchantID, order.OrderID, breakdown)
 
 	if err != nil {
 		return fmt.Errorf("set calculated order accrual %w", err)
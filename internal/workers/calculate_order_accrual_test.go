@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -19,29 +20,75 @@ func TestCalculateOrderAccrualWorker_processOrder(t *testing.T) {
 
 	worker := NewCalculateOrderAccrualWorker(registeredOrdersRepo, goodRewardRepo)
 
-	t.Run("valid", func(t *testing.T) {
+	t.Run("valid (rules as of the purchase)", func(t *testing.T) {
 		ctx := context.Background()
+		purchasedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
 		order := domain.RegisteredOrder{
-			OrderID: "123",
+			OrderID:     "123",
+			MerchantID:  1,
+			PurchasedAt: &purchasedAt,
+			CreatedAt:   purchasedAt.Add(time.Hour),
 		}
+		goods := []domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(100)}}
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetOrderGoods(ctx, order.OrderID).
-			Return([]domain.OrderGood{{Description: "Bork", Price: 100.00}}, nil)
+			GetOrderGoods(ctx, order.MerchantID, order.OrderID).
+			Return(goods, nil)
 		goodRewardRepo.
 			EXPECT().
-			GetRewardsWithMatches(ctx, []string{"Bork"}).
-			Return([]domain.GoodReward{{Match: "Bork", RewardType: domain.PercentRewardType, Reward: 10}}, nil)
+			GetRewardsWithMatches(ctx, order.MerchantID, goods, purchasedAt, purchasedAt).
+			Return([]domain.GoodReward{{ID: 7, Version: 2, Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10)}}, nil)
 		registeredOrdersRepo.
 			EXPECT().
-			SetCalculatedOrderAccrual(ctx, order.OrderID, 10.0).
+			SetCalculatedOrderAccrual(ctx, order.MerchantID, order.OrderID, &domain.AccrualBreakdown{
+				Accrual: domain.MoneyFromInt(10),
+				Goods: []domain.GoodBreakdown{{
+					OrderGood: goods[0],
+					Accrual:   domain.MoneyFromInt(10),
+					Rewards: []domain.RuleContribution{
+						{RuleID: 7, RuleVersion: 2, RewardType: domain.PercentRewardType, Amount: domain.MoneyFromInt(10)},
+					},
+				}},
+				Order: []domain.RuleContribution{},
+			}).
 			Return(nil)
 
 		err := worker.processOrder(ctx, &order)
 		assert.NoError(t, err)
 	})
 
+	t.Run("valid (prefiltered rule does not match)", func(t *testing.T) {
+		ctx := context.Background()
+		order := domain.RegisteredOrder{
+			OrderID:    "124",
+			MerchantID: 1,
+		}
+		goods := []domain.OrderGood{{Description: "Borkland kettle", Price: domain.MoneyFromInt(100)}}
+
+		registeredOrdersRepo.
+			EXPECT().
+			GetOrderGoods(ctx, order.MerchantID, order.OrderID).
+			Return(goods, nil)
+		goodRewardRepo.
+			EXPECT().
+			GetRewardsWithMatches(ctx, order.MerchantID, goods, order.EvaluatedAt(), order.EvaluatedAt()).
+			Return([]domain.GoodReward{
+				{ID: 1, Match: "Bork", MatchType: domain.WordMatchType, RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10)},
+				{ID: 2, Match: "KETTLE", MatchType: domain.WordMatchType, IgnoreCase: true, RewardType: domain.PointRewardType, Reward: domain.MoneyFromInt(5)},
+			}, nil)
+		registeredOrdersRepo.
+			EXPECT().
+			SetCalculatedOrderAccrual(ctx, order.MerchantID, order.OrderID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, _ string, breakdown *domain.AccrualBreakdown) error {
+				assert.Equal(t, domain.MoneyFromInt(5), breakdown.Accrual)
+				return nil
+			})
+
+		err := worker.processOrder(ctx, &order)
+		assert.NoError(t, err)
+	})
+
 	t.Run("invalid (nil order)", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -52,12 +99,13 @@ func TestCalculateOrderAccrualWorker_processOrder(t *testing.T) {
 	t.Run("invalid (get orders good error)", func(t *testing.T) {
 		ctx := context.Background()
 		order := domain.RegisteredOrder{
-			OrderID: "123",
+			OrderID:    "123",
+			MerchantID: 1,
 		}
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetOrderGoods(ctx, order.OrderID).
+			GetOrderGoods(ctx, order.MerchantID, order.OrderID).
 			Return(nil, fmt.Errorf("random error"))
 
 		err := worker.processOrder(ctx, &order)
@@ -67,16 +115,17 @@ func TestCalculateOrderAccrualWorker_processOrder(t *testing.T) {
 	t.Run("invalid (get rewards with matches error)", func(t *testing.T) {
 		ctx := context.Background()
 		order := domain.RegisteredOrder{
-			OrderID: "123",
+			OrderID:    "123",
+			MerchantID: 1,
 		}
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetOrderGoods(ctx, order.OrderID).
-			Return([]domain.OrderGood{{Description: "Bork", Price: 100.00}}, nil)
+			GetOrderGoods(ctx, order.MerchantID, order.OrderID).
+			Return([]domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(100)}}, nil)
 		goodRewardRepo.
 			EXPECT().
-			GetRewardsWithMatches(ctx, []string{"Bork"}).
+			GetRewardsWithMatches(ctx, order.MerchantID, gomock.Any(), order.EvaluatedAt(), order.EvaluatedAt()).
 			Return(nil, fmt.Errorf("random error"))
 
 		err := worker.processOrder(ctx, &order)
@@ -86,20 +135,21 @@ func TestCalculateOrderAccrualWorker_processOrder(t *testing.T) {
 	t.Run("invalid (set calculated order accrual error)", func(t *testing.T) {
 		ctx := context.Background()
 		order := domain.RegisteredOrder{
-			OrderID: "123",
+			OrderID:    "123",
+			MerchantID: 1,
 		}
 
 		registeredOrdersRepo.
 			EXPECT().
-			GetOrderGoods(ctx, order.OrderID).
-			Return([]domain.OrderGood{{Description: "Bork", Price: 100.00}}, nil)
+			GetOrderGoods(ctx, order.MerchantID, order.OrderID).
+			Return([]domain.OrderGood{{Description: "Bork", Price: domain.MoneyFromInt(100)}}, nil)
 		goodRewardRepo.
 			EXPECT().
-			GetRewardsWithMatches(ctx, []string{"Bork"}).
-			Return([]domain.GoodReward{{Match: "Bork", RewardType: domain.PercentRewardType, Reward: 10}}, nil)
+			GetRewardsWithMatches(ctx, order.MerchantID, gomock.Any(), order.EvaluatedAt(), order.EvaluatedAt()).
+			Return([]domain.GoodReward{{Match: "Bork", RewardType: domain.PercentRewardType, Reward: domain.MoneyFromInt(10)}}, nil)
 		registeredOrdersRepo.
 			EXPECT().
-			SetCalculatedOrderAccrual(ctx, order.OrderID, 10.0).
+			SetCalculatedOrderAccrual(ctx, order.MerchantID, order.OrderID, gomock.Any()).
 			Return(fmt.Errorf("random error"))
 
 		err := worker.processOrder(ctx, &order)
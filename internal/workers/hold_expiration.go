@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type balanceActionRepositoryForHolds interface {
+	ReleaseExpiredHolds(ctx context.Context, limit int) (int, error)
+}
+
+type HoldExpirationWorker struct {
+	balanceActionRepository balanceActionRepositoryForHolds
+	batchSize               int
+}
+
+func NewHoldExpirationWorker(
+	balanceActionRepository balanceActionRepositoryForHolds,
+) *HoldExpirationWorker {
+	return &HoldExpirationWorker{
+		balanceActionRepository: balanceActionRepository,
+		batchSize:               100,
+	}
+}
+
+func (w *HoldExpirationWorker) Start(ctx context.Context) {
+	log.Println("Start hold_expiration worker")
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[hold_expiration]: complete")
+			return
+		case <-ticker.C:
+			released, err := w.releaseExpiredHolds(ctx)
+
+			if err != nil {
+				log.Println("[hold_expiration]: release expired holds", err)
+			}
+
+			if released > 0 {
+				log.Println("[hold_expiration]: released", released, "holds")
+			}
+		}
+	}
+}
+
+// releaseExpiredHolds keeps releasing batches until no expired hold is left.
+func (w *HoldExpirationWorker) releaseExpiredHolds(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		released, err := w.balanceActionRepository.ReleaseExpiredHolds(ctx, w.batchSize)
+		total += released
+
+		if err != nil {
+			return total, err
+		}
+
+		if released < w.batchSize {
+			return total, nil
+		}
+	}
+}
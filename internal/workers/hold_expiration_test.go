@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/workers/mocks"
+)
+
+func TestHoldExpirationWorker_releaseExpiredHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForHolds(ctrl)
+
+	worker := NewHoldExpirationWorker(balanceActionRepo)
+	worker.batchSize = 2
+
+	t.Run("valid (several batches)", func(t *testing.T) {
+		ctx := context.Background()
+
+		gomock.InOrder(
+			balanceActionRepo.EXPECT().ReleaseExpiredHolds(ctx, 2).Return(2, nil),
+			balanceActionRepo.EXPECT().ReleaseExpiredHolds(ctx, 2).Return(1, nil),
+		)
+
+		released, err := worker.releaseExpiredHolds(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, released)
+	})
+
+	t.Run("invalid (repository error)", func(t *testing.T) {
+		ctx := context.Background()
+
+		balanceActionRepo.EXPECT().ReleaseExpiredHolds(ctx, 2).Return(1, fmt.Errorf("random error"))
+
+		released, err := worker.releaseExpiredHolds(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 1, released)
+	})
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accrual_adjustments.go
+//
+// Generated by this command:
+//
+//	mockgen -source=accrual_adjustments.go -destination=./mocks/accrual_adjustments.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockaccrualAdjustmentsRepository is a mock of accrualAdjustmentsRepository interface.
+type MockaccrualAdjustmentsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccrualAdjustmentsRepositoryMockRecorder
+}
+
+// MockaccrualAdjustmentsRepositoryMockRecorder is the mock recorder for MockaccrualAdjustmentsRepository.
+type MockaccrualAdjustmentsRepositoryMockRecorder struct {
+	mock *MockaccrualAdjustmentsRepository
+}
+
+// NewMockaccrualAdjustmentsRepository creates a new mock instance.
+func NewMockaccrualAdjustmentsRepository(ctrl *gomock.Controller) *MockaccrualAdjustmentsRepository {
+	mock := &MockaccrualAdjustmentsRepository{ctrl: ctrl}
+	mock.recorder = &MockaccrualAdjustmentsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccrualAdjustmentsRepository) EXPECT() *MockaccrualAdjustmentsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyAccrualAdjustment mocks base method.
+func (m *MockaccrualAdjustmentsRepository) ApplyAccrualAdjustment(ctx context.Context, adjustment domain.AccrualAdjustment, pointsExpireAt time.Time) (*domain.BalanceAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAccrualAdjustment", ctx, adjustment, pointsExpireAt)
+	ret0, _ := ret[0].(*domain.BalanceAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyAccrualAdjustment indicates an expected call of ApplyAccrualAdjustment.
+func (mr *MockaccrualAdjustmentsRepositoryMockRecorder) ApplyAccrualAdjustment(ctx, adjustment, pointsExpireAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAccrualAdjustment", reflect.TypeOf((*MockaccrualAdjustmentsRepository)(nil).ApplyAccrualAdjustment), ctx, adjustment, pointsExpireAt)
+}
+
+// GetAccrualAdjustmentsCursor mocks base method.
+func (m *MockaccrualAdjustmentsRepository) GetAccrualAdjustmentsCursor(ctx context.Context, lookback time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccrualAdjustmentsCursor", ctx, lookback)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccrualAdjustmentsCursor indicates an expected call of GetAccrualAdjustmentsCursor.
+func (mr *MockaccrualAdjustmentsRepositoryMockRecorder) GetAccrualAdjustmentsCursor(ctx, lookback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccrualAdjustmentsCursor", reflect.TypeOf((*MockaccrualAdjustmentsRepository)(nil).GetAccrualAdjustmentsCursor), ctx, lookback)
+}
+
+// GetPendingAccrualAdjustments mocks base method.
+func (m *MockaccrualAdjustmentsRepository) GetPendingAccrualAdjustments(ctx context.Context, after, limit int) ([]domain.AccrualAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAccrualAdjustments", ctx, after, limit)
+	ret0, _ := ret[0].([]domain.AccrualAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAccrualAdjustments indicates an expected call of GetPendingAccrualAdjustments.
+func (mr *MockaccrualAdjustmentsRepositoryMockRecorder) GetPendingAccrualAdjustments(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAccrualAdjustments", reflect.TypeOf((*MockaccrualAdjustmentsRepository)(nil).GetPendingAccrualAdjustments), ctx, after, limit)
+}
@@ -11,6 +11,7 @@ package repomock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/MowlCoder/accumulative-loyalty-system/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -40,46 +41,46 @@ func (m *MockregisteredOrdersRepository) EXPECT() *MockregisteredOrdersRepositor
 }
 
 // ChangeOrdersStatus mocks base method.
-func (m *MockregisteredOrdersRepository) ChangeOrdersStatus(ctx context.Context, orderIDs []string, status string) error {
+func (m *MockregisteredOrdersRepository) ChangeOrdersStatus(ctx context.Context, orders []domain.RegisteredOrder, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeOrdersStatus", ctx, orderIDs, status)
+	ret := m.ctrl.Call(m, "ChangeOrdersStatus", ctx, orders, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ChangeOrdersStatus indicates an expected call of ChangeOrdersStatus.
-func (mr *MockregisteredOrdersRepositoryMockRecorder) ChangeOrdersStatus(ctx, orderIDs, status any) *gomock.Call {
+func (mr *MockregisteredOrdersRepositoryMockRecorder) ChangeOrdersStatus(ctx, orders, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrdersStatus", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).ChangeOrdersStatus), ctx, orderIDs, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrdersStatus", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).ChangeOrdersStatus), ctx, orders, status)
 }
 
 // GetOrderGoods mocks base method.
-func (m *MockregisteredOrdersRepository) GetOrderGoods(ctx context.Context, orderID string) ([]domain.OrderGood, error) {
+func (m *MockregisteredOrdersRepository) GetOrderGoods(ctx context.Context, merchantID int, orderID string) ([]domain.OrderGood, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderGoods", ctx, orderID)
+	ret := m.ctrl.Call(m, "GetOrderGoods", ctx, merchantID, orderID)
 	ret0, _ := ret[0].([]domain.OrderGood)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrderGoods indicates an expected call of GetOrderGoods.
-func (mr *MockregisteredOrdersRepositoryMockRecorder) GetOrderGoods(ctx, orderID any) *gomock.Call {
+func (mr *MockregisteredOrdersRepositoryMockRecorder) GetOrderGoods(ctx, merchantID, orderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderGoods", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetOrderGoods), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderGoods", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).GetOrderGoods), ctx, merchantID, orderID)
 }
 
 // SetCalculatedOrderAccrual mocks base method.
-func (m *MockregisteredOrdersRepository) SetCalculatedOrderAccrual(ctx context.Context, orderID string, accrual float64) error {
+func (m *MockregisteredOrdersRepository) SetCalculatedOrderAccrual(ctx context.Context, merchantID int, orderID string, breakdown *domain.AccrualBreakdown) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetCalculatedOrderAccrual", ctx, orderID, accrual)
+	ret := m.ctrl.Call(m, "SetCalculatedOrderAccrual", ctx, merchantID, orderID, breakdown)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetCalculatedOrderAccrual indicates an expected call of SetCalculatedOrderAccrual.
-func (mr *MockregisteredOrdersRepositoryMockRecorder) SetCalculatedOrderAccrual(ctx, orderID, accrual any) *gomock.Call {
+func (mr *MockregisteredOrdersRepositoryMockRecorder) SetCalculatedOrderAccrual(ctx, merchantID, orderID, breakdown any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalculatedOrderAccrual", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).SetCalculatedOrderAccrual), ctx, orderID, accrual)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalculatedOrderAccrual", reflect.TypeOf((*MockregisteredOrdersRepository)(nil).SetCalculatedOrderAccrual), ctx, merchantID, orderID, breakdown)
 }
 
 // TakeOrdersForProcessing mocks base method.
@@ -121,16 +122,16 @@ func (m *MockgoodRewardRepository) EXPECT() *MockgoodRewardRepositoryMockRecorde
 }
 
 // GetRewardsWithMatches mocks base method.
-func (m *MockgoodRewardRepository) GetRewardsWithMatches(ctx context.Context, descriptions []string) ([]domain.GoodReward, error) {
+func (m *MockgoodRewardRepository) GetRewardsWithMatches(ctx context.Context, merchantID int, goods []domain.OrderGood, at, asOf time.Time) ([]domain.GoodReward, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRewardsWithMatches", ctx, descriptions)
+	ret := m.ctrl.Call(m, "GetRewardsWithMatches", ctx, merchantID, goods, at, asOf)
 	ret0, _ := ret[0].([]domain.GoodReward)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRewardsWithMatches indicates an expected call of GetRewardsWithMatches.
-func (mr *MockgoodRewardRepositoryMockRecorder) GetRewardsWithMatches(ctx, descriptions any) *gomock.Call {
+func (mr *MockgoodRewardRepositoryMockRecorder) GetRewardsWithMatches(ctx, merchantID, goods, at, asOf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardsWithMatches", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetRewardsWithMatches), ctx, descriptions)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardsWithMatches", reflect.TypeOf((*MockgoodRewardRepository)(nil).GetRewardsWithMatches), ctx, merchantID, goods, at, asOf)
 }
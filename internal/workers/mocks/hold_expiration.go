@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: hold_expiration.go
+//
+// Generated by this command:
+//
+//	mockgen -source=hold_expiration.go -destination=./mocks/hold_expiration.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbalanceActionRepositoryForHolds is a mock of balanceActionRepositoryForHolds interface.
+type MockbalanceActionRepositoryForHolds struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionRepositoryForHoldsMockRecorder
+}
+
+// MockbalanceActionRepositoryForHoldsMockRecorder is the mock recorder for MockbalanceActionRepositoryForHolds.
+type MockbalanceActionRepositoryForHoldsMockRecorder struct {
+	mock *MockbalanceActionRepositoryForHolds
+}
+
+// NewMockbalanceActionRepositoryForHolds creates a new mock instance.
+func NewMockbalanceActionRepositoryForHolds(ctrl *gomock.Controller) *MockbalanceActionRepositoryForHolds {
+	mock := &MockbalanceActionRepositoryForHolds{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionRepositoryForHoldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionRepositoryForHolds) EXPECT() *MockbalanceActionRepositoryForHoldsMockRecorder {
+	return m.recorder
+}
+
+// ReleaseExpiredHolds mocks base method.
+func (m *MockbalanceActionRepositoryForHolds) ReleaseExpiredHolds(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredHolds", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredHolds indicates an expected call of ReleaseExpiredHolds.
+func (mr *MockbalanceActionRepositoryForHoldsMockRecorder) ReleaseExpiredHolds(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredHolds", reflect.TypeOf((*MockbalanceActionRepositoryForHolds)(nil).ReleaseExpiredHolds), ctx, limit)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: points_expiration.go
+//
+// Generated by this command:
+//
+//	mockgen -source=points_expiration.go -destination=./mocks/points_expiration.go -package=repomock
+//
+// Package repomock is a generated GoMock package.
+package repomock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockbalanceActionRepositoryForExpiration is a mock of balanceActionRepositoryForExpiration interface.
+type MockbalanceActionRepositoryForExpiration struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceActionRepositoryForExpirationMockRecorder
+}
+
+// MockbalanceActionRepositoryForExpirationMockRecorder is the mock recorder for MockbalanceActionRepositoryForExpiration.
+type MockbalanceActionRepositoryForExpirationMockRecorder struct {
+	mock *MockbalanceActionRepositoryForExpiration
+}
+
+// NewMockbalanceActionRepositoryForExpiration creates a new mock instance.
+func NewMockbalanceActionRepositoryForExpiration(ctrl *gomock.Controller) *MockbalanceActionRepositoryForExpiration {
+	mock := &MockbalanceActionRepositoryForExpiration{ctrl: ctrl}
+	mock.recorder = &MockbalanceActionRepositoryForExpirationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceActionRepositoryForExpiration) EXPECT() *MockbalanceActionRepositoryForExpirationMockRecorder {
+	return m.recorder
+}
+
+// ExpireAccrualLots mocks base method.
+func (m *MockbalanceActionRepositoryForExpiration) ExpireAccrualLots(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAccrualLots", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAccrualLots indicates an expected call of ExpireAccrualLots.
+func (mr *MockbalanceActionRepositoryForExpirationMockRecorder) ExpireAccrualLots(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAccrualLots", reflect.TypeOf((*MockbalanceActionRepositoryForExpiration)(nil).ExpireAccrualLots), ctx, limit)
+}
@@ -2,13 +2,9 @@ package workers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,25 +14,28 @@ import (
 
 type userOrderRepository interface {
 	TakeOrdersForProcessing(ctx context.Context) ([]domain.UserOrder, error)
-	SetOrderCalculatingResult(ctx context.Context, orderID string, status string, accrual float64) error
+	SetOrderCalculatingResult(
+		ctx context.Context, orderID string, status string, accrual domain.Money, pointsExpireAt time.Time,
+	) error
+	SetOrderBreakdown(ctx context.Context, orderID string, breakdown *domain.AccrualBreakdown) error
 }
 
 type OrderAccrualCheckingWorker struct {
 	userOrderRepository userOrderRepository
-	httpClient          *http.Client
-	baseURL             string
+	accrualClient       *accrualClient
+	pointsTTL           time.Duration
 }
 
 func NewOrderAccrualCheckingWorker(
 	userOrderRepository userOrderRepository,
 	accrualBaseURL string,
+	accrualAPIKey string,
+	pointsTTL time.Duration,
 ) *OrderAccrualCheckingWorker {
 	return &OrderAccrualCheckingWorker{
 		userOrderRepository: userOrderRepository,
-		httpClient: &http.Client{
-			Timeout: time.Second * 10,
-		},
-		baseURL: accrualBaseURL,
+		accrualClient:       newAccrualClient(accrualBaseURL, accrualAPIKey),
+		pointsTTL:           pointsTTL,
 	}
 }
 
@@ -94,7 +93,7 @@ func (w *OrderAccrualCheckingWorker) processOrder(ctx context.Context, order *do
 		return ErrNilPointerToOrder
 	}
 
-	orderInfo, err := w.getInfoFromAccrualSystem(order.OrderID)
+	orderInfo, err := w.getInfoFromAccrualSystem(order.OrderID, order.Merchant)
 
 	if err != nil {
 		return fmt.Errorf("get info from accrual system %w", err)
@@ -111,13 +110,22 @@ func (w *OrderAccrualCheckingWorker) processOrder(ctx context.Context, order *do
 			order.OrderID,
 			domain.ProcessedOrderStatus,
 			*orderInfo.Accrual,
+			time.Now().UTC().Add(w.pointsTTL),
 		)
 
 		if err != nil {
 			return fmt.Errorf("save order accrual result %w", err)
 		}
+
+		// The points are already issued, a breakdown that can not be fetched
+		// only leaves the order detail without one.
+		if err := w.saveBreakdown(ctx, order); err != nil {
+			log.Println("[checking_order_accrual]: save breakdown of order", order.OrderID, err)
+		}
 	case domain.InvalidRegisteredOrderStatus:
-		err := w.userOrderRepository.SetOrderCalculatingResult(ctx, order.OrderID, domain.InvalidOrderStatus, 0)
+		err := w.userOrderRepository.SetOrderCalculatingResult(
+			ctx, order.OrderID, domain.InvalidOrderStatus, domain.Money{}, time.Now().UTC(),
+		)
 
 		if err != nil {
 			return fmt.Errorf("set invalid order result %w", err)
@@ -127,45 +135,20 @@ func (w *OrderAccrualCheckingWorker) processOrder(ctx context.Context, order *do
 	return nil
 }
 
-func (w *OrderAccrualCheckingWorker) getInfoFromAccrualSystem(orderID string) (*AccrualOrderInfo, error) {
-	req, err := http.NewRequest(http.MethodGet, w.baseURL+"/api/orders/"+orderID, nil)
-
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := w.httpClient.Do(req)
+func (w *OrderAccrualCheckingWorker) saveBreakdown(ctx context.Context, order *domain.UserOrder) error {
+	var breakdown domain.AccrualBreakdown
 
-	if err != nil {
-		return nil, err
+	if err := w.accrualClient.get("/api/orders/"+order.OrderID+"/breakdown", order.Merchant, &breakdown); err != nil {
+		return fmt.Errorf("get breakdown from accrual system %w", err)
 	}
 
-	defer response.Body.Close()
-
-	if response.StatusCode > 299 {
-		if response.StatusCode == http.StatusTooManyRequests {
-			var retryAfter int
-			retryAfter, err := strconv.Atoi(response.Header.Get("Retry-After"))
-
-			if err != nil || retryAfter <= 0 {
-				retryAfter = 60
-			}
-
-			return nil, domain.RetryAfterError{Seconds: retryAfter}
-		}
-
-		body, err := io.ReadAll(response.Body)
-
-		if err != nil {
-			return nil, err
-		}
-
-		return nil, errors.New("not success response" + string(body))
-	}
+	return w.userOrderRepository.SetOrderBreakdown(ctx, order.OrderID, &breakdown)
+}
 
+func (w *OrderAccrualCheckingWorker) getInfoFromAccrualSystem(orderID string, merchant string) (*AccrualOrderInfo, error) {
 	var responseBody AccrualOrderInfo
 
-	if err := json.NewDecoder(response.Body).Decode(&responseBody); err != nil {
+	if err := w.accrualClient.get("/api/orders/"+orderID, merchant, &responseBody); err != nil {
 		return nil, err
 	}
 
@@ -173,9 +156,9 @@ func (w *OrderAccrualCheckingWorker) getInfoFromAccrualSystem(orderID string) (*
 }
 
 type AccrualOrderInfo struct {
-	Order   string   `json:"order"`
-	Status  string   `json:"status"`
-	Accrual *float64 `json:"accrual"`
+	Order   string        `json:"order"`
+	Status  string        `json:"status"`
+	Accrual *domain.Money `json:"accrual"`
 }
 
 var (
@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type balanceActionRepositoryForExpiration interface {
+	ExpireAccrualLots(ctx context.Context, limit int) (int, error)
+}
+
+type PointsExpirationWorker struct {
+	balanceActionRepository balanceActionRepositoryForExpiration
+	batchSize               int
+}
+
+func NewPointsExpirationWorker(
+	balanceActionRepository balanceActionRepositoryForExpiration,
+) *PointsExpirationWorker {
+	return &PointsExpirationWorker{
+		balanceActionRepository: balanceActionRepository,
+		batchSize:               100,
+	}
+}
+
+func (w *PointsExpirationWorker) Start(ctx context.Context) {
+	log.Println("Start points_expiration worker")
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[points_expiration]: complete")
+			return
+		case <-ticker.C:
+			expired, err := w.expireLots(ctx)
+
+			if err != nil {
+				log.Println("[points_expiration]: expire lots", err)
+			}
+
+			if expired > 0 {
+				log.Println("[points_expiration]: expired", expired, "lots")
+			}
+		}
+	}
+}
+
+// expireLots keeps writing off batches until no expired lot is left.
+func (w *PointsExpirationWorker) expireLots(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		expired, err := w.balanceActionRepository.ExpireAccrualLots(ctx, w.batchSize)
+		total += expired
+
+		if err != nil {
+			return total, err
+		}
+
+		if expired < w.batchSize {
+			return total, nil
+		}
+	}
+}
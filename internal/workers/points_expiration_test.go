@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	repomock "github.com/MowlCoder/accumulative-loyalty-system/internal/workers/mocks"
+)
+
+func TestPointsExpirationWorker_expireLots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	balanceActionRepo := repomock.NewMockbalanceActionRepositoryForExpiration(ctrl)
+
+	worker := NewPointsExpirationWorker(balanceActionRepo)
+	worker.batchSize = 2
+
+	t.Run("valid (several batches)", func(t *testing.T) {
+		ctx := context.Background()
+
+		gomock.InOrder(
+			balanceActionRepo.EXPECT().ExpireAccrualLots(ctx, 2).Return(2, nil),
+			balanceActionRepo.EXPECT().ExpireAccrualLots(ctx, 2).Return(1, nil),
+		)
+
+		expired, err := worker.expireLots(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, expired)
+	})
+
+	t.Run("invalid (repository error)", func(t *testing.T) {
+		ctx := context.Background()
+
+		balanceActionRepo.EXPECT().ExpireAccrualLots(ctx, 2).Return(1, fmt.Errorf("random error"))
+
+		expired, err := worker.expireLots(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 1, expired)
+	})
+}